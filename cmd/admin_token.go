@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/cobra"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/connector"
+	"go.sirus.dev/p2p-comm/signalling/pkg/server"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"go.uber.org/zap"
+)
+
+var adminName string
+var adminPermissions []string
+var adminTokenTTL time.Duration
+
+var adminTokenCmd = &cobra.Command{
+	Use:   "admin-token",
+	Short: "generate admin scoped token to access room management service",
+	Run: func(cmd *cobra.Command, args []string) {
+		conf, err := LoadConfig()
+		if err != nil {
+			log.Fatalf("Error loading configurations %v", err)
+		}
+		keys, err := auth.LoadKeySet(conf.AccessSecret, conf.ActiveSigningKey, *conf.SigningKeys)
+		if err != nil {
+			log.Fatalf("Error loading signing keys %v", err)
+		}
+		tokenAPI := auth.NewAPI(nil, zap.NewNop().Sugar(), keys,
+			conf.AccessTokenTTL, conf.RefreshTokenTTL,
+		)
+		claims := utils.Claims{
+			server.ScopeKey:       server.AdminScope,
+			server.AdminNameKey:   adminName,
+			server.PermissionsKey: adminPermissions,
+		}
+		token, tokenID, expiresAt, err := tokenAPI.GenerateAdminToken(claims, adminTokenTTL)
+		if err != nil {
+			log.Fatalf("Error generating token %v", err)
+		}
+		log.Printf("token %s expires at %s", tokenID, expiresAt.Format(time.RFC3339))
+		fmt.Println(*token)
+	},
+}
+
+var revokeAdminTokenCmd = &cobra.Command{
+	Use:   "revoke [token]",
+	Short: "revoke admin token before it's expiration time",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		conf, err := LoadConfig()
+		if err != nil {
+			log.Fatalf("Error loading configurations %v", err)
+		}
+		keys, err := auth.LoadKeySet(conf.AccessSecret, conf.ActiveSigningKey, *conf.SigningKeys)
+		if err != nil {
+			log.Fatalf("Error loading signing keys %v", err)
+		}
+		db, err := connector.ConnectToPostgres(conf.Postgres, auth.Models)
+		if err != nil {
+			log.Fatalf("Error connecting to postgres %v", err)
+		}
+		defer db.Close()
+		nc, err := nats.Connect(conf.NatsURL)
+		if err != nil {
+			log.Fatalf("Error connecting to nats %v", err)
+		}
+		natsConn, err := nats.NewEncodedConn(nc, nats.JSON_ENCODER)
+		if err != nil {
+			log.Fatalf("Error encoding nats connection %v", err)
+		}
+		defer natsConn.Close()
+		tokenAPI := auth.NewAPI(db, zap.NewNop().Sugar(), keys,
+			conf.AccessTokenTTL, conf.RefreshTokenTTL,
+		)
+		claims, err := tokenAPI.ValidateAdminToken(context.Background(), args[0])
+		if err != nil {
+			log.Fatalf("Error validating token %v", err)
+		}
+		tokenID, _ := claims[auth.TokenIDKey].(string)
+		expiresAt, _ := claims[auth.ExpiresAtKey].(float64)
+		// tell running instances to reject the token
+		revocations := make(chan *auth.Revocation, 1)
+		tokenAPI.SetRevocations(revocations)
+		err = tokenAPI.RevokeToken("", tokenID, time.Unix(int64(expiresAt), 0))
+		if err != nil {
+			log.Fatalf("Error revoking token %v", err)
+		}
+		err = natsConn.Publish(conf.EventNamespace+"."+auth.TokenRevoked, <-revocations)
+		if err != nil {
+			log.Fatalf("Error publishing revocation %v", err)
+		}
+		err = natsConn.Flush()
+		if err != nil {
+			log.Fatalf("Error publishing revocation %v", err)
+		}
+		fmt.Printf("token %s revoked\n", tokenID)
+	},
+}
+
+func init() {
+	adminTokenCmd.Flags().StringVar(&adminName, "name", "admin", "name of admin identity")
+	adminTokenCmd.Flags().StringSliceVar(&adminPermissions, "permissions", []string{
+		server.PermissionRead,
+		server.PermissionManageUser,
+		server.PermissionManageRoom,
+	}, "permissions granted to admin")
+	adminTokenCmd.Flags().DurationVar(&adminTokenTTL, "ttl", time.Hour*24, "how long admin token valid")
+	adminTokenCmd.AddCommand(revokeAdminTokenCmd)
+	rootCmd.AddCommand(adminTokenCmd)
+}
@@ -3,24 +3,46 @@ package cmd
 import (
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/fatih/structs"
 	nats "github.com/nats-io/nats.go"
 	"github.com/spf13/viper"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/call"
 	"go.sirus.dev/p2p-comm/signalling/pkg/connector"
+	"go.sirus.dev/p2p-comm/signalling/pkg/ratelimit"
+	"go.sirus.dev/p2p-comm/signalling/pkg/server"
 	"go.sirus.dev/p2p-comm/signalling/pkg/signaling"
 )
 
 // Config define service configuration structure
 type Config struct {
-	LogLevel        string                    `mapstructure:"log_level"`
-	Postgres        *connector.PostgresConfig `mapstructure:"postgres"`
-	SignalingPort   int                       `mapstructure:"signaling_port"`
-	RoomManagerPort int                       `mapstructure:"room_manger_port"`
-	EventNamespace  string                    `mapstructure:"event_namespace"`
-	AccessSecret    string                    `mapstructure:"access_secret"`
-	NatsURL         string                    `mapstructure:"nats_url"`
-	ICEServers      *[]signaling.ICEServer    `mapstructure:"ice_servers"`
+	LogLevel          string                     `mapstructure:"log_level"`
+	Postgres          *connector.PostgresConfig  `mapstructure:"postgres"`
+	SignalingPort     int                        `mapstructure:"signaling_port"`
+	RoomManagerPort   int                        `mapstructure:"room_manger_port"`
+	SignalingTLS      *server.TLSConfig          `mapstructure:"signaling_tls"`
+	RoomManagerTLS    *server.TLSConfig          `mapstructure:"room_manager_tls"`
+	JWKSPort          int                        `mapstructure:"jwks_port"`
+	EventNamespace    string                     `mapstructure:"event_namespace"`
+	AccessSecret      string                     `mapstructure:"access_secret"`
+	AccessTokenTTL    time.Duration              `mapstructure:"access_token_ttl"`
+	RefreshTokenTTL   time.Duration              `mapstructure:"refresh_token_ttl"`
+	SigningKeys       *[]auth.KeyConfig          `mapstructure:"signing_keys"`
+	ActiveSigningKey  string                     `mapstructure:"active_signing_key"`
+	NatsURL           string                     `mapstructure:"nats_url"`
+	ICEServers        *[]signaling.ICEServer     `mapstructure:"ice_servers"`
+	AdminKeys         *[]server.AdminKey         `mapstructure:"admin_keys"`
+	AdminCertificates *[]server.AdminCertificate `mapstructure:"admin_certificates"`
+	RateLimit         *ratelimit.Config          `mapstructure:"rate_limit"`
+	PendingQueue      *signaling.QueueConfig     `mapstructure:"pending_queue"`
+	CallRingTimeout   time.Duration              `mapstructure:"call_ring_timeout"`
+	// how long user stay online after it's last session ended
+	PresenceGracePeriod time.Duration `mapstructure:"presence_grace_period"`
+	// how long instance counted alive without renewing it's lease,
+	// users of dead instance set offline after it
+	InstanceLease time.Duration `mapstructure:"instance_lease"`
 }
 
 // DefaultConfig is default configuration
@@ -29,14 +51,45 @@ var DefaultConfig = Config{
 	Postgres:        connector.DefaultPostgresConfig,
 	SignalingPort:   8053,
 	RoomManagerPort: 8052,
+	SignalingTLS:    &server.TLSConfig{},
+	RoomManagerTLS:  &server.TLSConfig{},
+	JWKSPort:        8054,
 	EventNamespace:  "qh",
 	NatsURL:         nats.DefaultOptions.Url,
 	AccessSecret:    "access-secret",
+	AccessTokenTTL:  time.Minute * 15,
+	RefreshTokenTTL: time.Hour * 24 * 7,
+	SigningKeys:     &[]auth.KeyConfig{},
 	ICEServers: &[]signaling.ICEServer{
 		{URL: "stun:stun.l.google.com:19302"},
 		{URL: "stun:stun.fwdnet.net"},
 		{URL: "stun:stunserver.org"},
 	},
+	AdminKeys:         &[]server.AdminKey{},
+	AdminCertificates: &[]server.AdminCertificate{},
+	RateLimit: &ratelimit.Config{
+		Store: ratelimit.MemoryStore,
+		Methods: []ratelimit.MethodLimit{
+			{Method: "OfferSessionDescription", Rate: 5, Burst: 20},
+			{Method: "AnswerSessionDescription", Rate: 5, Burst: 20},
+			{Method: "SendSessionDescription", Rate: 5, Burst: 20},
+			{Method: "SendICECandidate", Rate: 20, Burst: 100},
+			{Method: "SendSignal", Rate: 10, Burst: 50},
+			{Method: "StartCall", Rate: 1, Burst: 5},
+			{Method: "SetPresence", Rate: 1, Burst: 10},
+		},
+		PerIP:       ratelimit.Limit{Rate: 50, Burst: 200},
+		MaxStreams:  20,
+		StreamLease: time.Minute,
+	},
+	PendingQueue: &signaling.QueueConfig{
+		TTL:               time.Second * 30,
+		Size:              100,
+		SubscriptionLease: time.Minute,
+	},
+	CallRingTimeout:     call.DefaultRingTimeout,
+	PresenceGracePeriod: signaling.DefaultPresenceGracePeriod,
+	InstanceLease:       signaling.DefaultInstanceLease,
 }
 
 // String implement string interface
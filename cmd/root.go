@@ -7,7 +7,10 @@ import (
 
 	"github.com/nats-io/nats.go"
 	"github.com/spf13/cobra"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/call"
 	"go.sirus.dev/p2p-comm/signalling/pkg/connector"
+	"go.sirus.dev/p2p-comm/signalling/pkg/ratelimit"
 	"go.sirus.dev/p2p-comm/signalling/pkg/room"
 	"go.sirus.dev/p2p-comm/signalling/pkg/server"
 	"go.sirus.dev/p2p-comm/signalling/pkg/signaling"
@@ -34,6 +37,10 @@ var rootCmd = &cobra.Command{
 		// connect to postgres
 		models := []interface{}{}
 		models = append(models, room.Models...)
+		models = append(models, auth.Models...)
+		models = append(models, ratelimit.Models...)
+		models = append(models, signaling.Models...)
+		models = append(models, call.Models...)
 		db, err := connector.ConnectToPostgres(conf.Postgres, models)
 		if err != nil {
 			logger.Fatalf("failed to open postgres -> %v", err)
@@ -53,23 +60,55 @@ var rootCmd = &cobra.Command{
 		}
 		logger.Info("nats connected")
 
-		// instantiacte room manager and signaling API
-		roomManagerAPI := room.NewAPI(db, logger, conf.AccessSecret)
-		signalingAPI := signaling.NewAPI(db, logger, conf.ICEServers)
+		// instantiacte token, room manager and signaling API
+		keys, err := auth.LoadKeySet(conf.AccessSecret, conf.ActiveSigningKey, *conf.SigningKeys)
+		if err != nil {
+			logger.Fatalf("failed to load signing keys -> %v", err)
+		}
+		tokenAPI := auth.NewAPI(db, logger, keys,
+			conf.AccessTokenTTL, conf.RefreshTokenTTL,
+		)
+		err = tokenAPI.LoadRevocations()
+		if err != nil {
+			logger.Fatalf("failed to load token revocations -> %v", err)
+		}
+		roomManagerAPI := room.NewAPI(db, logger, tokenAPI)
+		signalingAPI := signaling.NewAPI(db, logger, conf.ICEServers, conf.PendingQueue, conf.PresenceGracePeriod)
+		callAPI := call.NewAPI(db, logger, signalingAPI, conf.CallRingTimeout)
+
+		// register instance so users of crashed instance set offline by other instances
+		releaseInstance, err := signalingAPI.KeepInstance(conf.InstanceLease)
+		if err != nil {
+			logger.Fatalf("failed to register instance -> %v", err)
+		}
+		defer releaseInstance()
+
+		// close calls left ringing by crashed instances or active without participant
+		stopSweeping := callAPI.KeepSweeping(call.DefaultSweepInterval)
+		defer stopSweeping()
+
+		// setup rate limiter, share limits between instances using database
+		var limitStore ratelimit.IStore = ratelimit.NewMemoryStore()
+		if conf.RateLimit.Store == ratelimit.DatabaseStore {
+			limitStore = ratelimit.NewDatabaseStore(db)
+		}
+		limiter := ratelimit.New(limitStore, *conf.RateLimit)
 
 		// create services
 		roomManagerSvc := server.NewRoomManagementService(
 			roomManagerAPI, logger, natsConn,
-			conf.EventNamespace, conf.AccessSecret,
+			conf.EventNamespace, tokenAPI,
+			*conf.AdminKeys, *conf.AdminCertificates,
 		)
 		signalingSvc := server.NewSignalingService(signalingAPI, logger, natsConn,
-			conf.EventNamespace, conf.AccessSecret,
+			conf.EventNamespace, tokenAPI, limiter, callAPI,
 		)
 
 		// create server
 		svc := server.New(
-			signalingSvc, conf.SignalingPort,
-			roomManagerSvc, conf.RoomManagerPort,
+			signalingSvc, conf.SignalingPort, conf.SignalingTLS,
+			roomManagerSvc, conf.RoomManagerPort, conf.RoomManagerTLS,
+			conf.JWKSPort,
 		)
 		go func() {
 			logger.Infof("start room manager server on port %d", conf.RoomManagerPort)
@@ -78,6 +117,13 @@ var rootCmd = &cobra.Command{
 				logger.Errorf("failed to start room manager server", err)
 			}
 		}()
+		go func() {
+			logger.Infof("start JWKS server on port %d", conf.JWKSPort)
+			err = svc.StartJWKS()
+			if err != nil {
+				logger.Errorf("failed to start JWKS server", err)
+			}
+		}()
 		logger.Infof("start signaling server on port %d", conf.SignalingPort)
 		err = svc.StartSignaling()
 		if err != nil {
@@ -0,0 +1,494 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jinzhu/gorm"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+	"go.uber.org/zap"
+)
+
+// revocationPruneInterval is how often revoked tokens already expired forgotten
+const revocationPruneInterval = time.Minute
+
+// maxRevokeAttempts is number of retry when watermark created concurrently
+const maxRevokeAttempts = 5
+
+// watermark revoke all user's tokens issued before it
+type watermark struct {
+	issuedBefore time.Time
+	generation   int64
+}
+
+// NewAPI will create new instance of token API
+func NewAPI(
+	db *gorm.DB,
+	logger *zap.SugaredLogger,
+	keys *KeySet,
+	accessTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+) *API {
+	return &API{
+		DB:              db,
+		Logger:          logger,
+		Keys:            keys,
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+		revokedTokens:   map[string]time.Time{},
+		watermarks:      map[string]watermark{},
+	}
+}
+
+// API to issue, refresh, validate & revoke user tokens
+type API struct {
+	DB              *gorm.DB
+	Logger          *zap.SugaredLogger
+	Keys            *KeySet
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	Revocations     chan *Revocation
+	mutex           sync.RWMutex
+	revokedTokens   map[string]time.Time
+	watermarks      map[string]watermark
+	prunedAt        time.Time
+}
+
+// GetRevocations will return channel use to publish token revocations
+func (a *API) GetRevocations() chan *Revocation {
+	return a.Revocations
+}
+
+// SetRevocations will set channel use to publish token revocations
+func (a *API) SetRevocations(revocations chan *Revocation) {
+	a.Revocations = revocations
+}
+
+// GenerateToken will sign new token of a type for user
+// return token with it's expiration time
+func (a *API) GenerateToken(
+	userID string,
+	tokenType string,
+	ttl time.Duration,
+	scope *Scope,
+) (*string, *time.Time, error) {
+	tokenID, err := utils.GenerateTokenID()
+	if err != nil {
+		return nil, nil, err
+	}
+	generation, err := a.tokenGeneration(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims := utils.Claims{
+		UserIDKey:     userID,
+		TokenTypeKey:  tokenType,
+		TokenIDKey:    tokenID,
+		IssuedAtKey:   issuedAt(now),
+		ExpiresAtKey:  expiresAt.Unix(),
+		GenerationKey: generation,
+	}
+	scope.SetClaims(claims)
+	token, err := a.Keys.Sign(claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	return token, &expiresAt, nil
+}
+
+// GenerateAdminToken will sign admin token carrying claims valid until ttl passed,
+// return token with it's id & expiration time
+func (a *API) GenerateAdminToken(
+	claims utils.Claims,
+	ttl time.Duration,
+) (*string, string, *time.Time, error) {
+	tokenID, err := utils.GenerateTokenID()
+	if err != nil {
+		return nil, "", nil, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims[TokenTypeKey] = AdminToken
+	claims[TokenIDKey] = tokenID
+	claims[IssuedAtKey] = issuedAt(now)
+	claims[ExpiresAtKey] = expiresAt.Unix()
+	token, err := a.Keys.Sign(claims)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return token, tokenID, &expiresAt, nil
+}
+
+// VerifyToken will verify token signature & expiration, return it's claims
+func (a *API) VerifyToken(
+	ctx context.Context,
+	token string,
+) (utils.Claims, error) {
+	return a.Keys.Verify(token)
+}
+
+// JWKS return JWKS document of keys used to verify tokens
+func (a *API) JWKS() *JSONWebKeySet {
+	return a.Keys.JWKS()
+}
+
+// IssueUserTokens will issue access & refresh token pair for user,
+// both tokens restricted to scope when given
+func (a *API) IssueUserTokens(
+	ctx context.Context,
+	userID string,
+	scope *Scope,
+) (*protos.UserAccessToken, error) {
+	err := scope.Validate()
+	if err != nil {
+		return nil, err
+	}
+	accessToken, accessExpiresAt, err := a.GenerateToken(userID, AccessToken, a.AccessTokenTTL, scope)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, refreshExpiresAt, err := a.GenerateToken(userID, RefreshToken, a.RefreshTokenTTL, scope)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := ptypes.TimestampProto(*accessExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	refreshTokenExpiresAt, err := ptypes.TimestampProto(*refreshExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &protos.UserAccessToken{
+		Token:                 *accessToken,
+		ExpiresAt:             expiresAt,
+		RefreshToken:          *refreshToken,
+		RefreshTokenExpiresAt: refreshTokenExpiresAt,
+	}, nil
+}
+
+// ValidateToken will validate user token signature, expiration, type & revocation
+func (a *API) ValidateToken(
+	token string,
+	tokenType string,
+) (utils.Claims, error) {
+	claims, err := a.validateClaims(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := claims[UserIDKey].(string); !ok {
+		return nil, fmt.Errorf(InvalidTokenError)
+	}
+	return claims, nil
+}
+
+// ValidateAdminToken will validate admin token signature, expiration, type & revocation
+func (a *API) ValidateAdminToken(
+	ctx context.Context,
+	token string,
+) (utils.Claims, error) {
+	return a.validateClaims(token, AdminToken)
+}
+
+// validateClaims will validate token of a type and return it's claims
+func (a *API) validateClaims(
+	token string,
+	tokenType string,
+) (utils.Claims, error) {
+	claims, err := a.Keys.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	// token without expiration time are never valid
+	if _, ok := claims[ExpiresAtKey]; !ok {
+		return nil, fmt.Errorf(InvalidTokenError)
+	}
+	if claims[TokenTypeKey] != tokenType {
+		return nil, fmt.Errorf(InvalidTokenTypeError)
+	}
+	if a.IsRevoked(claims) {
+		return nil, fmt.Errorf(TokenRevokedError)
+	}
+	return claims, nil
+}
+
+// ValidateAccessToken will validate access token and return it's claims
+func (a *API) ValidateAccessToken(
+	ctx context.Context,
+	token string,
+) (utils.Claims, error) {
+	return a.ValidateToken(token, AccessToken)
+}
+
+// RefreshUserTokens will rotate access & refresh token pair using refresh token,
+// used refresh token will be revoked so it can't be used twice
+// and new pair keep the scope of refresh token
+func (a *API) RefreshUserTokens(
+	ctx context.Context,
+	refreshToken string,
+) (*protos.UserAccessToken, error) {
+	claims, err := a.ValidateToken(refreshToken, RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	userID := claims[UserIDKey].(string)
+	tokenID, _ := claims[TokenIDKey].(string)
+	expiresAt, _ := claims[ExpiresAtKey].(float64)
+	err = a.useToken(userID, tokenID, time.Unix(int64(expiresAt), 0))
+	if err != nil {
+		return nil, err
+	}
+	return a.IssueUserTokens(ctx, userID, ScopeFromClaims(claims))
+}
+
+// useToken will revoke single use token by it's id, only first use succeed
+// when token used concurrently on any instance
+func (a *API) useToken(
+	userID string,
+	tokenID string,
+	expiresAt time.Time,
+) error {
+	if len(tokenID) == 0 {
+		return fmt.Errorf(InvalidTokenError)
+	}
+	err := a.DB.Create(&RevokedTokenModel{
+		ID:        tokenID,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}).Error
+	if err != nil {
+		// token id already exist when token used by other call
+		used := &RevokedTokenModel{}
+		if a.DB.Where("id = ?", tokenID).First(used).Error == nil {
+			return fmt.Errorf(TokenRevokedError)
+		}
+		return err
+	}
+	a.publishRevocation(&Revocation{
+		TokenID:   tokenID,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	})
+	return nil
+}
+
+// IsRevoked return true when token revoked by it's id
+// or issued before user's tokens watermark
+func (a *API) IsRevoked(claims utils.Claims) bool {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	if tokenID, ok := claims[TokenIDKey].(string); ok {
+		if _, revoked := a.revokedTokens[tokenID]; revoked {
+			return true
+		}
+	}
+	userID, _ := claims[UserIDKey].(string)
+	w, ok := a.watermarks[userID]
+	if !ok {
+		return false
+	}
+	// generation assigned by database, so it doesn't depend on clock of instance issued the token
+	if generation, ok := claims[GenerationKey].(float64); ok && w.generation > 0 {
+		return int64(generation) < w.generation
+	}
+	// tokens issued at the time of revocation also revoked
+	iat, _ := claims[IssuedAtKey].(float64)
+	return iat <= issuedAt(w.issuedBefore)
+}
+
+// issuedAt return issued at claim of time with sub-second precision,
+// so token issued right after revocation not revoked
+func issuedAt(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// RevokeToken will revoke single token by it's id until it's expiration time
+func (a *API) RevokeToken(
+	userID string,
+	tokenID string,
+	expiresAt time.Time,
+) error {
+	if len(tokenID) == 0 {
+		return fmt.Errorf(InvalidTokenError)
+	}
+	err := a.DB.Save(&RevokedTokenModel{
+		ID:        tokenID,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}).Error
+	if err != nil {
+		return err
+	}
+	a.publishRevocation(&Revocation{
+		TokenID:   tokenID,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	})
+	return nil
+}
+
+// RevokeUserTokens will revoke all user's tokens issued until now
+// by moving user to next token generation
+func (a *API) RevokeUserTokens(
+	ctx context.Context,
+	userID string,
+) error {
+	now := time.Now()
+	generation, err := a.nextTokenGeneration(userID, now)
+	if err != nil {
+		return err
+	}
+	a.Logger.Infof("revoke all tokens of user %s", userID)
+	a.publishRevocation(&Revocation{
+		UserID:       userID,
+		IssuedBefore: &now,
+		Generation:   generation,
+	})
+	return nil
+}
+
+// tokenGeneration return current generation of user's tokens, kept on database
+// so token issued by any instance after revocation not revoked whatever it's clock
+func (a *API) tokenGeneration(userID string) (int64, error) {
+	w := &TokenWatermarkModel{}
+	err := a.DB.Where("user_id = ?", userID).First(w).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return w.Generation, nil
+}
+
+// nextTokenGeneration will increment generation of user's tokens, return new generation
+func (a *API) nextTokenGeneration(userID string, now time.Time) (int64, error) {
+	for attempt := 0; attempt < maxRevokeAttempts; attempt++ {
+		res := a.DB.Model(&TokenWatermarkModel{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{
+				"generation":    gorm.Expr("generation + 1"),
+				"issued_before": now,
+			})
+		if res.Error != nil {
+			return 0, res.Error
+		}
+		if res.RowsAffected == 0 {
+			err := a.DB.Create(&TokenWatermarkModel{
+				UserID:       userID,
+				IssuedBefore: now,
+				Generation:   1,
+			}).Error
+			if err != nil {
+				// watermark created by other instance
+				continue
+			}
+		}
+		return a.tokenGeneration(userID)
+	}
+	return 0, fmt.Errorf(RevokeConflictError)
+}
+
+// publishRevocation will apply revocation to this instance and publish it to others without
+// blocking, other instances still load persisted revocation on start when it's not published
+func (a *API) publishRevocation(revocation *Revocation) {
+	a.ApplyRevocation(revocation)
+	if a.Revocations == nil {
+		a.Logger.Warnf("revocation of user %s not published to other instances", revocation.UserID)
+		return
+	}
+	select {
+	case a.Revocations <- revocation:
+	default:
+		a.Logger.Warnf("revocation of user %s not published to other instances, publisher busy", revocation.UserID)
+	}
+}
+
+// ApplyRevocation will apply token revocation to this instance
+func (a *API) ApplyRevocation(revocation *Revocation) {
+	if revocation == nil {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if len(revocation.TokenID) > 0 {
+		a.revokedTokens[revocation.TokenID] = revocation.ExpiresAt
+	}
+	a.pruneRevokedTokens(time.Now())
+	if revocation.IssuedBefore != nil || revocation.Generation > 0 {
+		w := a.watermarks[revocation.UserID]
+		if revocation.IssuedBefore != nil && revocation.IssuedBefore.After(w.issuedBefore) {
+			w.issuedBefore = *revocation.IssuedBefore
+		}
+		if revocation.Generation > w.generation {
+			w.generation = revocation.Generation
+		}
+		a.watermarks[revocation.UserID] = w
+	}
+}
+
+// pruneRevokedTokens will forget revoked tokens that already expired,
+// expired token rejected by it's expiration time anyway
+func (a *API) pruneRevokedTokens(now time.Time) {
+	if now.Sub(a.prunedAt) < revocationPruneInterval {
+		return
+	}
+	a.prunedAt = now
+	for tokenID, expiresAt := range a.revokedTokens {
+		if expiresAt.Before(now) {
+			delete(a.revokedTokens, tokenID)
+		}
+	}
+}
+
+// RevokedTokenCount return number of revoked tokens remembered by this instance
+func (a *API) RevokedTokenCount() int {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return len(a.revokedTokens)
+}
+
+// LoadRevocations will load persisted revocations to this instance
+// and forget revoked tokens that already expired
+func (a *API) LoadRevocations() error {
+	now := time.Now()
+	err := a.DB.
+		Where("expires_at < ?", now).
+		Delete(&RevokedTokenModel{}).
+		Error
+	if err != nil {
+		return err
+	}
+	tokens := []RevokedTokenModel{}
+	err = a.DB.Find(&tokens).Error
+	if err != nil {
+		return err
+	}
+	watermarks := []TokenWatermarkModel{}
+	err = a.DB.Find(&watermarks).Error
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		a.ApplyRevocation(&Revocation{
+			TokenID:   token.ID,
+			UserID:    token.UserID,
+			ExpiresAt: token.ExpiresAt,
+		})
+	}
+	for _, model := range watermarks {
+		issuedBefore := model.IssuedBefore
+		a.ApplyRevocation(&Revocation{
+			UserID:       model.UserID,
+			IssuedBefore: &issuedBefore,
+			Generation:   model.Generation,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,375 @@
+package auth_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/connector"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"go.uber.org/zap"
+	"syreclabs.com/go/faker"
+)
+
+var _ = Describe("API", func() {
+	var (
+		db          *gorm.DB
+		logger      *zap.SugaredLogger
+		secret      string
+		revocations chan *auth.Revocation
+		api         *auth.API
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = connector.ConnectToMemmory(auth.Models)
+		if err != nil {
+			Fail(err.Error())
+		}
+		config := zap.NewDevelopmentConfig()
+		config.Level = zap.NewAtomicLevelAt(zap.FatalLevel + 1) // silent
+		loggerRaw, err := config.Build()
+		if err != nil {
+			Fail(err.Error())
+		}
+		logger = loggerRaw.Sugar()
+		secret = faker.RandomString(20)
+		revocations = make(chan *auth.Revocation, auth.RevocationBufferSize)
+		api = auth.NewAPI(db, logger, &auth.KeySet{Secret: secret}, time.Minute, time.Hour)
+		api.SetRevocations(revocations)
+	})
+
+	AfterEach(func() {
+		if db != nil {
+			db.Close()
+		}
+		if logger != nil {
+			logger.Sync()
+		}
+	})
+
+	Describe("IssueUserTokens", func() {
+		It("should issue access token with expiration time", func() {
+			res, err := api.IssueUserTokens(context.Background(), "u1", nil)
+			Expect(err).To(BeNil())
+			claims, err := utils.ValidateToken(secret, res.Token)
+			Expect(err).To(BeNil())
+			Expect(claims).To(HaveKeyWithValue(auth.UserIDKey, "u1"))
+			Expect(claims).To(HaveKeyWithValue(auth.TokenTypeKey, auth.AccessToken))
+			Expect(claims).To(HaveKey(auth.TokenIDKey))
+			Expect(claims).To(HaveKey(auth.IssuedAtKey))
+			Expect(claims[auth.ExpiresAtKey]).To(BeNumerically("==", res.ExpiresAt.Seconds))
+			Expect(res.ExpiresAt.Seconds).To(BeNumerically("~", time.Now().Add(time.Minute).Unix(), 1))
+		})
+
+		It("should issue refresh token with expiration time", func() {
+			res, err := api.IssueUserTokens(context.Background(), "u1", nil)
+			Expect(err).To(BeNil())
+			claims, err := utils.ValidateToken(secret, res.RefreshToken)
+			Expect(err).To(BeNil())
+			Expect(claims).To(HaveKeyWithValue(auth.UserIDKey, "u1"))
+			Expect(claims).To(HaveKeyWithValue(auth.TokenTypeKey, auth.RefreshToken))
+			Expect(res.RefreshTokenExpiresAt.Seconds).To(BeNumerically("~", time.Now().Add(time.Hour).Unix(), 1))
+		})
+	})
+
+	Describe("IssueUserTokens with scope", func() {
+		It("should restrict both tokens to scope", func() {
+			scope := &auth.Scope{
+				RoomIDs:      []string{"r1"},
+				Capabilities: []string{auth.CapabilityPresence},
+			}
+			res, err := api.IssueUserTokens(context.Background(), "u1", scope)
+			Expect(err).To(BeNil())
+			for _, token := range []string{res.Token, res.RefreshToken} {
+				claims, err := api.VerifyToken(context.Background(), token)
+				Expect(err).To(BeNil())
+				Expect(auth.ScopeFromClaims(claims)).To(Equal(scope))
+			}
+		})
+
+		It("should reject unknown capability", func() {
+			res, err := api.IssueUserTokens(context.Background(), "u1", &auth.Scope{
+				Capabilities: []string{"admin"},
+			})
+			Expect(res).To(BeNil())
+			Expect(err.Error()).To(Equal(auth.InvalidCapabilityError))
+		})
+	})
+
+	Describe("ValidateAccessToken", func() {
+		It("should return claims of valid access token", func() {
+			res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+			claims, err := api.ValidateAccessToken(context.Background(), res.Token)
+			Expect(err).To(BeNil())
+			Expect(claims).To(HaveKeyWithValue(auth.UserIDKey, "u1"))
+		})
+
+		When("access token expired", func() {
+			It("should return token expired error", func() {
+				api.AccessTokenTTL = -time.Minute
+				res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+				_, err := api.ValidateAccessToken(context.Background(), res.Token)
+				Expect(err.Error()).To(Equal(utils.TokenExpiredError))
+			})
+		})
+
+		When("refresh token used as access token", func() {
+			It("should return invalid token type error", func() {
+				res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+				_, err := api.ValidateAccessToken(context.Background(), res.RefreshToken)
+				Expect(err.Error()).To(Equal(auth.InvalidTokenTypeError))
+			})
+		})
+
+		When("token has no expiration time", func() {
+			It("should return invalid token error", func() {
+				token, _ := utils.GenerateToken(secret, utils.Claims{
+					auth.UserIDKey:    "u1",
+					auth.TokenTypeKey: auth.AccessToken,
+				})
+				_, err := api.ValidateAccessToken(context.Background(), *token)
+				Expect(err.Error()).To(Equal(auth.InvalidTokenError))
+			})
+		})
+	})
+
+	Describe("RefreshUserTokens", func() {
+		It("should rotate access & refresh token", func() {
+			res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+			go func() { <-revocations }()
+			refreshed, err := api.RefreshUserTokens(context.Background(), res.RefreshToken)
+			Expect(err).To(BeNil())
+			Expect(refreshed.Token).NotTo(Equal(res.Token))
+			Expect(refreshed.RefreshToken).NotTo(Equal(res.RefreshToken))
+			claims, err := api.ValidateAccessToken(context.Background(), refreshed.Token)
+			Expect(err).To(BeNil())
+			Expect(claims).To(HaveKeyWithValue(auth.UserIDKey, "u1"))
+		})
+
+		It("should keep scope of refresh token", func() {
+			scope := &auth.Scope{RoomIDs: []string{"r1", "r2"}}
+			res, _ := api.IssueUserTokens(context.Background(), "u1", scope)
+			go func() { <-revocations }()
+			refreshed, err := api.RefreshUserTokens(context.Background(), res.RefreshToken)
+			Expect(err).To(BeNil())
+			claims, err := api.ValidateAccessToken(context.Background(), refreshed.Token)
+			Expect(err).To(BeNil())
+			Expect(auth.ScopeFromClaims(claims)).To(Equal(scope))
+		})
+
+		It("should revoke used refresh token", func(done Done) {
+			res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+			go func() {
+				api.RefreshUserTokens(context.Background(), res.RefreshToken)
+			}()
+			revocation := <-revocations
+			Expect(revocation.UserID).To(Equal("u1"))
+			Expect(revocation.TokenID).NotTo(BeEmpty())
+			_, err := api.RefreshUserTokens(context.Background(), res.RefreshToken)
+			Expect(err.Error()).To(Equal(auth.TokenRevokedError))
+			close(done)
+		}, 0.3)
+
+		When("refresh token used concurrently", func() {
+			It("should only rotate tokens once", func() {
+				res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+				other := auth.NewAPI(db, logger, &auth.KeySet{Secret: secret}, time.Minute, time.Hour)
+				go func() { <-revocations }()
+				_, err := api.RefreshUserTokens(context.Background(), res.RefreshToken)
+				Expect(err).To(BeNil())
+				// other instance not received revocation yet
+				_, err = other.RefreshUserTokens(context.Background(), res.RefreshToken)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal(auth.TokenRevokedError))
+			})
+		})
+
+		When("revocation publisher not receiving", func() {
+			It("should not block revocation", func(done Done) {
+				api.SetRevocations(make(chan *auth.Revocation))
+				err := api.RevokeUserTokens(context.Background(), "u1")
+				Expect(err).To(BeNil())
+				close(done)
+			}, 0.3)
+		})
+
+		When("revocation publisher not set", func() {
+			It("should not block revocation", func(done Done) {
+				api.SetRevocations(nil)
+				err := api.RevokeUserTokens(context.Background(), "u1")
+				Expect(err).To(BeNil())
+				close(done)
+			}, 0.3)
+		})
+
+		When("access token used as refresh token", func() {
+			It("should return invalid token type error", func() {
+				res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+				_, err := api.RefreshUserTokens(context.Background(), res.Token)
+				Expect(err.Error()).To(Equal(auth.InvalidTokenTypeError))
+			})
+		})
+
+		When("refresh token expired", func() {
+			It("should return token expired error", func() {
+				api.RefreshTokenTTL = -time.Minute
+				res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+				_, err := api.RefreshUserTokens(context.Background(), res.RefreshToken)
+				Expect(err.Error()).To(Equal(utils.TokenExpiredError))
+			})
+		})
+	})
+
+	Describe("ValidateAdminToken", func() {
+		It("should accept admin token with it's claims", func() {
+			token, tokenID, expiresAt, err := api.GenerateAdminToken(utils.Claims{"name": "ops"}, time.Minute)
+			Expect(err).To(BeNil())
+			Expect(expiresAt.Unix()).To(BeNumerically("~", time.Now().Add(time.Minute).Unix(), 1))
+			claims, err := api.ValidateAdminToken(context.Background(), *token)
+			Expect(err).To(BeNil())
+			Expect(claims).To(HaveKeyWithValue("name", "ops"))
+			Expect(claims).To(HaveKeyWithValue(auth.TokenTypeKey, auth.AdminToken))
+			Expect(claims).To(HaveKeyWithValue(auth.TokenIDKey, tokenID))
+		})
+
+		When("user token used as admin token", func() {
+			It("should return invalid token type error", func() {
+				res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+				_, err := api.ValidateAdminToken(context.Background(), res.Token)
+				Expect(err.Error()).To(Equal(auth.InvalidTokenTypeError))
+			})
+		})
+
+		When("admin token used as access token", func() {
+			It("should return invalid token type error", func() {
+				token, _, _, _ := api.GenerateAdminToken(utils.Claims{}, time.Minute)
+				_, err := api.ValidateAccessToken(context.Background(), *token)
+				Expect(err.Error()).To(Equal(auth.InvalidTokenTypeError))
+			})
+		})
+
+		When("admin token revoked", func() {
+			It("should return token revoked error", func(done Done) {
+				token, tokenID, expiresAt, _ := api.GenerateAdminToken(utils.Claims{}, time.Minute)
+				go func() { <-revocations }()
+				err := api.RevokeToken("", tokenID, *expiresAt)
+				Expect(err).To(BeNil())
+				_, err = api.ValidateAdminToken(context.Background(), *token)
+				Expect(err.Error()).To(Equal(auth.TokenRevokedError))
+				close(done)
+			}, 0.3)
+		})
+	})
+
+	Describe("RevokeUserTokens", func() {
+		It("should reject tokens issued before revocation", func(done Done) {
+			res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+			go func() { <-revocations }()
+			err := api.RevokeUserTokens(context.Background(), "u1")
+			Expect(err).To(BeNil())
+			_, err = api.ValidateAccessToken(context.Background(), res.Token)
+			Expect(err.Error()).To(Equal(auth.TokenRevokedError))
+			close(done)
+		}, 0.3)
+
+		It("should accept tokens issued after revocation", func(done Done) {
+			go func() { <-revocations }()
+			err := api.RevokeUserTokens(context.Background(), "u1")
+			Expect(err).To(BeNil())
+			res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+			_, err = api.ValidateAccessToken(context.Background(), res.Token)
+			Expect(err).To(BeNil())
+			close(done)
+		}, 0.3)
+
+		It("should not reject other user's tokens", func(done Done) {
+			res, _ := api.IssueUserTokens(context.Background(), "u2", nil)
+			go func() { <-revocations }()
+			api.RevokeUserTokens(context.Background(), "u1")
+			_, err := api.ValidateAccessToken(context.Background(), res.Token)
+			Expect(err).To(BeNil())
+			close(done)
+		}, 0.3)
+
+		It("should publish revocation", func(done Done) {
+			go func() {
+				api.RevokeUserTokens(context.Background(), "u1")
+			}()
+			revocation := <-revocations
+			Expect(revocation.UserID).To(Equal("u1"))
+			Expect(revocation.IssuedBefore).NotTo(BeNil())
+			Expect(revocation.Generation).To(BeEquivalentTo(1))
+			close(done)
+		}, 0.3)
+
+		It("should move user to next token generation on every revocation", func() {
+			api.RevokeUserTokens(context.Background(), "u1")
+			api.RevokeUserTokens(context.Background(), "u1")
+			res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+			claims, err := api.VerifyToken(context.Background(), res.Token)
+			Expect(err).To(BeNil())
+			Expect(claims[auth.GenerationKey]).To(BeEquivalentTo(2))
+		})
+
+		It("should accept tokens issued after revocation whatever clock of revoking instance", func() {
+			err := api.RevokeUserTokens(context.Background(), "u1")
+			Expect(err).To(BeNil())
+			res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+			// revocation published by instance with clock ahead
+			issuedBefore := time.Now().Add(time.Minute)
+			api.ApplyRevocation(&auth.Revocation{
+				UserID:       "u1",
+				IssuedBefore: &issuedBefore,
+				Generation:   1,
+			})
+			_, err = api.ValidateAccessToken(context.Background(), res.Token)
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("ApplyRevocation", func() {
+		It("should reject token revoked by other instance", func() {
+			res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+			issuedBefore := time.Now()
+			api.ApplyRevocation(&auth.Revocation{
+				UserID:       "u1",
+				IssuedBefore: &issuedBefore,
+			})
+			_, err := api.ValidateAccessToken(context.Background(), res.Token)
+			Expect(err.Error()).To(Equal(auth.TokenRevokedError))
+		})
+
+		It("should forget revoked tokens already expired", func() {
+			api.ApplyRevocation(&auth.Revocation{
+				TokenID:   "expired",
+				UserID:    "u1",
+				ExpiresAt: time.Now().Add(-time.Minute),
+			})
+			Expect(api.RevokedTokenCount()).To(Equal(0))
+			api.ApplyRevocation(&auth.Revocation{
+				TokenID:   "active",
+				UserID:    "u1",
+				ExpiresAt: time.Now().Add(time.Minute),
+			})
+			Expect(api.RevokedTokenCount()).To(Equal(1))
+		})
+	})
+
+	Describe("LoadRevocations", func() {
+		It("should reject tokens revoked before instance started", func(done Done) {
+			res, _ := api.IssueUserTokens(context.Background(), "u1", nil)
+			go func() { <-revocations }()
+			api.RevokeUserTokens(context.Background(), "u1")
+			other := auth.NewAPI(db, logger, &auth.KeySet{Secret: secret}, time.Minute, time.Hour)
+			err := other.LoadRevocations()
+			Expect(err).To(BeNil())
+			_, err = other.ValidateAccessToken(context.Background(), res.Token)
+			Expect(err.Error()).To(Equal(auth.TokenRevokedError))
+			close(done)
+		}, 0.3)
+	})
+})
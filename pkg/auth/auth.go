@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+)
+
+const (
+	// UserIDKey is claim key of user owning the token
+	UserIDKey = "user_id"
+	// DeviceIDKey is claim & context key of device token issued to
+	DeviceIDKey = "device_id"
+	// TokenTypeKey is claim key of token type
+	TokenTypeKey = "typ"
+	// TokenIDKey is claim key of unique token identifier
+	TokenIDKey = "jti"
+	// IssuedAtKey is claim key of time token issued
+	IssuedAtKey = "iat"
+	// GenerationKey is claim key of user's token generation when token issued
+	GenerationKey = "gen"
+	// ExpiresAtKey is claim key of token expiration time
+	ExpiresAtKey = "exp"
+)
+
+const (
+	// AccessToken is type of token used to call signaling service
+	AccessToken = "access"
+	// RefreshToken is type of token used to obtain new token pair
+	RefreshToken = "refresh"
+	// AdminToken is type of token used to call room management service
+	AdminToken = "admin"
+)
+
+const (
+	// TokenRevoked emitted when one or more tokens has been revoked
+	TokenRevoked = "chat.auth.token-revoked"
+)
+
+// RevocationBufferSize is number of revocations waiting to be published,
+// revocation dropped when buffer full so revoking never block
+const RevocationBufferSize = 100
+
+const (
+	InvalidTokenError     = "invalid token"
+	InvalidTokenTypeError = "invalid token type"
+	TokenRevokedError     = "token revoked"
+	RevokeConflictError   = "tokens revoked concurrently, try again"
+)
+
+// Revocation emitted when tokens revoked, either a single token by it's id
+// or all user's tokens issued before a generation (or time for tokens without generation)
+type Revocation struct {
+	TokenID      string     `json:"token_id"`
+	UserID       string     `json:"user_id"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	IssuedBefore *time.Time `json:"issued_before"`
+	Generation   int64      `json:"generation"`
+}
+
+// DeviceIDFromContext return device of user on a call,
+// empty when client didn't identify it's device
+func DeviceIDFromContext(ctx context.Context) string {
+	deviceID, _ := ctx.Value(DeviceIDKey).(string)
+	return deviceID
+}
+
+// ITokenManager issue and verify tokens used by peer as user identification form
+type ITokenManager interface {
+	GetRevocations() chan *Revocation
+	SetRevocations(revocations chan *Revocation)
+	IssueUserTokens(ctx context.Context, userID string, scope *Scope) (*protos.UserAccessToken, error)
+	RefreshUserTokens(ctx context.Context, refreshToken string) (*protos.UserAccessToken, error)
+	ValidateAccessToken(ctx context.Context, token string) (utils.Claims, error)
+	VerifyToken(ctx context.Context, token string) (utils.Claims, error)
+	ValidateAdminToken(ctx context.Context, token string) (utils.Claims, error)
+	JWKS() *JSONWebKeySet
+	RevokeUserTokens(ctx context.Context, userID string) error
+	ApplyRevocation(revocation *Revocation)
+	LoadRevocations() error
+}
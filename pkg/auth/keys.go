@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"sort"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+)
+
+const (
+	// SigningKeyNotFoundError returned when active key not loaded or has no private key
+	SigningKeyNotFoundError = "active signing key not found"
+	// SecretDisabledError returned when HMAC token given but secret not configured
+	SecretDisabledError = "token without key id not accepted"
+)
+
+// KeyConfig define asymmetric key used to sign or verify tokens,
+// key with only public key file can verify but not sign tokens
+type KeyConfig struct {
+	ID             string `json:"id" mapstructure:"id"`
+	Algorithm      string `json:"algorithm" mapstructure:"algorithm"`
+	PrivateKeyFile string `json:"private_key_file" mapstructure:"private_key_file"`
+	PublicKeyFile  string `json:"public_key_file" mapstructure:"public_key_file"`
+}
+
+// KeySet hold keys used to sign & verify tokens,
+// tokens signed using HMAC secret when there is no active asymmetric key
+type KeySet struct {
+	Secret      string
+	ActiveKeyID string
+	Keys        map[string]*utils.SigningKey
+}
+
+// LoadKeySet will read asymmetric keys from their files
+func LoadKeySet(
+	secret string,
+	activeKeyID string,
+	configs []KeyConfig,
+) (*KeySet, error) {
+	keys := map[string]*utils.SigningKey{}
+	for _, config := range configs {
+		var privatePEM, publicPEM []byte
+		var err error
+		if len(config.PrivateKeyFile) > 0 {
+			privatePEM, err = ioutil.ReadFile(config.PrivateKeyFile)
+		} else {
+			publicPEM, err = ioutil.ReadFile(config.PublicKeyFile)
+		}
+		if err != nil {
+			return nil, err
+		}
+		key, err := utils.ParseSigningKey(config.ID, config.Algorithm, privatePEM, publicPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signing key %s: %v", config.ID, err)
+		}
+		keys[config.ID] = key
+	}
+	if len(activeKeyID) > 0 {
+		key, ok := keys[activeKeyID]
+		if !ok || key.PrivateKey == nil {
+			return nil, fmt.Errorf(SigningKeyNotFoundError)
+		}
+	}
+	return &KeySet{
+		Secret:      secret,
+		ActiveKeyID: activeKeyID,
+		Keys:        keys,
+	}, nil
+}
+
+// Sign will sign claims using active key,
+// fallback to HMAC secret when there is no active key
+func (k *KeySet) Sign(claims utils.Claims) (*string, error) {
+	if len(k.ActiveKeyID) == 0 {
+		return utils.GenerateToken(k.Secret, claims)
+	}
+	key, ok := k.Keys[k.ActiveKeyID]
+	if !ok {
+		return nil, fmt.Errorf(SigningKeyNotFoundError)
+	}
+	return utils.GenerateTokenWithKey(key, claims)
+}
+
+// Verify will verify token signed by one of keys in key set,
+// token without key id verified using HMAC secret when secret configured
+func (k *KeySet) Verify(token string) (utils.Claims, error) {
+	kid, err := utils.GetTokenKeyID(token)
+	if err != nil {
+		return nil, err
+	}
+	if len(kid) > 0 {
+		return utils.ValidateTokenWithKeys(k.Keys, token)
+	}
+	if len(k.Secret) == 0 {
+		return nil, fmt.Errorf(SecretDisabledError)
+	}
+	return utils.ValidateToken(k.Secret, token)
+}
+
+// JSONWebKey is public key representation on JWKS document
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JSONWebKeySet is JWKS document used by other services to verify tokens
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS return public part of asymmetric keys as JWKS document
+func (k *KeySet) JWKS() *JSONWebKeySet {
+	jwks := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range k.Keys {
+		jwk := JSONWebKey{
+			KeyID:     key.ID,
+			Use:       "sig",
+			Algorithm: key.Algorithm,
+		}
+		switch public := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = encodeBase64URL(public.N.Bytes())
+			jwk.E = encodeBase64URL(big.NewInt(int64(public.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (public.Curve.Params().BitSize + 7) / 8
+			jwk.KeyType = "EC"
+			jwk.Curve = public.Curve.Params().Name
+			jwk.X = encodeBase64URL(padBytes(public.X.Bytes(), size))
+			jwk.Y = encodeBase64URL(padBytes(public.Y.Bytes(), size))
+		default:
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID
+	})
+	return jwks
+}
+
+// encodeBase64URL encode bytes as unpadded base64url used by JWK
+func encodeBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// padBytes left pad bytes with zero to it's expected size
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
@@ -0,0 +1,116 @@
+package auth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+)
+
+var _ = Describe("KeySet", func() {
+	var (
+		dir string
+	)
+
+	writeKey := func(name string) string {
+		private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).To(BeNil())
+		der, err := x509.MarshalECPrivateKey(private)
+		Expect(err).To(BeNil())
+		file := filepath.Join(dir, name+".pem")
+		err = ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: der,
+		}), 0600)
+		Expect(err).To(BeNil())
+		return file
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "keys")
+		if err != nil {
+			Fail(err.Error())
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("sign using HMAC secret when there is no active key", func() {
+		keys, err := auth.LoadKeySet("secret", "", nil)
+		Expect(err).To(BeNil())
+		token, err := keys.Sign(utils.Claims{"claim_1": "content_1"})
+		Expect(err).To(BeNil())
+		claims, err := keys.Verify(*token)
+		Expect(err).To(BeNil())
+		Expect(claims["claim_1"]).To(Equal("content_1"))
+	})
+
+	It("reject unknown active key", func() {
+		_, err := auth.LoadKeySet("secret", "key-1", nil)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(Equal(auth.SigningKeyNotFoundError))
+	})
+
+	It("verify tokens signed by previous key after rotation", func() {
+		configs := []auth.KeyConfig{
+			{ID: "key-1", Algorithm: "ES256", PrivateKeyFile: writeKey("key-1")},
+			{ID: "key-2", Algorithm: "ES256", PrivateKeyFile: writeKey("key-2")},
+		}
+		old, err := auth.LoadKeySet("", "key-1", configs)
+		Expect(err).To(BeNil())
+		oldToken, err := old.Sign(utils.Claims{"claim_1": "old"})
+		Expect(err).To(BeNil())
+		rotated, err := auth.LoadKeySet("", "key-2", configs)
+		Expect(err).To(BeNil())
+		newToken, err := rotated.Sign(utils.Claims{"claim_1": "new"})
+		Expect(err).To(BeNil())
+		kid, _ := utils.GetTokenKeyID(*newToken)
+		Expect(kid).To(Equal("key-2"))
+		claims, err := rotated.Verify(*oldToken)
+		Expect(err).To(BeNil())
+		Expect(claims["claim_1"]).To(Equal("old"))
+		claims, err = rotated.Verify(*newToken)
+		Expect(err).To(BeNil())
+		Expect(claims["claim_1"]).To(Equal("new"))
+	})
+
+	It("reject token without key id when secret disabled", func() {
+		keys, err := auth.LoadKeySet("", "key-1", []auth.KeyConfig{
+			{ID: "key-1", Algorithm: "ES256", PrivateKeyFile: writeKey("key-1")},
+		})
+		Expect(err).To(BeNil())
+		token, _ := utils.GenerateToken("secret", utils.Claims{})
+		_, err = keys.Verify(*token)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(Equal(auth.SecretDisabledError))
+	})
+
+	It("publish public keys as JWKS", func() {
+		keys, err := auth.LoadKeySet("secret", "key-1", []auth.KeyConfig{
+			{ID: "key-2", Algorithm: "ES256", PrivateKeyFile: writeKey("key-2")},
+			{ID: "key-1", Algorithm: "ES256", PrivateKeyFile: writeKey("key-1")},
+		})
+		Expect(err).To(BeNil())
+		jwks := keys.JWKS()
+		Expect(jwks.Keys).To(HaveLen(2))
+		Expect(jwks.Keys[0].KeyID).To(Equal("key-1"))
+		Expect(jwks.Keys[0].KeyType).To(Equal("EC"))
+		Expect(jwks.Keys[0].Curve).To(Equal("P-256"))
+		Expect(jwks.Keys[0].Algorithm).To(Equal("ES256"))
+		Expect(jwks.Keys[0].X).To(HaveLen(43))
+		Expect(jwks.Keys[0].Y).To(HaveLen(43))
+		Expect(jwks.Keys[1].KeyID).To(Equal("key-2"))
+	})
+})
@@ -0,0 +1,24 @@
+package auth
+
+import "time"
+
+// Models defined in auth package
+var Models = []interface{}{
+	&RevokedTokenModel{},
+	&TokenWatermarkModel{},
+}
+
+// RevokedTokenModel define single token revoked before it's expiration time
+type RevokedTokenModel struct {
+	ID        string    `gorm:"primary_key;not null;size:100"`
+	UserID    string    `gorm:"column:user_id;index;size:100"`
+	ExpiresAt time.Time `gorm:"column:expires_at;index"`
+}
+
+// TokenWatermarkModel define generation (or time for tokens without generation)
+// before which all user's tokens are revoked, incremented on every revocation
+type TokenWatermarkModel struct {
+	UserID       string    `gorm:"primary_key;not null;size:100"`
+	IssuedBefore time.Time `gorm:"column:issued_before"`
+	Generation   int64     `gorm:"column:generation"`
+}
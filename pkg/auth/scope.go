@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+)
+
+const (
+	// RoomsKey is claim key of rooms token restricted to
+	RoomsKey = "rooms"
+	// CapabilitiesKey is claim key of capabilities token restricted to
+	CapabilitiesKey = "caps"
+	// ScopeContextKey is context key of token scope
+	ScopeContextKey = "token_scope"
+)
+
+const (
+	// CapabilitySignal allow user to exchange SDP & ICE candidates
+	CapabilitySignal = "signal"
+	// CapabilityPresence allow user to publish and watch online status
+	CapabilityPresence = "presence"
+	// CapabilityProfile allow user to update it's profile
+	CapabilityProfile = "profile"
+)
+
+// Capabilities list all capabilities token can be restricted to
+var Capabilities = []string{
+	CapabilitySignal,
+	CapabilityPresence,
+	CapabilityProfile,
+}
+
+const (
+	InvalidCapabilityError = "invalid capability"
+)
+
+// Scope restrict what token can do, empty room list allow all user's rooms
+// and empty capability list allow all capabilities,
+// nil scope is unrestricted
+type Scope struct {
+	RoomIDs      []string
+	Capabilities []string
+}
+
+// Validate return error when scope contain unknown capability
+func (s *Scope) Validate() error {
+	if s == nil {
+		return nil
+	}
+	for _, capability := range s.Capabilities {
+		if !utils.ContainString(Capabilities, capability) {
+			return fmt.Errorf(InvalidCapabilityError)
+		}
+	}
+	return nil
+}
+
+// IsRoomRestricted return true when token restricted to some rooms
+func (s *Scope) IsRoomRestricted() bool {
+	return s != nil && len(s.RoomIDs) > 0
+}
+
+// AllowRoom return true when token allowed to access a room
+func (s *Scope) AllowRoom(roomID string) bool {
+	if !s.IsRoomRestricted() {
+		return true
+	}
+	return utils.ContainString(s.RoomIDs, roomID)
+}
+
+// FilterRooms return rooms allowed by token
+func (s *Scope) FilterRooms(roomIDs []string) []string {
+	if !s.IsRoomRestricted() {
+		return roomIDs
+	}
+	allowed := []string{}
+	for _, id := range roomIDs {
+		if utils.ContainString(s.RoomIDs, id) {
+			allowed = append(allowed, id)
+		}
+	}
+	return allowed
+}
+
+// Can return true when token has a capability
+func (s *Scope) Can(capability string) bool {
+	if s == nil || len(s.Capabilities) == 0 {
+		return true
+	}
+	return utils.ContainString(s.Capabilities, capability)
+}
+
+// SetClaims will write scope to token claims
+func (s *Scope) SetClaims(claims utils.Claims) {
+	if s == nil {
+		return
+	}
+	if len(s.RoomIDs) > 0 {
+		claims[RoomsKey] = s.RoomIDs
+	}
+	if len(s.Capabilities) > 0 {
+		claims[CapabilitiesKey] = s.Capabilities
+	}
+}
+
+// ScopeFromClaims return scope of token, nil when token unrestricted
+func ScopeFromClaims(claims utils.Claims) *Scope {
+	rooms := claimStrings(claims[RoomsKey])
+	capabilities := claimStrings(claims[CapabilitiesKey])
+	if len(rooms) == 0 && len(capabilities) == 0 {
+		return nil
+	}
+	return &Scope{
+		RoomIDs:      rooms,
+		Capabilities: capabilities,
+	}
+}
+
+// ScopeFromContext return scope of token used on a call
+func ScopeFromContext(ctx context.Context) *Scope {
+	scope, _ := ctx.Value(ScopeContextKey).(*Scope)
+	return scope
+}
+
+// claimStrings return list of string on a claim
+func claimStrings(claim interface{}) []string {
+	var values []string
+	switch v := claim.(type) {
+	case []string:
+		values = append(values, v...)
+	case []interface{}:
+		for _, i := range v {
+			if s, ok := i.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,43 @@
+package auth_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+)
+
+var _ = Describe("Scope", func() {
+	It("allow everything when scope is nil", func() {
+		var scope *auth.Scope
+		Expect(scope.AllowRoom("r1")).To(BeTrue())
+		Expect(scope.Can(auth.CapabilitySignal)).To(BeTrue())
+		Expect(scope.FilterRooms([]string{"r1", "r2"})).To(ConsistOf("r1", "r2"))
+	})
+
+	It("restrict rooms & capabilities", func() {
+		scope := &auth.Scope{
+			RoomIDs:      []string{"r1"},
+			Capabilities: []string{auth.CapabilityPresence},
+		}
+		Expect(scope.AllowRoom("r1")).To(BeTrue())
+		Expect(scope.AllowRoom("r2")).To(BeFalse())
+		Expect(scope.FilterRooms([]string{"r1", "r2"})).To(ConsistOf("r1"))
+		Expect(scope.Can(auth.CapabilityPresence)).To(BeTrue())
+		Expect(scope.Can(auth.CapabilitySignal)).To(BeFalse())
+	})
+
+	It("return nil scope of unrestricted claims", func() {
+		Expect(auth.ScopeFromClaims(utils.Claims{})).To(BeNil())
+	})
+
+	It("read scope from parsed claims", func() {
+		claims := utils.Claims{
+			auth.RoomsKey:        []interface{}{"r1", "r2"},
+			auth.CapabilitiesKey: []interface{}{auth.CapabilitySignal},
+		}
+		scope := auth.ScopeFromClaims(claims)
+		Expect(scope.RoomIDs).To(Equal([]string{"r1", "r2"}))
+		Expect(scope.Capabilities).To(Equal([]string{auth.CapabilitySignal}))
+	})
+})
@@ -0,0 +1,293 @@
+package call
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jinzhu/gorm"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CallNotFoundError     = "call not found"
+	CallYourselfError     = "can't call yourself"
+	InvalidCallStateError = "call can't change from it's current state"
+	CallRoleError         = "user not allowed to change call state"
+)
+
+// NewAPI will create new instance of call API
+func NewAPI(
+	db *gorm.DB,
+	logger *zap.SugaredLogger,
+	peers IPeers,
+	ringTimeout time.Duration,
+) *API {
+	if ringTimeout <= 0 {
+		ringTimeout = DefaultRingTimeout
+	}
+	return &API{
+		DB:          db,
+		Logger:      logger,
+		Peers:       peers,
+		RingTimeout: ringTimeout,
+	}
+}
+
+// API implement call service
+type API struct {
+	DB          *gorm.DB
+	Logger      *zap.SugaredLogger
+	Peers       IPeers
+	RingTimeout time.Duration
+	Events      chan *CallEvent
+	RoomEvents  chan *RoomCallEvent
+}
+
+// GetEvents will return channel use to publish call events
+func (a *API) GetEvents() chan *CallEvent {
+	return a.Events
+}
+
+// SetEvents will set channel use to publish call events
+func (a *API) SetEvents(events chan *CallEvent) {
+	a.Events = events
+}
+
+// StartCall will ring target user, call missed when not answered before ring timeout
+func (a *API) StartCall(ctx context.Context, param *protos.StartCallParam) (*protos.Call, error) {
+	user, err := a.Peers.GetUserContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if param.UserID == user.ID {
+		return nil, status.Error(codes.InvalidArgument, CallYourselfError)
+	}
+	err = a.Peers.AuthorizePeer(ctx, user, param.UserID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := utils.GenerateTokenID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	c := &CallModel{
+		ID:           id,
+		CallerID:     user.ID,
+		CalleeID:     param.UserID,
+		State:        CallRinging,
+		CreatedAt:    now,
+		RingingUntil: now.Add(a.RingTimeout),
+	}
+	err = a.DB.Create(c).Error
+	if err != nil {
+		return nil, err
+	}
+	time.AfterFunc(a.RingTimeout, func() {
+		_, err := a.ExpireCall(id)
+		if err != nil {
+			a.Logger.Errorf("failed to expire call -> %v", err)
+		}
+	})
+	a.emit(c, now)
+	return CallModelToProto(c), nil
+}
+
+// AcceptCall will answer ringing call, only callee can accept a call
+func (a *API) AcceptCall(ctx context.Context, param *protos.CallParam) (*protos.Call, error) {
+	return a.transition(ctx, param.Id, isCallee, CallRinging, CallActive)
+}
+
+// RejectCall will decline ringing call, only callee can reject a call
+func (a *API) RejectCall(ctx context.Context, param *protos.CallParam) (*protos.Call, error) {
+	return a.transition(ctx, param.Id, isCallee, CallRinging, CallRejected)
+}
+
+// CancelCall will stop ringing call, only caller can cancel a call
+func (a *API) CancelCall(ctx context.Context, param *protos.CallParam) (*protos.Call, error) {
+	return a.transition(ctx, param.Id, isCaller, CallRinging, CallCancelled)
+}
+
+// HangupCall will end active call, any participant can hang up a call
+func (a *API) HangupCall(ctx context.Context, param *protos.CallParam) (*protos.Call, error) {
+	return a.transition(ctx, param.Id, (*CallModel).IsParticipant, CallActive, CallEnded)
+}
+
+// ExpireCall will mark call as missed when it's still ringing after ring timeout,
+// return true when call expired
+func (a *API) ExpireCall(id string) (bool, error) {
+	now := time.Now()
+	res := a.DB.Model(&CallModel{}).
+		Where("id = ? AND state = ? AND ringing_until <= ?", id, CallRinging, now).
+		Updates(map[string]interface{}{
+			"state":    CallMissed,
+			"ended_at": now,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+	c := &CallModel{}
+	err := a.DB.First(c, "id = ?", id).Error
+	if err != nil {
+		return true, err
+	}
+	a.emit(c, now)
+	return true, nil
+}
+
+// SubscribeCallEvent will return state changes of calls user participate in
+func (a *API) SubscribeCallEvent(
+	ctx context.Context,
+	events <-chan *CallEvent,
+	protoEvents chan<- *protos.CallEvent,
+) error {
+	user, err := a.Peers.GetUserContext(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case event := <-events:
+			if event == nil || event.Call == nil {
+				continue
+			}
+			if !event.Call.IsParticipant(user.ID) {
+				continue
+			}
+			t, err := ptypes.TimestampProto(event.Time)
+			if err != nil {
+				a.Logger.Error(err)
+				continue
+			}
+			select {
+			case protoEvents <- &protos.CallEvent{
+				Call: CallModelToProto(event.Call),
+				Time: t,
+			}:
+			case <-ctx.Done():
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// isCaller return true when user started the call
+func isCaller(c *CallModel, userID string) bool {
+	return c.CallerID == userID
+}
+
+// isCallee return true when user is target of the call
+func isCallee(c *CallModel, userID string) bool {
+	return c.CalleeID == userID
+}
+
+// transition will move call from a state to another when user has the role to do it,
+// call changed concurrently to other state rejected
+func (a *API) transition(
+	ctx context.Context,
+	id string,
+	allowed func(c *CallModel, userID string) bool,
+	from string,
+	to string,
+) (*protos.Call, error) {
+	user, err := a.Peers.GetUserContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c, err := a.GetCall(id, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed(c, user.ID) {
+		return nil, status.Error(codes.PermissionDenied, CallRoleError)
+	}
+	// ringing call past it's timeout already missed
+	if c.State == CallRinging && !time.Now().Before(c.RingingUntil) {
+		_, err := a.ExpireCall(id)
+		if err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.FailedPrecondition, InvalidCallStateError)
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{"state": to}
+	if to == CallActive {
+		updates["answered_at"] = now
+	} else {
+		updates["ended_at"] = now
+		updates["ended_by"] = user.ID
+	}
+	res := a.DB.Model(&CallModel{}).
+		Where("id = ? AND state = ?", id, from).
+		Updates(updates)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, status.Error(codes.FailedPrecondition, InvalidCallStateError)
+	}
+	c = &CallModel{}
+	err = a.DB.First(c, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	a.emit(c, now)
+	return CallModelToProto(c), nil
+}
+
+// GetCall return call user participate in
+func (a *API) GetCall(id string, userID string) (*CallModel, error) {
+	c := &CallModel{}
+	err := a.DB.First(c, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.NotFound, CallNotFoundError)
+		}
+		return nil, err
+	}
+	if !c.IsParticipant(userID) {
+		return nil, status.Error(codes.NotFound, CallNotFoundError)
+	}
+	return c, nil
+}
+
+// emit will publish call state to it's participants
+func (a *API) emit(c *CallModel, t time.Time) {
+	a.Events <- &CallEvent{
+		Call: c,
+		Time: t,
+	}
+}
+
+// AuthorizeSession return error when users are not participants of an open call,
+// used to relay session description belong to a call
+func AuthorizeSession(db *gorm.DB, id string, userIDs ...string) error {
+	c := &CallModel{}
+	err := db.First(c, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return status.Error(codes.NotFound, CallNotFoundError)
+		}
+		return err
+	}
+	for _, userID := range userIDs {
+		if !c.IsParticipant(userID) {
+			return status.Error(codes.NotFound, CallNotFoundError)
+		}
+	}
+	if !c.IsOpen() {
+		return status.Error(codes.FailedPrecondition, InvalidCallStateError)
+	}
+	return nil
+}
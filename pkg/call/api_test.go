@@ -0,0 +1,484 @@
+package call_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/call"
+	"go.sirus.dev/p2p-comm/signalling/pkg/connector"
+	"go.sirus.dev/p2p-comm/signalling/pkg/room"
+	"go.sirus.dev/p2p-comm/signalling/pkg/signaling"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("API", func() {
+	var (
+		db     *gorm.DB
+		logger *zap.SugaredLogger
+		events chan *call.CallEvent
+		rooms  chan *call.RoomCallEvent
+		peers  *signaling.API
+		api    *call.API
+		caller context.Context
+		callee context.Context
+		other  context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		models := []interface{}{}
+		models = append(models, room.Models...)
+		models = append(models, call.Models...)
+		models = append(models, signaling.Models...)
+		db, err = connector.ConnectToMemmory(models)
+		if err != nil {
+			Fail(err.Error())
+		}
+		config := zap.NewDevelopmentConfig()
+		config.Level = zap.NewAtomicLevelAt(zap.FatalLevel + 1) // silent
+		loggerRaw, err := config.Build()
+		if err != nil {
+			Fail(err.Error())
+		}
+		logger = loggerRaw.Sugar()
+		events = make(chan *call.CallEvent, 10)
+		peers = signaling.NewAPI(db, logger, &[]signaling.ICEServer{}, nil, 0)
+		api = call.NewAPI(db, logger, peers, time.Minute)
+		api.SetEvents(events)
+		rooms = make(chan *call.RoomCallEvent, 10)
+		api.SetRoomCallEvents(rooms)
+
+		// u1, u2 & u4 share r1, u3 not join any room
+		r1 := room.FakeRoom()
+		r1.ID = "r1"
+		u1 := room.FakeUser()
+		u1.ID = "u1"
+		u2 := room.FakeUser()
+		u2.ID = "u2"
+		u3 := room.FakeUser()
+		u3.ID = "u3"
+		db.Create(r1)
+		db.Create(u1)
+		db.Create(u2)
+		u4 := room.FakeUser()
+		u4.ID = "u4"
+		db.Create(u3)
+		db.Create(u4)
+		db.Model(r1).Association("Members").Append(u1, u2, u4)
+		caller = context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+		callee = context.WithValue(context.Background(), room.UserIDKey, u2.ID)
+		other = context.WithValue(context.Background(), room.UserIDKey, u3.ID)
+	})
+
+	AfterEach(func() {
+		if db != nil {
+			db.Close()
+		}
+		if logger != nil {
+			logger.Sync()
+		}
+	})
+
+	Describe("GetEvents", func() {
+		It("should return call events channel", func() {
+			Expect(api.GetEvents()).To(Equal(events))
+		})
+	})
+
+	Describe("StartCall", func() {
+		It("should create ringing call and notify participants", func() {
+			c, err := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+			Expect(err).To(BeNil())
+			Expect(c.Id).NotTo(BeEmpty())
+			Expect(c.CallerID).To(Equal("u1"))
+			Expect(c.CalleeID).To(Equal("u2"))
+			Expect(c.State).To(Equal(protos.CallStates_Ringing))
+			Expect(c.RingingUntil.Seconds).To(BeNumerically("~", time.Now().Add(time.Minute).Unix(), 1))
+			event := <-events
+			Expect(event.Call.ID).To(Equal(c.Id))
+			Expect(event.Call.State).To(Equal(call.CallRinging))
+		})
+
+		When("callee not share any room with caller", func() {
+			It("should return permission denied error", func() {
+				_, err := api.StartCall(caller, &protos.StartCallParam{UserID: "u3"})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Expect(events).NotTo(Receive())
+			})
+		})
+
+		When("user call itself", func() {
+			It("should return invalid argument error", func() {
+				_, err := api.StartCall(caller, &protos.StartCallParam{UserID: "u1"})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Describe("AcceptCall", func() {
+		It("should activate ringing call", func() {
+			c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+			<-events
+			res, err := api.AcceptCall(callee, &protos.CallParam{Id: c.Id})
+			Expect(err).To(BeNil())
+			Expect(res.State).To(Equal(protos.CallStates_Active))
+			Expect(res.AnsweredAt).NotTo(BeNil())
+			event := <-events
+			Expect(event.Call.State).To(Equal(call.CallActive))
+		})
+
+		When("caller accept it's own call", func() {
+			It("should return permission denied error", func() {
+				c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+				_, err := api.AcceptCall(caller, &protos.CallParam{Id: c.Id})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+		})
+
+		When("user not participate in the call", func() {
+			It("should return not found error", func() {
+				c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+				_, err := api.AcceptCall(other, &protos.CallParam{Id: c.Id})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+
+		When("call already accepted", func() {
+			It("should return failed precondition error", func() {
+				c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+				api.AcceptCall(callee, &protos.CallParam{Id: c.Id})
+				_, err := api.AcceptCall(callee, &protos.CallParam{Id: c.Id})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			})
+		})
+
+		When("ring timeout passed", func() {
+			It("should mark call missed", func() {
+				api.RingTimeout = -time.Second
+				c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+				<-events
+				_, err := api.AcceptCall(callee, &protos.CallParam{Id: c.Id})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+				event := <-events
+				Expect(event.Call.State).To(Equal(call.CallMissed))
+			})
+		})
+	})
+
+	Describe("RejectCall", func() {
+		It("should reject ringing call", func() {
+			c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+			res, err := api.RejectCall(callee, &protos.CallParam{Id: c.Id})
+			Expect(err).To(BeNil())
+			Expect(res.State).To(Equal(protos.CallStates_Rejected))
+			Expect(res.EndedBy).To(Equal("u2"))
+		})
+	})
+
+	Describe("CancelCall", func() {
+		It("should cancel ringing call", func() {
+			c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+			res, err := api.CancelCall(caller, &protos.CallParam{Id: c.Id})
+			Expect(err).To(BeNil())
+			Expect(res.State).To(Equal(protos.CallStates_Cancelled))
+			Expect(res.EndedBy).To(Equal("u1"))
+		})
+
+		When("callee cancel the call", func() {
+			It("should return permission denied error", func() {
+				c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+				_, err := api.CancelCall(callee, &protos.CallParam{Id: c.Id})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+		})
+	})
+
+	Describe("HangupCall", func() {
+		It("should end active call", func() {
+			c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+			api.AcceptCall(callee, &protos.CallParam{Id: c.Id})
+			res, err := api.HangupCall(caller, &protos.CallParam{Id: c.Id})
+			Expect(err).To(BeNil())
+			Expect(res.State).To(Equal(protos.CallStates_Ended))
+			Expect(res.EndedAt).NotTo(BeNil())
+		})
+
+		When("call still ringing", func() {
+			It("should return failed precondition error", func() {
+				c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+				_, err := api.HangupCall(callee, &protos.CallParam{Id: c.Id})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			})
+		})
+	})
+
+	Describe("ExpireCall", func() {
+		It("should not expire call still within ring timeout", func() {
+			c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+			expired, err := api.ExpireCall(c.Id)
+			Expect(err).To(BeNil())
+			Expect(expired).To(BeFalse())
+		})
+
+		It("should expire ringing call after ring timeout", func(done Done) {
+			api.RingTimeout = time.Millisecond * 10
+			c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+			<-events
+			event := <-events
+			Expect(event.Call.ID).To(Equal(c.Id))
+			Expect(event.Call.State).To(Equal(call.CallMissed))
+			close(done)
+		}, 0.3)
+	})
+
+	Describe("SweepCalls", func() {
+		It("should mark ringing call missed after ring timeout without it's timer", func() {
+			now := time.Now()
+			db.Create(&call.CallModel{
+				ID:           "c1",
+				CallerID:     "u1",
+				CalleeID:     "u2",
+				State:        call.CallRinging,
+				CreatedAt:    now.Add(-time.Minute),
+				RingingUntil: now.Add(-time.Second),
+			})
+			db.Create(&call.CallModel{
+				ID:           "c2",
+				CallerID:     "u1",
+				CalleeID:     "u4",
+				State:        call.CallRinging,
+				CreatedAt:    now,
+				RingingUntil: now.Add(time.Minute),
+			})
+			closed, err := api.SweepCalls(now)
+			Expect(err).To(BeNil())
+			Expect(closed).To(ConsistOf("c1"))
+			event := <-events
+			Expect(event.Call.ID).To(Equal("c1"))
+			Expect(event.Call.State).To(Equal(call.CallMissed))
+		})
+
+		It("should end active call when participant has no session", func() {
+			release, err := peers.KeepSubscribed("u1", "", signaling.DeliveryKindSDP)
+			Expect(err).To(BeNil())
+			defer release()
+			c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+			api.AcceptCall(callee, &protos.CallParam{Id: c.Id})
+			<-events
+			<-events
+			closed, err := api.SweepCalls(time.Now())
+			Expect(err).To(BeNil())
+			Expect(closed).To(ConsistOf(c.Id))
+			event := <-events
+			Expect(event.Call.State).To(Equal(call.CallEnded))
+			Expect(event.Call.EndedBy).To(Equal("u2"))
+		})
+
+		It("should keep active call while participants have session", func() {
+			for _, userID := range []string{"u1", "u2"} {
+				release, err := peers.KeepSubscribed(userID, "", signaling.DeliveryKindSDP)
+				Expect(err).To(BeNil())
+				defer release()
+			}
+			c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+			api.AcceptCall(callee, &protos.CallParam{Id: c.Id})
+			<-events
+			<-events
+			closed, err := api.SweepCalls(time.Now())
+			Expect(err).To(BeNil())
+			Expect(closed).To(BeEmpty())
+			Expect(events).NotTo(Receive())
+		})
+	})
+
+	Describe("SubscribeCallEvent", func() {
+		It("should only receive events of calls user participate in", func(done Done) {
+			in := make(chan *call.CallEvent)
+			out := make(chan *protos.CallEvent)
+			go api.SubscribeCallEvent(callee, in, out)
+			go func() {
+				in <- &call.CallEvent{
+					Call: &call.CallModel{ID: "c1", CallerID: "u1", CalleeID: "u3"},
+					Time: time.Now(),
+				}
+				in <- &call.CallEvent{
+					Call: &call.CallModel{ID: "c2", CallerID: "u1", CalleeID: "u2", State: call.CallRinging},
+					Time: time.Now(),
+				}
+			}()
+			event := <-out
+			Expect(event.Call.Id).To(Equal("c2"))
+			Expect(event.Call.State).To(Equal(protos.CallStates_Ringing))
+			close(done)
+		}, 0.3)
+	})
+
+	Describe("ShouldOffer", func() {
+		It("should let peer with lower user id offer", func() {
+			Expect(call.ShouldOffer("u1", "u2")).To(BeTrue())
+			Expect(call.ShouldOffer("u2", "u1")).To(BeFalse())
+		})
+	})
+
+	Describe("JoinRoomCall", func() {
+		It("should tell joiner whom to offer and whom to wait", func() {
+			u4 := context.WithValue(context.Background(), room.UserIDKey, "u4")
+			_, err := api.JoinRoomCall(caller, &protos.RoomCallParam{RoomID: "r1"})
+			Expect(err).To(BeNil())
+			_, err = api.JoinRoomCall(u4, &protos.RoomCallParam{RoomID: "r1"})
+			Expect(err).To(BeNil())
+			res, err := api.JoinRoomCall(callee, &protos.RoomCallParam{RoomID: "r1"})
+			Expect(err).To(BeNil())
+			Expect(res.ParticipantIDs).To(ConsistOf("u1", "u4"))
+			Expect(res.OfferTo).To(ConsistOf("u4"))
+			Expect(res.AnswerTo).To(ConsistOf("u1"))
+		})
+
+		It("should notify other participants", func() {
+			api.JoinRoomCall(caller, &protos.RoomCallParam{RoomID: "r1"})
+			<-rooms
+			api.JoinRoomCall(callee, &protos.RoomCallParam{RoomID: "r1"})
+			event := <-rooms
+			Expect(event.Event).To(Equal(call.ParticipantJoined))
+			Expect(event.UserID).To(Equal("u2"))
+			Expect(event.Participants).To(ConsistOf("u1"))
+		})
+
+		When("user join again", func() {
+			It("should not notify other participants twice", func() {
+				api.JoinRoomCall(caller, &protos.RoomCallParam{RoomID: "r1"})
+				<-rooms
+				api.JoinRoomCall(callee, &protos.RoomCallParam{RoomID: "r1"})
+				<-rooms
+				res, err := api.JoinRoomCall(callee, &protos.RoomCallParam{RoomID: "r1"})
+				Expect(err).To(BeNil())
+				Expect(res.ParticipantIDs).To(ConsistOf("u1"))
+				Expect(rooms).NotTo(Receive())
+			})
+		})
+
+		When("user not member of the room", func() {
+			It("should return permission denied error", func() {
+				_, err := api.JoinRoomCall(other, &protos.RoomCallParam{RoomID: "r1"})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+		})
+	})
+
+	Describe("LeaveRoomCall", func() {
+		It("should remove user and notify the rest", func() {
+			api.JoinRoomCall(caller, &protos.RoomCallParam{RoomID: "r1"})
+			api.JoinRoomCall(callee, &protos.RoomCallParam{RoomID: "r1"})
+			<-rooms
+			<-rooms
+			err := api.LeaveRoomCall(caller, &protos.RoomCallParam{RoomID: "r1"})
+			Expect(err).To(BeNil())
+			event := <-rooms
+			Expect(event.Event).To(Equal(call.ParticipantLeft))
+			Expect(event.UserID).To(Equal("u1"))
+			Expect(event.Participants).To(ConsistOf("u2"))
+			participants, _ := api.GetRoomCallParticipants("r1")
+			Expect(participants).To(ConsistOf("u2"))
+		})
+
+		When("user not in the call", func() {
+			It("should not notify anyone", func() {
+				err := api.LeaveRoomCall(caller, &protos.RoomCallParam{RoomID: "r1"})
+				Expect(err).To(BeNil())
+				Expect(rooms).NotTo(Receive())
+			})
+		})
+	})
+
+	Describe("SweepRoomCalls", func() {
+		It("should remove participant with no session left", func() {
+			release, err := peers.KeepSubscribed("u1", "", signaling.DeliveryKindSDP)
+			Expect(err).To(BeNil())
+			defer release()
+			api.JoinRoomCall(caller, &protos.RoomCallParam{RoomID: "r1"})
+			api.JoinRoomCall(callee, &protos.RoomCallParam{RoomID: "r1"})
+			<-rooms
+			<-rooms
+			removed, err := api.SweepRoomCalls(time.Now())
+			Expect(err).To(BeNil())
+			Expect(removed).To(Equal(map[string][]string{"r1": {"u2"}}))
+			event := <-rooms
+			Expect(event.Event).To(Equal(call.ParticipantLeft))
+			Expect(event.UserID).To(Equal("u2"))
+			participants, _ := api.GetRoomCallParticipants("r1")
+			Expect(participants).To(ConsistOf("u1"))
+		})
+
+		It("should remove participant no longer member of the room", func() {
+			for _, userID := range []string{"u1", "u2"} {
+				release, err := peers.KeepSubscribed(userID, "", signaling.DeliveryKindSDP)
+				Expect(err).To(BeNil())
+				defer release()
+			}
+			api.JoinRoomCall(caller, &protos.RoomCallParam{RoomID: "r1"})
+			api.JoinRoomCall(callee, &protos.RoomCallParam{RoomID: "r1"})
+			<-rooms
+			<-rooms
+			db.Exec("DELETE FROM room_members WHERE room_model_id = ? AND user_model_id = ?", "r1", "u2")
+			removed, err := api.SweepRoomCalls(time.Now())
+			Expect(err).To(BeNil())
+			Expect(removed).To(Equal(map[string][]string{"r1": {"u2"}}))
+			participants, _ := api.GetRoomCallParticipants("r1")
+			Expect(participants).To(ConsistOf("u1"))
+		})
+
+		It("should keep connected members", func() {
+			release, err := peers.KeepSubscribed("u1", "", signaling.DeliveryKindSDP)
+			Expect(err).To(BeNil())
+			defer release()
+			api.JoinRoomCall(caller, &protos.RoomCallParam{RoomID: "r1"})
+			<-rooms
+			removed, err := api.SweepRoomCalls(time.Now())
+			Expect(err).To(BeNil())
+			Expect(removed).To(BeEmpty())
+			Expect(rooms).NotTo(Receive())
+		})
+	})
+
+	Describe("SubscribeRoomCallEvent", func() {
+		It("should tell participant to offer to joiner with higher user id", func(done Done) {
+			in := make(chan *call.RoomCallEvent)
+			out := make(chan *protos.RoomCallEvent)
+			go api.SubscribeRoomCallEvent(caller, in, out)
+			go func() {
+				in <- &call.RoomCallEvent{
+					Event: call.ParticipantJoined, RoomID: "r9", UserID: "u3",
+					Participants: []string{"u2"}, Time: time.Now(),
+				}
+				in <- &call.RoomCallEvent{
+					Event: call.ParticipantJoined, RoomID: "r1", UserID: "u2",
+					Participants: []string{"u1"}, Time: time.Now(),
+				}
+			}()
+			event := <-out
+			Expect(event.Event).To(Equal(protos.RoomCallEvents_ParticipantJoined))
+			Expect(event.RoomID).To(Equal("r1"))
+			Expect(event.UserID).To(Equal("u2"))
+			Expect(event.ShouldOffer).To(BeTrue())
+			close(done)
+		}, 0.3)
+	})
+
+	Describe("AuthorizeSession", func() {
+		It("should allow participants of open call", func() {
+			c, _ := api.StartCall(caller, &protos.StartCallParam{UserID: "u2"})
+			Expect(call.AuthorizeSession(db, c.Id, "u1", "u2")).To(BeNil())
+			err := call.AuthorizeSession(db, c.Id, "u1", "u3")
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+			api.CancelCall(caller, &protos.CallParam{Id: c.Id})
+			err = call.AuthorizeSession(db, c.Id, "u1", "u2")
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+		})
+	})
+})
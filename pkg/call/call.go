@@ -0,0 +1,104 @@
+package call
+
+import (
+	"context"
+	"time"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/room"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+)
+
+const (
+	// CallStateChanged emitted when call started, answered or ended
+	CallStateChanged = "chat.call.state-changed"
+	// RoomCallChanged emitted when user join or leave room call
+	RoomCallChanged = "chat.call.room-changed"
+)
+
+const (
+	// ParticipantJoined is room call event when user join the call
+	ParticipantJoined = "joined"
+	// ParticipantLeft is room call event when user leave the call
+	ParticipantLeft = "left"
+)
+
+const (
+	// CallRinging is state of call waiting callee to answer
+	CallRinging = "ringing"
+	// CallActive is state of call accepted by callee
+	CallActive = "active"
+	// CallRejected is state of call rejected by callee
+	CallRejected = "rejected"
+	// CallCancelled is state of call cancelled by caller before answered
+	CallCancelled = "cancelled"
+	// CallMissed is state of call not answered before ring timeout
+	CallMissed = "missed"
+	// CallEnded is state of active call hung up by a participant
+	CallEnded = "ended"
+)
+
+// DefaultRingTimeout is how long call ringing before missed
+const DefaultRingTimeout = time.Second * 30
+
+// DefaultSweepInterval is how often calls left open by crashed instances
+// or disconnected users checked
+const DefaultSweepInterval = time.Second * 10
+
+// CallStateToProto map call state to protobuf
+var CallStateToProto = map[string]protos.CallStates{
+	CallRinging:   protos.CallStates_Ringing,
+	CallActive:    protos.CallStates_Active,
+	CallRejected:  protos.CallStates_Rejected,
+	CallCancelled: protos.CallStates_Cancelled,
+	CallMissed:    protos.CallStates_Missed,
+	CallEnded:     protos.CallStates_Ended,
+}
+
+// CallEvent contain call state emitted to it's participants
+type CallEvent struct {
+	Call *CallModel `json:"call"`
+	Time time.Time  `json:"time"`
+}
+
+// RoomCallEvent contain user joined or left room call,
+// emitted to other participants of the call
+type RoomCallEvent struct {
+	Event        string    `json:"event"`
+	RoomID       string    `json:"room_id"`
+	UserID       string    `json:"user_id"`
+	Participants []string  `json:"participants"`
+	Time         time.Time `json:"time"`
+}
+
+// IPeers authorize user to call other user or room
+type IPeers interface {
+	GetUserContext(ctx context.Context) (*room.UserModel, error)
+	AuthorizePeer(ctx context.Context, me *room.UserModel, userID string) error
+	GetRoomRecipients(ctx context.Context, me *room.UserModel, roomID string) ([]string, error)
+	HasSession(userID string, now time.Time) (bool, error)
+}
+
+// ICall is service to manage call sessions between users
+type ICall interface {
+	GetEvents() chan *CallEvent
+	SetEvents(events chan *CallEvent)
+	GetRoomCallEvents() chan *RoomCallEvent
+	SetRoomCallEvents(events chan *RoomCallEvent)
+	StartCall(ctx context.Context, param *protos.StartCallParam) (*protos.Call, error)
+	AcceptCall(ctx context.Context, param *protos.CallParam) (*protos.Call, error)
+	RejectCall(ctx context.Context, param *protos.CallParam) (*protos.Call, error)
+	CancelCall(ctx context.Context, param *protos.CallParam) (*protos.Call, error)
+	HangupCall(ctx context.Context, param *protos.CallParam) (*protos.Call, error)
+	SubscribeCallEvent(
+		ctx context.Context,
+		events <-chan *CallEvent,
+		protoEvents chan<- *protos.CallEvent,
+	) error
+	JoinRoomCall(ctx context.Context, param *protos.RoomCallParam) (*protos.RoomCall, error)
+	LeaveRoomCall(ctx context.Context, param *protos.RoomCallParam) error
+	SubscribeRoomCallEvent(
+		ctx context.Context,
+		events <-chan *RoomCallEvent,
+		protoEvents chan<- *protos.RoomCallEvent,
+	) error
+}
@@ -0,0 +1,13 @@
+package call_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCall(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Call Suite")
+}
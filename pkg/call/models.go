@@ -0,0 +1,72 @@
+package call
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+)
+
+// Models defined in call package
+var Models = []interface{}{
+	&CallModel{},
+	&RoomCallParticipantModel{},
+}
+
+// CallModel define call session between caller & callee
+type CallModel struct {
+	ID           string     `gorm:"primary_key;not null;size:100" json:"id"`
+	CallerID     string     `gorm:"column:caller_id;index;size:100" json:"caller_id"`
+	CalleeID     string     `gorm:"column:callee_id;index;size:100" json:"callee_id"`
+	State        string     `gorm:"column:state;size:20" json:"state"`
+	CreatedAt    time.Time  `gorm:"column:created_at" json:"created_at"`
+	RingingUntil time.Time  `gorm:"column:ringing_until" json:"ringing_until"`
+	AnsweredAt   *time.Time `gorm:"column:answered_at" json:"answered_at"`
+	EndedAt      *time.Time `gorm:"column:ended_at" json:"ended_at"`
+	EndedBy      string     `gorm:"column:ended_by;size:100" json:"ended_by"`
+}
+
+// RoomCallParticipantModel define user currently in a room call
+type RoomCallParticipantModel struct {
+	RoomID   string    `gorm:"primary_key;not null;size:100"`
+	UserID   string    `gorm:"primary_key;not null;size:100"`
+	JoinedAt time.Time `gorm:"column:joined_at"`
+}
+
+// IsParticipant return true when user is caller or callee
+func (c *CallModel) IsParticipant(userID string) bool {
+	return c.CallerID == userID || c.CalleeID == userID
+}
+
+// IsOpen return true when call still ringing or active
+func (c *CallModel) IsOpen() bool {
+	return c.State == CallRinging || c.State == CallActive
+}
+
+// CallModelToProto convert call model to protobuf
+func CallModelToProto(c *CallModel) *protos.Call {
+	return &protos.Call{
+		Id:           c.ID,
+		CallerID:     c.CallerID,
+		CalleeID:     c.CalleeID,
+		State:        CallStateToProto[c.State],
+		CreatedAt:    timestampProto(&c.CreatedAt),
+		RingingUntil: timestampProto(&c.RingingUntil),
+		AnsweredAt:   timestampProto(c.AnsweredAt),
+		EndedAt:      timestampProto(c.EndedAt),
+		EndedBy:      c.EndedBy,
+	}
+}
+
+// timestampProto convert optional time to protobuf timestamp
+func timestampProto(t *time.Time) *timestamp.Timestamp {
+	if t == nil {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(*t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
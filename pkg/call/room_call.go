@@ -0,0 +1,206 @@
+package call
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+)
+
+// RoomCallEventToProto map room call event to protobuf
+var RoomCallEventToProto = map[string]protos.RoomCallEvents{
+	ParticipantJoined: protos.RoomCallEvents_ParticipantJoined,
+	ParticipantLeft:   protos.RoomCallEvents_ParticipantLeft,
+}
+
+// ShouldOffer return true when user must send offer to peer on mesh call,
+// peer with lower user id always offer so every pair connected once
+func ShouldOffer(userID string, peerID string) bool {
+	return userID < peerID
+}
+
+// GetRoomCallEvents will return channel use to publish room call events
+func (a *API) GetRoomCallEvents() chan *RoomCallEvent {
+	return a.RoomEvents
+}
+
+// SetRoomCallEvents will set channel use to publish room call events
+func (a *API) SetRoomCallEvents(events chan *RoomCallEvent) {
+	a.RoomEvents = events
+}
+
+// JoinRoomCall will add user to call of a room, return other participants
+// and which of them user must send offer to
+func (a *API) JoinRoomCall(ctx context.Context, param *protos.RoomCallParam) (*protos.RoomCall, error) {
+	user, err := a.Peers.GetUserContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, err = a.Peers.GetRoomRecipients(ctx, user, param.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	joined, err := a.addRoomCallParticipant(param.RoomID, user.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := a.GetRoomCallParticipants(param.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	others := []string{}
+	for _, id := range participants {
+		if id != user.ID {
+			others = append(others, id)
+		}
+	}
+	// user joining again from other device or after reconnect already known by others
+	if joined {
+		a.emitRoomCall(ParticipantJoined, param.RoomID, user.ID, others, now)
+	}
+
+	roomCall := &protos.RoomCall{
+		RoomID:         param.RoomID,
+		ParticipantIDs: others,
+		OfferTo:        []string{},
+		AnswerTo:       []string{},
+	}
+	for _, id := range others {
+		if ShouldOffer(user.ID, id) {
+			roomCall.OfferTo = append(roomCall.OfferTo, id)
+		} else {
+			roomCall.AnswerTo = append(roomCall.AnswerTo, id)
+		}
+	}
+	return roomCall, nil
+}
+
+// addRoomCallParticipant will add user to room call,
+// return false when user already in the call
+func (a *API) addRoomCallParticipant(roomID string, userID string, now time.Time) (bool, error) {
+	err := a.DB.Create(&RoomCallParticipantModel{
+		RoomID:   roomID,
+		UserID:   userID,
+		JoinedAt: now,
+	}).Error
+	if err == nil {
+		return true, nil
+	}
+	count := 0
+	countErr := a.DB.Model(&RoomCallParticipantModel{}).
+		Where("room_id = ? AND user_id = ?", roomID, userID).
+		Count(&count).
+		Error
+	if countErr == nil && count > 0 {
+		return false, nil
+	}
+	return false, err
+}
+
+// LeaveRoomCall will remove user from call of a room
+func (a *API) LeaveRoomCall(ctx context.Context, param *protos.RoomCallParam) error {
+	user, err := a.Peers.GetUserContext(ctx)
+	if err != nil {
+		return err
+	}
+	return a.RemoveRoomCallParticipant(param.RoomID, user.ID)
+}
+
+// RemoveRoomCallParticipant will remove user from room call and notify the rest,
+// nothing happen when user not in the call
+func (a *API) RemoveRoomCallParticipant(roomID string, userID string) error {
+	res := a.DB.
+		Where("room_id = ? AND user_id = ?", roomID, userID).
+		Delete(&RoomCallParticipantModel{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil
+	}
+	participants, err := a.GetRoomCallParticipants(roomID)
+	if err != nil {
+		return err
+	}
+	a.emitRoomCall(ParticipantLeft, roomID, userID, participants, time.Now())
+	return nil
+}
+
+// GetRoomCallParticipants return users currently in a room call
+func (a *API) GetRoomCallParticipants(roomID string) ([]string, error) {
+	participants := []*RoomCallParticipantModel{}
+	err := a.DB.
+		Where("room_id = ?", roomID).
+		Order("joined_at").
+		Find(&participants).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, p := range participants {
+		ids = append(ids, p.UserID)
+	}
+	return ids, nil
+}
+
+// SubscribeRoomCallEvent will return join & leave of room calls user participate in
+func (a *API) SubscribeRoomCallEvent(
+	ctx context.Context,
+	events <-chan *RoomCallEvent,
+	protoEvents chan<- *protos.RoomCallEvent,
+) error {
+	user, err := a.Peers.GetUserContext(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case event := <-events:
+			if event == nil || event.UserID == user.ID {
+				continue
+			}
+			if !utils.ContainString(event.Participants, user.ID) {
+				continue
+			}
+			t, err := ptypes.TimestampProto(event.Time)
+			if err != nil {
+				a.Logger.Error(err)
+				continue
+			}
+			select {
+			case protoEvents <- &protos.RoomCallEvent{
+				Event:       RoomCallEventToProto[event.Event],
+				RoomID:      event.RoomID,
+				UserID:      event.UserID,
+				ShouldOffer: event.Event == ParticipantJoined && ShouldOffer(user.ID, event.UserID),
+				Time:        t,
+			}:
+			case <-ctx.Done():
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// emitRoomCall will publish room call changes to other participants
+func (a *API) emitRoomCall(
+	event string,
+	roomID string,
+	userID string,
+	participants []string,
+	t time.Time,
+) {
+	a.RoomEvents <- &RoomCallEvent{
+		Event:        event,
+		RoomID:       roomID,
+		UserID:       userID,
+		Participants: participants,
+		Time:         t,
+	}
+}
@@ -0,0 +1,152 @@
+package call
+
+import (
+	"context"
+	"time"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/room"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// KeepSweeping will periodically close calls & room call participation left open until
+// released, ring timeout of a call kept on database so calls started by crashed instance still missed
+func (a *API) KeepSweeping(interval time.Duration) func() {
+	if interval <= 0 {
+		interval = DefaultSweepInterval
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				_, err := a.SweepCalls(now)
+				if err != nil {
+					a.Logger.Errorf("failed to sweep calls -> %v", err)
+				}
+				_, err = a.SweepRoomCalls(now)
+				if err != nil {
+					a.Logger.Errorf("failed to sweep room calls -> %v", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
+// SweepCalls will mark ringing calls past their ring timeout as missed and end
+// active calls which participant has no session left, return calls closed
+func (a *API) SweepCalls(now time.Time) ([]string, error) {
+	closed := []string{}
+	ringing := []*CallModel{}
+	err := a.DB.
+		Where("state = ? AND ringing_until <= ?", CallRinging, now).
+		Find(&ringing).
+		Error
+	if err != nil {
+		return closed, err
+	}
+	for _, c := range ringing {
+		expired, err := a.ExpireCall(c.ID)
+		if err != nil {
+			return closed, err
+		}
+		if expired {
+			closed = append(closed, c.ID)
+		}
+	}
+
+	active := []*CallModel{}
+	err = a.DB.Where("state = ?", CallActive).Find(&active).Error
+	if err != nil {
+		return closed, err
+	}
+	for _, c := range active {
+		for _, userID := range []string{c.CallerID, c.CalleeID} {
+			connected, err := a.Peers.HasSession(userID, now)
+			if err != nil {
+				return closed, err
+			}
+			if connected {
+				continue
+			}
+			ended, err := a.endCall(c.ID, userID, now)
+			if err != nil {
+				return closed, err
+			}
+			if ended {
+				closed = append(closed, c.ID)
+			}
+			break
+		}
+	}
+	return closed, nil
+}
+
+// SweepRoomCalls will remove room call participants with no session left
+// or no longer member of the room, return users removed from each room
+func (a *API) SweepRoomCalls(now time.Time) (map[string][]string, error) {
+	removed := map[string][]string{}
+	participants := []*RoomCallParticipantModel{}
+	err := a.DB.Find(&participants).Error
+	if err != nil {
+		return removed, err
+	}
+	for _, p := range participants {
+		connected, err := a.Peers.HasSession(p.UserID, now)
+		if err != nil {
+			return removed, err
+		}
+		member := true
+		if connected {
+			_, err = a.Peers.GetRoomRecipients(context.Background(), &room.UserModel{ID: p.UserID}, p.RoomID)
+			if status.Code(err) == codes.PermissionDenied {
+				member, err = false, nil
+			}
+			if err != nil {
+				return removed, err
+			}
+		}
+		if connected && member {
+			continue
+		}
+		err = a.RemoveRoomCallParticipant(p.RoomID, p.UserID)
+		if err != nil {
+			return removed, err
+		}
+		removed[p.RoomID] = append(removed[p.RoomID], p.UserID)
+	}
+	return removed, nil
+}
+
+// endCall will end active call on behalf of participant,
+// return false when call no longer active
+func (a *API) endCall(id string, userID string, now time.Time) (bool, error) {
+	res := a.DB.Model(&CallModel{}).
+		Where("id = ? AND state = ?", id, CallActive).
+		Updates(map[string]interface{}{
+			"state":    CallEnded,
+			"ended_at": now,
+			"ended_by": userID,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+	c := &CallModel{}
+	err := a.DB.First(c, "id = ?", id).Error
+	if err != nil {
+		return true, err
+	}
+	a.emit(c, now)
+	return true, nil
+}
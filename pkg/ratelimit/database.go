@@ -0,0 +1,159 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+)
+
+// maxAttempts is number of retry when bucket updated concurrently
+const maxAttempts = 5
+
+// NewDatabaseStore will create store that share limits between instances
+func NewDatabaseStore(db *gorm.DB) *Database {
+	return &Database{DB: db}
+}
+
+// Database is store of token buckets & stream leases shared by all instances
+type Database struct {
+	DB       *gorm.DB
+	mutex    sync.Mutex
+	prunedAt time.Time
+}
+
+// Take will take a token from bucket,
+// bucket updated only when nobody else updated it since read
+func (d *Database) Take(
+	ctx context.Context,
+	key string,
+	limit Limit,
+	now time.Time,
+) (time.Duration, error) {
+	err := d.prune(now)
+	if err != nil {
+		return 0, err
+	}
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		b := &BucketModel{}
+		err := d.DB.Where("id = ?", key).First(b).Error
+		if gorm.IsRecordNotFoundError(err) {
+			err = d.DB.Create(&BucketModel{
+				ID:         key,
+				Tokens:     float64(limit.Burst) - 1,
+				RefilledAt: now,
+				FullAt:     fullAt(float64(limit.Burst)-1, limit, now),
+			}).Error
+			if err == nil {
+				return 0, nil
+			}
+			// bucket created by other instance
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		tokens := refill(b.Tokens, b.RefilledAt, limit, now)
+		if tokens < 1 {
+			return retryAfter(tokens, limit), fmt.Errorf(LimitExceededError)
+		}
+		res := d.DB.Model(&BucketModel{}).
+			Where("id = ? AND version = ?", key, b.Version).
+			Updates(map[string]interface{}{
+				"tokens":      tokens - 1,
+				"refilled_at": now,
+				"full_at":     fullAt(tokens-1, limit, now),
+				"version":     b.Version + 1,
+			})
+		if res.Error != nil {
+			return 0, res.Error
+		}
+		if res.RowsAffected == 1 {
+			return 0, nil
+		}
+	}
+	return 0, fmt.Errorf(ConflictError)
+}
+
+// prune will delete buckets refilled to full, they behave the same as new bucket
+func (d *Database) prune(now time.Time) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if now.Sub(d.prunedAt) < pruneInterval {
+		return nil
+	}
+	d.prunedAt = now
+	return d.DB.Where("full_at <= ?", now).Delete(&BucketModel{}).Error
+}
+
+// BucketCount return number of buckets kept on database
+func (d *Database) BucketCount() (int, error) {
+	count := 0
+	err := d.DB.Model(&BucketModel{}).Count(&count).Error
+	return count, err
+}
+
+// Acquire will count new stream until it's lease expired,
+// lease dropped when other instance acquired the last slot at the same time
+func (d *Database) Acquire(
+	ctx context.Context,
+	key string,
+	max int,
+	ttl time.Duration,
+) (string, error) {
+	now := time.Now()
+	count := 0
+	err := d.DB.Model(&StreamLeaseModel{}).
+		Where("key = ? AND expires_at > ?", key, now).
+		Count(&count).
+		Error
+	if err != nil {
+		return "", err
+	}
+	if count >= max {
+		return "", fmt.Errorf(TooManyStreamsError)
+	}
+	id, err := utils.GenerateTokenID()
+	if err != nil {
+		return "", err
+	}
+	err = d.DB.Create(&StreamLeaseModel{
+		ID:        id,
+		Key:       key,
+		ExpiresAt: now.Add(ttl),
+	}).Error
+	if err != nil {
+		return "", err
+	}
+	err = d.DB.Model(&StreamLeaseModel{}).
+		Where("key = ? AND expires_at > ?", key, now).
+		Count(&count).
+		Error
+	if err != nil {
+		return "", err
+	}
+	if count > max {
+		d.Release(ctx, id)
+		return "", fmt.Errorf(TooManyStreamsError)
+	}
+	return id, nil
+}
+
+// Renew will extend stream lease
+func (d *Database) Renew(ctx context.Context, lease string, ttl time.Duration) error {
+	return d.DB.Model(&StreamLeaseModel{}).
+		Where("id = ?", lease).
+		Update("expires_at", time.Now().Add(ttl)).
+		Error
+}
+
+// Release will stop counting stream and forget expired leases
+func (d *Database) Release(ctx context.Context, lease string) error {
+	return d.DB.
+		Where("id = ? OR expires_at < ?", lease, time.Now()).
+		Delete(&StreamLeaseModel{}).
+		Error
+}
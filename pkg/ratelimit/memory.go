@@ -0,0 +1,125 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+)
+
+// pruneInterval is how often idle buckets forgotten
+const pruneInterval = time.Minute
+
+// NewMemoryStore will create store that keep limits on this instance only
+func NewMemoryStore() *Memory {
+	return &Memory{
+		buckets: map[string]*bucket{},
+		streams: map[string]int{},
+		leases:  map[string]string{},
+	}
+}
+
+// bucket is token bucket state
+type bucket struct {
+	tokens     float64
+	refilledAt time.Time
+	limit      Limit
+}
+
+// Memory is in-process store of token buckets & stream leases
+type Memory struct {
+	mutex    sync.Mutex
+	buckets  map[string]*bucket
+	streams  map[string]int
+	leases   map[string]string
+	prunedAt time.Time
+}
+
+// Take will take a token from bucket
+func (m *Memory) Take(
+	ctx context.Context,
+	key string,
+	limit Limit,
+	now time.Time,
+) (time.Duration, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.prune(now)
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), refilledAt: now}
+		m.buckets[key] = b
+	}
+	b.limit = limit
+	b.tokens = refill(b.tokens, b.refilledAt, limit, now)
+	b.refilledAt = now
+	if b.tokens < 1 {
+		return retryAfter(b.tokens, limit), fmt.Errorf(LimitExceededError)
+	}
+	b.tokens--
+	return 0, nil
+}
+
+// prune will forget buckets refilled to full, they behave the same as new bucket
+func (m *Memory) prune(now time.Time) {
+	if now.Sub(m.prunedAt) < pruneInterval {
+		return
+	}
+	m.prunedAt = now
+	for key, b := range m.buckets {
+		if refill(b.tokens, b.refilledAt, b.limit, now) >= float64(b.limit.Burst) {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+// BucketCount return number of buckets kept in memory
+func (m *Memory) BucketCount() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return len(m.buckets)
+}
+
+// Acquire will count new stream, lease never expire on memory store
+func (m *Memory) Acquire(
+	ctx context.Context,
+	key string,
+	max int,
+	ttl time.Duration,
+) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.streams[key] >= max {
+		return "", fmt.Errorf(TooManyStreamsError)
+	}
+	lease, err := utils.GenerateTokenID()
+	if err != nil {
+		return "", err
+	}
+	m.streams[key]++
+	m.leases[lease] = key
+	return lease, nil
+}
+
+// Renew do nothing since lease never expire on memory store
+func (m *Memory) Renew(ctx context.Context, lease string, ttl time.Duration) error {
+	return nil
+}
+
+// Release will stop counting stream
+func (m *Memory) Release(ctx context.Context, lease string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	key, ok := m.leases[lease]
+	if !ok {
+		return nil
+	}
+	delete(m.leases, lease)
+	m.streams[key]--
+	if m.streams[key] <= 0 {
+		delete(m.streams, key)
+	}
+	return nil
+}
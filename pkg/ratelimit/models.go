@@ -0,0 +1,26 @@
+package ratelimit
+
+import "time"
+
+// Models defined in ratelimit package
+var Models = []interface{}{
+	&BucketModel{},
+	&StreamLeaseModel{},
+}
+
+// BucketModel define token bucket shared by all instances,
+// version used to detect concurrent update, bucket pruned once full
+type BucketModel struct {
+	ID         string    `gorm:"primary_key;not null;size:200"`
+	Tokens     float64   `gorm:"column:tokens"`
+	RefilledAt time.Time `gorm:"column:refilled_at"`
+	FullAt     time.Time `gorm:"column:full_at;index"`
+	Version    int64     `gorm:"column:version"`
+}
+
+// StreamLeaseModel define open stream counted until it's lease expired
+type StreamLeaseModel struct {
+	ID        string    `gorm:"primary_key;not null;size:100"`
+	Key       string    `gorm:"column:key;index;size:200"`
+	ExpiresAt time.Time `gorm:"column:expires_at;index"`
+}
@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Stores supported by limiter
+const (
+	MemoryStore   = "memory"
+	DatabaseStore = "database"
+)
+
+const (
+	// LimitExceededError returned when call exceed it's rate limit
+	LimitExceededError = "rate limit exceeded"
+	// TooManyStreamsError returned when user open too many streams
+	TooManyStreamsError = "too many open streams"
+	// ConflictError returned when bucket kept updated by other calls,
+	// call let through since limit not known to be exceeded
+	ConflictError = "rate limit bucket updated concurrently"
+)
+
+// Limit define token bucket, bucket refilled by rate tokens per second
+// up to burst tokens, limit with zero rate is unlimited
+type Limit struct {
+	Rate  float64 `json:"rate" mapstructure:"rate"`
+	Burst int     `json:"burst" mapstructure:"burst"`
+}
+
+// Unlimited return true when limit not enforced
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+// normalize make sure bucket can hold at least a token
+func (l Limit) normalize() Limit {
+	if l.Burst < 1 {
+		l.Burst = int(math.Max(1, math.Ceil(l.Rate)))
+	}
+	return l
+}
+
+// DefaultStreamLease is stream lease used when not configured
+const DefaultStreamLease = time.Minute
+
+// MethodLimit define per user limit of a method
+type MethodLimit struct {
+	Method string  `json:"method" mapstructure:"method"`
+	Rate   float64 `json:"rate" mapstructure:"rate"`
+	Burst  int     `json:"burst" mapstructure:"burst"`
+}
+
+// Config define rate limits configuration
+type Config struct {
+	// Store is where buckets kept, use database to enforce limits cluster-wide
+	Store string `json:"store" mapstructure:"store"`
+	// Methods define per user limit of each method
+	Methods []MethodLimit `json:"methods" mapstructure:"methods"`
+	// Default is per user limit of methods not listed on methods
+	Default Limit `json:"default" mapstructure:"default"`
+	// PerIP is limit of all calls from an IP address
+	PerIP Limit `json:"per_ip" mapstructure:"per_ip"`
+	// MaxStreams is number of concurrently open streams per user, zero is unlimited
+	MaxStreams int `json:"max_streams" mapstructure:"max_streams"`
+	// StreamLease is how long stream counted when it's holder stop renew it
+	StreamLease time.Duration `json:"stream_lease" mapstructure:"stream_lease"`
+}
+
+// IStore keep token buckets & stream leases
+type IStore interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (time.Duration, error)
+	Acquire(ctx context.Context, key string, max int, ttl time.Duration) (string, error)
+	Renew(ctx context.Context, lease string, ttl time.Duration) error
+	Release(ctx context.Context, lease string) error
+}
+
+// New will create new instance of limiter
+func New(store IStore, config Config) *Limiter {
+	methods := map[string]Limit{}
+	for _, m := range config.Methods {
+		methods[m.Method] = Limit{Rate: m.Rate, Burst: m.Burst}.normalize()
+	}
+	config.Default = config.Default.normalize()
+	config.PerIP = config.PerIP.normalize()
+	if config.StreamLease <= 0 {
+		config.StreamLease = DefaultStreamLease
+	}
+	return &Limiter{
+		Store:   store,
+		Config:  config,
+		methods: methods,
+	}
+}
+
+// Limiter enforce rate limits of calls & number of open streams
+type Limiter struct {
+	Store   IStore
+	Config  Config
+	methods map[string]Limit
+}
+
+// MethodLimit return per user limit of a method
+func (l *Limiter) MethodLimit(method string) Limit {
+	if limit, ok := l.methods[method]; ok {
+		return limit
+	}
+	return l.Config.Default
+}
+
+// AllowIP will take token from bucket of an IP address,
+// return time to wait before retry when limit exceeded
+func (l *Limiter) AllowIP(ctx context.Context, ip string) (time.Duration, error) {
+	if len(ip) == 0 || l.Config.PerIP.Unlimited() {
+		return 0, nil
+	}
+	return l.Store.Take(ctx, "ip:"+ip, l.Config.PerIP, time.Now())
+}
+
+// AllowUser will take token from user bucket of a method,
+// return time to wait before retry when limit exceeded
+func (l *Limiter) AllowUser(ctx context.Context, userID string, method string) (time.Duration, error) {
+	limit := l.MethodLimit(method)
+	if len(userID) == 0 || limit.Unlimited() {
+		return 0, nil
+	}
+	return l.Store.Take(ctx, "user:"+userID+":"+method, limit, time.Now())
+}
+
+// OpenStream will count new stream of user, return lease of the stream
+// that must be released when stream closed
+func (l *Limiter) OpenStream(ctx context.Context, userID string) (string, error) {
+	if l.Config.MaxStreams <= 0 || len(userID) == 0 {
+		return "", nil
+	}
+	return l.Store.Acquire(ctx, "streams:"+userID, l.Config.MaxStreams, l.Config.StreamLease)
+}
+
+// RenewStream will keep stream counted for another lease period
+func (l *Limiter) RenewStream(ctx context.Context, lease string) error {
+	if len(lease) == 0 {
+		return nil
+	}
+	return l.Store.Renew(ctx, lease, l.Config.StreamLease)
+}
+
+// CloseStream will stop counting stream
+func (l *Limiter) CloseStream(ctx context.Context, lease string) error {
+	if len(lease) == 0 {
+		return nil
+	}
+	return l.Store.Release(ctx, lease)
+}
+
+// refill return tokens of bucket after refilled since last refill
+func refill(tokens float64, refilledAt time.Time, limit Limit, now time.Time) float64 {
+	elapsed := now.Sub(refilledAt).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(float64(limit.Burst), tokens+elapsed*limit.Rate)
+}
+
+// fullAt return when bucket refilled to it's burst,
+// full bucket behave the same as new bucket so it can be forgotten
+func fullAt(tokens float64, limit Limit, now time.Time) time.Time {
+	missing := float64(limit.Burst) - tokens
+	if missing <= 0 || limit.Rate <= 0 {
+		return now
+	}
+	return now.Add(time.Duration(missing / limit.Rate * float64(time.Second)))
+}
+
+// retryAfter return time needed to refill a token
+func retryAfter(tokens float64, limit Limit) time.Duration {
+	return time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+}
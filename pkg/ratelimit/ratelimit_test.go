@@ -0,0 +1,200 @@
+package ratelimit_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/connector"
+	"go.sirus.dev/p2p-comm/signalling/pkg/ratelimit"
+)
+
+var _ = Describe("Limiter", func() {
+	var (
+		db *gorm.DB
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = connector.ConnectToMemmory(ratelimit.Models)
+		if err != nil {
+			Fail(err.Error())
+		}
+	})
+
+	AfterEach(func() {
+		if db != nil {
+			db.Close()
+		}
+	})
+
+	stores := map[string]func() ratelimit.IStore{
+		"memory store": func() ratelimit.IStore {
+			return ratelimit.NewMemoryStore()
+		},
+		"database store": func() ratelimit.IStore {
+			return ratelimit.NewDatabaseStore(db)
+		},
+	}
+
+	for name, newStore := range stores {
+		newStore := newStore
+		Context("with "+name, func() {
+			ctx := context.Background()
+
+			It("limit calls per user & method", func() {
+				limiter := ratelimit.New(newStore(), ratelimit.Config{
+					Methods: []ratelimit.MethodLimit{
+						{Method: "SendICECandidate", Rate: 1, Burst: 2},
+					},
+				})
+				_, err := limiter.AllowUser(ctx, "u1", "SendICECandidate")
+				Expect(err).To(BeNil())
+				_, err = limiter.AllowUser(ctx, "u1", "SendICECandidate")
+				Expect(err).To(BeNil())
+				retryAfter, err := limiter.AllowUser(ctx, "u1", "SendICECandidate")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal(ratelimit.LimitExceededError))
+				Expect(retryAfter).To(BeNumerically(">", 0))
+				Expect(retryAfter).To(BeNumerically("<=", time.Second))
+				// other user & method has their own bucket
+				_, err = limiter.AllowUser(ctx, "u2", "SendICECandidate")
+				Expect(err).To(BeNil())
+				_, err = limiter.AllowUser(ctx, "u1", "OfferSessionDescription")
+				Expect(err).To(BeNil())
+			})
+
+			It("refill bucket over time", func() {
+				store := newStore()
+				limit := ratelimit.Limit{Rate: 10, Burst: 1}
+				now := time.Now()
+				_, err := store.Take(ctx, "k", limit, now)
+				Expect(err).To(BeNil())
+				_, err = store.Take(ctx, "k", limit, now)
+				Expect(err).NotTo(BeNil())
+				_, err = store.Take(ctx, "k", limit, now.Add(100*time.Millisecond))
+				Expect(err).To(BeNil())
+			})
+
+			It("limit calls per IP", func() {
+				limiter := ratelimit.New(newStore(), ratelimit.Config{
+					PerIP: ratelimit.Limit{Rate: 1, Burst: 1},
+				})
+				_, err := limiter.AllowIP(ctx, "10.0.0.1")
+				Expect(err).To(BeNil())
+				_, err = limiter.AllowIP(ctx, "10.0.0.1")
+				Expect(err).NotTo(BeNil())
+				_, err = limiter.AllowIP(ctx, "10.0.0.2")
+				Expect(err).To(BeNil())
+			})
+
+			It("not limit unlimited methods", func() {
+				limiter := ratelimit.New(newStore(), ratelimit.Config{})
+				for i := 0; i < 10; i++ {
+					_, err := limiter.AllowUser(ctx, "u1", "GetProfile")
+					Expect(err).To(BeNil())
+				}
+			})
+
+			It("limit open streams per user", func() {
+				limiter := ratelimit.New(newStore(), ratelimit.Config{MaxStreams: 2})
+				l1, err := limiter.OpenStream(ctx, "u1")
+				Expect(err).To(BeNil())
+				_, err = limiter.OpenStream(ctx, "u1")
+				Expect(err).To(BeNil())
+				_, err = limiter.OpenStream(ctx, "u1")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal(ratelimit.TooManyStreamsError))
+				_, err = limiter.OpenStream(ctx, "u2")
+				Expect(err).To(BeNil())
+				err = limiter.CloseStream(ctx, l1)
+				Expect(err).To(BeNil())
+				_, err = limiter.OpenStream(ctx, "u1")
+				Expect(err).To(BeNil())
+			})
+		})
+	}
+
+	Context("with memory store", func() {
+		It("forget idle buckets", func() {
+			ctx := context.Background()
+			store := ratelimit.NewMemoryStore()
+			limit := ratelimit.Limit{Rate: 10, Burst: 2}
+			now := time.Now()
+			_, err := store.Take(ctx, "idle", limit, now)
+			Expect(err).To(BeNil())
+			_, err = store.Take(ctx, "busy", limit, now.Add(time.Minute))
+			Expect(err).To(BeNil())
+			Expect(store.BucketCount()).To(Equal(1))
+			// pruned bucket start full again
+			_, err = store.Take(ctx, "idle", limit, now.Add(time.Minute))
+			Expect(err).To(BeNil())
+			_, err = store.Take(ctx, "idle", limit, now.Add(time.Minute))
+			Expect(err).To(BeNil())
+			_, err = store.Take(ctx, "idle", limit, now.Add(time.Minute))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Context("with database store", func() {
+		It("delete idle buckets", func() {
+			ctx := context.Background()
+			store := ratelimit.NewDatabaseStore(db)
+			limit := ratelimit.Limit{Rate: 10, Burst: 2}
+			now := time.Now()
+			_, err := store.Take(ctx, "idle", limit, now)
+			Expect(err).To(BeNil())
+			_, err = store.Take(ctx, "busy", limit, now.Add(time.Minute))
+			Expect(err).To(BeNil())
+			Expect(store.BucketCount()).To(Equal(1))
+			// pruned bucket start full again
+			_, err = store.Take(ctx, "idle", limit, now.Add(time.Minute))
+			Expect(err).To(BeNil())
+			_, err = store.Take(ctx, "idle", limit, now.Add(time.Minute))
+			Expect(err).To(BeNil())
+			_, err = store.Take(ctx, "idle", limit, now.Add(time.Minute))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("share buckets between instances", func() {
+			ctx := context.Background()
+			config := ratelimit.Config{PerIP: ratelimit.Limit{Rate: 1, Burst: 1}}
+			l1 := ratelimit.New(ratelimit.NewDatabaseStore(db), config)
+			l2 := ratelimit.New(ratelimit.NewDatabaseStore(db), config)
+			_, err := l1.AllowIP(ctx, "10.0.0.1")
+			Expect(err).To(BeNil())
+			_, err = l2.AllowIP(ctx, "10.0.0.1")
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("let call through when bucket kept updated concurrently", func() {
+			ctx := context.Background()
+			store := ratelimit.NewDatabaseStore(db)
+			limit := ratelimit.Limit{Rate: 1, Burst: 5}
+			_, err := store.Take(ctx, "k", limit, time.Now())
+			Expect(err).To(BeNil())
+			// other instance update bucket between every read & write
+			db.Callback().Update().Before("gorm:update").Register("concurrent_update", func(scope *gorm.Scope) {
+				scope.NewDB().Exec("UPDATE bucket_models SET version = version + 1")
+			})
+			defer db.Callback().Update().Remove("concurrent_update")
+			_, err = store.Take(ctx, "k", limit, time.Now())
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal(ratelimit.ConflictError))
+		})
+
+		It("stop counting stream after it's lease expired", func() {
+			ctx := context.Background()
+			store := ratelimit.NewDatabaseStore(db)
+			_, err := store.Acquire(ctx, "streams:u1", 1, 50*time.Millisecond)
+			Expect(err).To(BeNil())
+			_, err = store.Acquire(ctx, "streams:u1", 1, 50*time.Millisecond)
+			Expect(err).NotTo(BeNil())
+			time.Sleep(60 * time.Millisecond)
+			_, err = store.Acquire(ctx, "streams:u1", 1, 50*time.Millisecond)
+			Expect(err).To(BeNil())
+		})
+	})
+})
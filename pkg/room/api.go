@@ -7,13 +7,13 @@ import (
 	"time"
 
 	"github.com/jinzhu/gorm"
-	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
 	"go.sirus.dev/p2p-comm/signalling/protos"
 	"go.uber.org/zap"
 )
 
 const (
-	UserIDKey = "user_id"
+	UserIDKey = auth.UserIDKey
 )
 
 const (
@@ -28,21 +28,21 @@ const (
 func NewAPI(
 	db *gorm.DB,
 	logger *zap.SugaredLogger,
-	accessSecret string,
+	tokens auth.ITokenManager,
 ) *API {
 	return &API{
-		DB:           db,
-		Logger:       logger,
-		AccessSecret: accessSecret,
+		DB:     db,
+		Logger: logger,
+		Tokens: tokens,
 	}
 }
 
 // API to manage room & participant in it
 type API struct {
-	DB           *gorm.DB
-	Logger       *zap.SugaredLogger
-	AccessSecret string
-	Events       chan *RoomEvent
+	DB     *gorm.DB
+	Logger *zap.SugaredLogger
+	Tokens auth.ITokenManager
+	Events chan *RoomEvent
 }
 
 // GetEvents will return channel use to publish events
@@ -195,8 +195,9 @@ func (a *API) GetUsers(ctx context.Context, param *protos.PaginationParam) (*pro
 	}, nil
 }
 
-// GetUserAccessToken will return access token used by peer as an user identification form
-func (a *API) GetUserAccessToken(ctx context.Context, param *protos.GetUserParam) (*protos.UserAccessToken, error) {
+// GetUserAccessToken will return access token used by peer as an user identification form,
+// token can be restricted to some rooms & capabilities
+func (a *API) GetUserAccessToken(ctx context.Context, param *protos.UserAccessTokenParam) (*protos.UserAccessToken, error) {
 	// get user information
 	user := &UserModel{}
 	err := a.DB.Where(&UserModel{ID: param.Id}).
@@ -207,15 +208,15 @@ func (a *API) GetUserAccessToken(ctx context.Context, param *protos.GetUserParam
 		}
 		return nil, err
 	}
-	// create token
-	claim := map[string]interface{}{UserIDKey: user.ID}
-	token, err := utils.GenerateToken(a.AccessSecret, claim)
-	if err != nil {
-		return nil, err
+	// issue access & refresh token
+	var scope *auth.Scope
+	if len(param.RoomIDs) > 0 || len(param.Capabilities) > 0 {
+		scope = &auth.Scope{
+			RoomIDs:      param.RoomIDs,
+			Capabilities: param.Capabilities,
+		}
 	}
-	return &protos.UserAccessToken{
-		Token: *token,
-	}, nil
+	return a.Tokens.IssueUserTokens(ctx, user.ID, scope)
 }
 
 // UpdateUserProfile will update user profile informations
@@ -264,6 +265,11 @@ func (a *API) RemoveUser(ctx context.Context, param *protos.GetUserParam) (*prot
 		}
 		return nil, err
 	}
+	// force logout removed user
+	err = a.Tokens.RevokeUserTokens(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
 	// publish user removed events
 	payload, err := a.GetUserInstancePayload(user)
 	if err != nil {
@@ -277,6 +283,25 @@ func (a *API) RemoveUser(ctx context.Context, param *protos.GetUserParam) (*prot
 	return UserModelToProto(user), nil
 }
 
+// RevokeUserTokens will revoke all tokens issued to a user, forcing them to logout
+func (a *API) RevokeUserTokens(ctx context.Context, param *protos.GetUserParam) (*protos.User, error) {
+	// get user information
+	user := &UserModel{}
+	err := a.DB.Where(&UserModel{ID: param.Id}).
+		First(user).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf(UserNotFoundError)
+		}
+		return nil, err
+	}
+	err = a.Tokens.RevokeUserTokens(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return UserModelToProto(user), nil
+}
+
 // Create will create a new room for user to participate in
 func (a *API) Create(ctx context.Context, param *protos.NewRoomParam) (*protos.Room, error) {
 	// check if user presents
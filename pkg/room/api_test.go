@@ -2,10 +2,12 @@ package room_test
 
 import (
 	"context"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
 	"go.sirus.dev/p2p-comm/signalling/pkg/connector"
 	"go.sirus.dev/p2p-comm/signalling/pkg/room"
 	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
@@ -20,12 +22,14 @@ var _ = Describe("API", func() {
 		logger       *zap.SugaredLogger
 		accessSecret string
 		roomEvents   chan *room.RoomEvent
+		revocations  chan *auth.Revocation
+		tokens       *auth.API
 		api          room.API
 	)
 
 	BeforeEach(func() {
 		var err error
-		db, err = connector.ConnectToMemmory(room.Models)
+		db, err = connector.ConnectToMemmory(append(room.Models, auth.Models...))
 		if err != nil {
 			Fail(err.Error())
 		}
@@ -38,7 +42,10 @@ var _ = Describe("API", func() {
 		logger = loggerRaw.Sugar()
 		accessSecret = faker.RandomString(20)
 		roomEvents = make(chan *room.RoomEvent)
-		api = room.API{db, logger, accessSecret, roomEvents}
+		revocations = make(chan *auth.Revocation)
+		tokens = auth.NewAPI(db, logger, &auth.KeySet{Secret: accessSecret}, time.Minute, time.Hour)
+		tokens.SetRevocations(revocations)
+		api = room.API{db, logger, tokens, roomEvents}
 	})
 
 	var (
@@ -314,7 +321,7 @@ var _ = Describe("API", func() {
 	Describe("GetUserAccessToken", func() {
 		It("should return user access token", func() {
 			ctx := context.Background()
-			res, err := api.GetUserAccessToken(ctx, &protos.GetUserParam{
+			res, err := api.GetUserAccessToken(ctx, &protos.UserAccessTokenParam{
 				Id: u1.ID,
 			})
 			Expect(err).To(BeNil())
@@ -323,10 +330,38 @@ var _ = Describe("API", func() {
 			Expect(claim).To(HaveKeyWithValue(room.UserIDKey, u1.ID))
 		})
 
+		It("should return refresh token with expiration time", func() {
+			ctx := context.Background()
+			res, err := api.GetUserAccessToken(ctx, &protos.UserAccessTokenParam{
+				Id: u1.ID,
+			})
+			Expect(err).To(BeNil())
+			Expect(res.ExpiresAt).NotTo(BeNil())
+			Expect(res.RefreshTokenExpiresAt).NotTo(BeNil())
+			claim, err := utils.ValidateToken(accessSecret, res.RefreshToken)
+			Expect(err).To(BeNil())
+			Expect(claim).To(HaveKeyWithValue(auth.TokenTypeKey, auth.RefreshToken))
+		})
+
+		It("should return token restricted to rooms & capabilities", func() {
+			ctx := context.Background()
+			res, err := api.GetUserAccessToken(ctx, &protos.UserAccessTokenParam{
+				Id:           u1.ID,
+				RoomIDs:      []string{r1.ID},
+				Capabilities: []string{auth.CapabilitySignal},
+			})
+			Expect(err).To(BeNil())
+			claims, err := tokens.ValidateAccessToken(ctx, res.Token)
+			Expect(err).To(BeNil())
+			scope := auth.ScopeFromClaims(claims)
+			Expect(scope.RoomIDs).To(ConsistOf(r1.ID))
+			Expect(scope.Capabilities).To(ConsistOf(auth.CapabilitySignal))
+		})
+
 		When("user not exist", func() {
 			It("should return user not found error", func() {
 				ctx := context.Background()
-				res, err := api.GetUserAccessToken(ctx, &protos.GetUserParam{
+				res, err := api.GetUserAccessToken(ctx, &protos.UserAccessTokenParam{
 					Id: "non-exist-id",
 				})
 				Expect(res).To(BeNil())
@@ -393,6 +428,7 @@ var _ = Describe("API", func() {
 			param := &protos.GetUserParam{
 				Id: u1.ID,
 			}
+			go func() { <-revocations }()
 			go func() { <-roomEvents }()
 			res, err := api.RemoveUser(ctx, param)
 			Expect(err).To(BeNil())
@@ -401,11 +437,27 @@ var _ = Describe("API", func() {
 			Expect(res.Photo).To(Equal(u1.Photo))
 		})
 
+		It("should revoke removed user's tokens", func(done Done) {
+			ctx := context.Background()
+			token, err := tokens.IssueUserTokens(ctx, u1.ID, nil)
+			Expect(err).To(BeNil())
+			go func() {
+				api.RemoveUser(ctx, &protos.GetUserParam{Id: u1.ID})
+			}()
+			revocation := <-revocations
+			Expect(revocation.UserID).To(Equal(u1.ID))
+			_, err = tokens.ValidateAccessToken(ctx, token.Token)
+			Expect(err.Error()).To(Equal(auth.TokenRevokedError))
+			<-roomEvents
+			close(done)
+		}, 0.3)
+
 		It("should publish user removed event", func(done Done) {
 			ctx := context.Background()
 			param := &protos.GetUserParam{
 				Id: u1.ID,
 			}
+			go func() { <-revocations }()
 			go func() {
 				api.RemoveUser(ctx, param)
 			}()
@@ -434,6 +486,31 @@ var _ = Describe("API", func() {
 		})
 	})
 
+	Describe("RevokeUserTokens", func() {
+		It("should revoke all user's tokens", func(done Done) {
+			ctx := context.Background()
+			token, err := tokens.IssueUserTokens(ctx, u1.ID, nil)
+			Expect(err).To(BeNil())
+			go func() { <-revocations }()
+			res, err := api.RevokeUserTokens(ctx, &protos.GetUserParam{Id: u1.ID})
+			Expect(err).To(BeNil())
+			Expect(res.Id).To(Equal(u1.ID))
+			_, err = tokens.ValidateAccessToken(ctx, token.Token)
+			Expect(err.Error()).To(Equal(auth.TokenRevokedError))
+			close(done)
+		}, 0.3)
+
+		When("user not exist", func() {
+			It("should return user not found error", func() {
+				res, err := api.RevokeUserTokens(context.Background(), &protos.GetUserParam{
+					Id: "non-exist-id",
+				})
+				Expect(res).To(BeNil())
+				Expect(err.Error()).To(Equal(room.UserNotFoundError))
+			})
+		})
+	})
+
 	Describe("Create", func() {
 		It("should add new room to system", func() {
 			ctx := context.Background()
@@ -1,5 +1,7 @@
 package room
 
+import "time"
+
 // Models defined in room package
 var Models = []interface{}{
 	&RoomModel{},
@@ -17,9 +19,33 @@ type RoomModel struct {
 
 // UserModel define user information save on database
 type UserModel struct {
-	ID     string       `gorm:"primary_key;not null;size:100"`
-	Name   string       `gorm:"column:name;"`
-	Photo  string       `gorm:"column:photo;"`
-	Online bool         `gorm:"column:online;default:false"`
-	Rooms  []*RoomModel `gorm:"many2many:room_members;save_associations:false;"`
+	ID         string       `gorm:"primary_key;not null;size:100"`
+	Name       string       `gorm:"column:name;"`
+	Photo      string       `gorm:"column:photo;"`
+	Online     bool         `gorm:"column:online;default:false"`
+	Presence   string       `gorm:"column:presence;size:20"`
+	StatusText string       `gorm:"column:status_text;"`
+	LastSeenAt *time.Time   `gorm:"column:last_seen_at"`
+	Rooms      []*RoomModel `gorm:"many2many:room_members;save_associations:false;"`
+}
+
+// VisiblePresence return presence of user seen by other users,
+// invisible user seen as offline
+func (u *UserModel) VisiblePresence() string {
+	if !u.Online || u.Presence == PresenceInvisible {
+		return PresenceOffline
+	}
+	if len(u.Presence) == 0 {
+		return PresenceOnline
+	}
+	return u.Presence
+}
+
+// VisibleStatusText return status text seen by other users,
+// hidden while user seen offline
+func (u *UserModel) VisibleStatusText() string {
+	if u.VisiblePresence() == PresenceOffline {
+		return ""
+	}
+	return u.StatusText
 }
@@ -26,6 +26,16 @@ const (
 	UserRemoved = "chat.room.user-removed"
 )
 
+// presence states of user, connected user without presence set is online
+const (
+	PresenceOffline      = "offline"
+	PresenceOnline       = "online"
+	PresenceAway         = "away"
+	PresenceBusy         = "busy"
+	PresenceDoNotDisturb = "do-not-disturb"
+	PresenceInvisible    = "invisible"
+)
+
 // RoomEvent contain data emitted by events channel
 type RoomEvent struct {
 	Event   string      `json:"event"`
@@ -67,9 +77,10 @@ type IRoomManager interface {
 	RegisterUser(ctx context.Context, param *protos.NewUserParam) (*protos.User, error)
 	GetUser(ctx context.Context, param *protos.GetUserParam) (*protos.User, error)
 	GetUsers(ctx context.Context, param *protos.PaginationParam) (*protos.Users, error)
-	GetUserAccessToken(ctx context.Context, param *protos.GetUserParam) (*protos.UserAccessToken, error)
+	GetUserAccessToken(ctx context.Context, param *protos.UserAccessTokenParam) (*protos.UserAccessToken, error)
 	UpdateUserProfile(ctx context.Context, param *protos.UpdateUserProfileParam) (*protos.User, error)
 	RemoveUser(ctx context.Context, param *protos.GetUserParam) (*protos.User, error)
+	RevokeUserTokens(ctx context.Context, param *protos.GetUserParam) (*protos.User, error)
 	Create(ctx context.Context, param *protos.NewRoomParam) (*protos.Room, error)
 	GetByID(ctx context.Context, param *protos.GetRoomParam) (*protos.Room, error)
 	GetAll(ctx context.Context, param *protos.PaginationParam) (*protos.Rooms, error)
@@ -1,9 +1,30 @@
 package room
 
 import (
+	"github.com/golang/protobuf/ptypes"
 	"go.sirus.dev/p2p-comm/signalling/protos"
 )
 
+// PresenceToProto map presence state to protobuf
+var PresenceToProto = map[string]protos.PresenceState{
+	PresenceOffline:      protos.PresenceState_Offline,
+	PresenceOnline:       protos.PresenceState_Online,
+	PresenceAway:         protos.PresenceState_Away,
+	PresenceBusy:         protos.PresenceState_Busy,
+	PresenceDoNotDisturb: protos.PresenceState_DoNotDisturb,
+	PresenceInvisible:    protos.PresenceState_Invisible,
+}
+
+// PresenceProtoToState map protobuf presence to presence state
+var PresenceProtoToState = map[protos.PresenceState]string{
+	protos.PresenceState_Offline:      PresenceOffline,
+	protos.PresenceState_Online:       PresenceOnline,
+	protos.PresenceState_Away:         PresenceAway,
+	protos.PresenceState_Busy:         PresenceBusy,
+	protos.PresenceState_DoNotDisturb: PresenceDoNotDisturb,
+	protos.PresenceState_Invisible:    PresenceInvisible,
+}
+
 // RoomModelToProto will convert room model to it's proto representation
 func RoomModelToProto(model *RoomModel) *protos.Room {
 	room := &protos.Room{
@@ -21,12 +42,20 @@ func RoomModelToProto(model *RoomModel) *protos.Room {
 	return room
 }
 
-// UserModelToProto will convert user model to it's proto representation
+// UserModelToProto will convert user model to it's proto representation,
+// presence shown as seen by other users
 func UserModelToProto(model *UserModel) *protos.User {
-	return &protos.User{
-		Id:     model.ID,
-		Name:   model.Name,
-		Photo:  model.Photo,
-		Online: model.Online,
+	presence := model.VisiblePresence()
+	user := &protos.User{
+		Id:         model.ID,
+		Name:       model.Name,
+		Photo:      model.Photo,
+		Online:     presence != PresenceOffline,
+		Presence:   PresenceToProto[presence],
+		StatusText: model.VisibleStatusText(),
+	}
+	if model.LastSeenAt != nil {
+		user.LastSeenAt, _ = ptypes.TimestampProto(*model.LastSeenAt)
 	}
+	return user
 }
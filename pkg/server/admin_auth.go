@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// PermissionRead allow admin to call read-only room manager methods
+	PermissionRead = "read"
+	// PermissionManageUser allow admin to manage users and their access tokens
+	PermissionManageUser = "user"
+	// PermissionManageRoom allow admin to manage rooms and their members
+	PermissionManageRoom = "room"
+)
+
+const (
+	// AdminScope is scope claim value of admin access token
+	AdminScope = "admin"
+	// ScopeKey is claim key of token scope
+	ScopeKey = "scope"
+	// PermissionsKey is claim key of admin permissions
+	PermissionsKey = "permissions"
+	// AdminNameKey is claim key of admin name
+	AdminNameKey = "name"
+	// APIKeyMetadata is metadata key of admin API key
+	APIKeyMetadata = "api-key"
+	// TokenMetadata is metadata key of access token
+	TokenMetadata = "token"
+	// DeviceIDMetadata is metadata key of device identifier,
+	// used when access token not bound to a device
+	DeviceIDMetadata = "device-id"
+)
+
+// AdminKey define API key allowed to call room manager service
+type AdminKey struct {
+	Name        string   `json:"name" mapstructure:"name"`
+	Key         string   `json:"-" mapstructure:"key"`
+	Permissions []string `json:"permissions" mapstructure:"permissions"`
+}
+
+// AdminCertificate define client certificate allowed to call room manager service,
+// certificate matched by it's subject common name
+type AdminCertificate struct {
+	CommonName  string   `json:"common_name" mapstructure:"common_name"`
+	Permissions []string `json:"permissions" mapstructure:"permissions"`
+}
+
+// Admin is identity of room manager caller
+type Admin struct {
+	Name        string
+	Permissions []string
+}
+
+// RoomManagerPermissions map room manager method to permission required to call it
+var RoomManagerPermissions = map[string]string{
+	"/protos.RoomManagementService/RegisterUser":       PermissionManageUser,
+	"/protos.RoomManagementService/GetUser":            PermissionRead,
+	"/protos.RoomManagementService/GetUsers":           PermissionRead,
+	"/protos.RoomManagementService/GetUserAccessToken": PermissionManageUser,
+	"/protos.RoomManagementService/UpdateUserProfile":  PermissionManageUser,
+	"/protos.RoomManagementService/RemoveUser":         PermissionManageUser,
+	"/protos.RoomManagementService/RevokeUserTokens":   PermissionManageUser,
+	"/protos.RoomManagementService/CreateRoom":         PermissionManageRoom,
+	"/protos.RoomManagementService/GetRoom":            PermissionRead,
+	"/protos.RoomManagementService/GetRooms":           PermissionRead,
+	"/protos.RoomManagementService/UpdateRoomProfile":  PermissionManageRoom,
+	"/protos.RoomManagementService/AddUserToRoom":      PermissionManageRoom,
+	"/protos.RoomManagementService/KickUserFromRoom":   PermissionManageRoom,
+	"/protos.RoomManagementService/DestroyRoom":        PermissionManageRoom,
+}
+
+// GetAdminContext will return admin identity of a call
+// based on verified client certificate, API key or admin access token at metadata
+func (s *RoomManagementService) GetAdminContext(ctx context.Context) (*Admin, error) {
+	// authenticate using client certificate
+	if cert := getClientCertificate(ctx); cert != nil {
+		for _, c := range s.AdminCerts {
+			if len(c.CommonName) > 0 && c.CommonName == cert.Subject.CommonName {
+				return &Admin{
+					Name:        c.CommonName,
+					Permissions: c.Permissions,
+				}, nil
+			}
+		}
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "metadata not found")
+	}
+	// authenticate using API key
+	if keys := md.Get(APIKeyMetadata); len(keys) > 0 {
+		for _, key := range s.AdminKeys {
+			if len(key.Key) == 0 {
+				continue
+			}
+			if subtle.ConstantTimeCompare([]byte(key.Key), []byte(keys[0])) == 1 {
+				return &Admin{
+					Name:        key.Name,
+					Permissions: key.Permissions,
+				}, nil
+			}
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid api key")
+	}
+	// authenticate using admin scoped token
+	tokens := md.Get(TokenMetadata)
+	if len(tokens) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "admin credential not found on metadata")
+	}
+	claims, err := s.Tokens.ValidateAdminToken(ctx, tokens[0])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	if scope, _ := claims[ScopeKey].(string); scope != AdminScope {
+		return nil, status.Error(codes.PermissionDenied, "token is not admin scoped")
+	}
+	admin := &Admin{Permissions: []string{}}
+	admin.Name, _ = claims[AdminNameKey].(string)
+	permissions, _ := claims[PermissionsKey].([]interface{})
+	for _, p := range permissions {
+		if permission, ok := p.(string); ok {
+			admin.Permissions = append(admin.Permissions, permission)
+		}
+	}
+	return admin, nil
+}
+
+// getClientCertificate return client certificate of a call
+// only when it's verified against client CA
+func getClientCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return info.State.VerifiedChains[0][0]
+}
+
+// Authorize is unary interceptor that only allow admin
+// with sufficient permission to call room manager methods
+func (s *RoomManagementService) Authorize(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	admin, err := s.GetAdminContext(ctx)
+	if err != nil {
+		s.AuditRefusedCall(ctx, info.FullMethod, nil, err)
+		return nil, err
+	}
+	permission, ok := RoomManagerPermissions[info.FullMethod]
+	if !ok {
+		err = status.Errorf(codes.PermissionDenied, "method %s not allowed", info.FullMethod)
+	} else if !utils.ContainString(admin.Permissions, permission) {
+		err = status.Errorf(codes.PermissionDenied, "permission %s required", permission)
+	}
+	if err != nil {
+		s.AuditRefusedCall(ctx, info.FullMethod, admin, err)
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// AuditRefusedCall will log room manager call refused by authorization
+func (s *RoomManagementService) AuditRefusedCall(
+	ctx context.Context,
+	method string,
+	admin *Admin,
+	reason error,
+) {
+	address := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		address = p.Addr.String()
+	}
+	name := ""
+	if admin != nil {
+		name = admin.Name
+	}
+	s.Logger.Warnw("room manager call refused",
+		"method", method,
+		"admin", name,
+		"peer", address,
+		"reason", status.Convert(reason).Message(),
+	)
+}
@@ -0,0 +1,183 @@
+package server_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/connector"
+	"go.sirus.dev/p2p-comm/signalling/pkg/server"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"syreclabs.com/go/faker"
+)
+
+var _ = Describe("RoomManagementService", func() {
+	var (
+		db     *gorm.DB
+		logger *zap.SugaredLogger
+		tokens *auth.API
+		svc    *server.RoomManagementService
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = connector.ConnectToMemmory(auth.Models)
+		if err != nil {
+			Fail(err.Error())
+		}
+		logger = zap.NewNop().Sugar()
+		tokens = auth.NewAPI(db, logger, &auth.KeySet{Secret: faker.RandomString(20)}, time.Minute, time.Hour)
+		tokens.SetRevocations(make(chan *auth.Revocation, 10))
+		svc = server.NewRoomManagementService(nil, logger, nil, "test", tokens,
+			[]server.AdminKey{
+				{Name: "reader", Key: "read-key", Permissions: []string{server.PermissionRead}},
+			},
+			[]server.AdminCertificate{},
+		)
+	})
+
+	AfterEach(func() {
+		if db != nil {
+			db.Close()
+		}
+	})
+
+	withMetadata := func(kv ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	}
+	authorize := func(ctx context.Context, method string) error {
+		_, err := svc.Authorize(ctx, nil,
+			&grpc.UnaryServerInfo{FullMethod: "/protos.RoomManagementService/" + method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "ok", nil
+			},
+		)
+		return err
+	}
+	adminToken := func(permissions []string, ttl time.Duration) string {
+		token, _, _, err := tokens.GenerateAdminToken(utils.Claims{
+			server.ScopeKey:       server.AdminScope,
+			server.AdminNameKey:   "ops",
+			server.PermissionsKey: permissions,
+		}, ttl)
+		Expect(err).To(BeNil())
+		return *token
+	}
+
+	Describe("RoomManagerPermissions", func() {
+		It("should require permission for every room manager method", func() {
+			srv := grpc.NewServer()
+			protos.RegisterRoomManagementServiceServer(srv, svc)
+			info := srv.GetServiceInfo()["protos.RoomManagementService"]
+			Expect(info.Methods).NotTo(BeEmpty())
+			for _, method := range info.Methods {
+				Expect(server.RoomManagerPermissions).To(
+					HaveKey("/protos.RoomManagementService/"+method.Name),
+					method.Name,
+				)
+			}
+		})
+	})
+
+	Describe("Authorize", func() {
+		When("admin credential not found", func() {
+			It("should refuse call as unauthenticated", func() {
+				err := authorize(context.Background(), "GetUser")
+				Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+				err = authorize(withMetadata("other", "value"), "GetUser")
+				Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("api key has permission", func() {
+			It("should call handler", func() {
+				err := authorize(withMetadata(server.APIKeyMetadata, "read-key"), "GetUser")
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("api key lack permission", func() {
+			It("should refuse call as permission denied", func() {
+				err := authorize(withMetadata(server.APIKeyMetadata, "read-key"), "CreateRoom")
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Expect(status.Convert(err).Message()).To(Equal("permission " + server.PermissionManageRoom + " required"))
+			})
+		})
+
+		When("api key unknown", func() {
+			It("should refuse call as unauthenticated", func() {
+				err := authorize(withMetadata(server.APIKeyMetadata, "wrong"), "GetUser")
+				Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("method not listed", func() {
+			It("should refuse call as permission denied", func() {
+				err := authorize(withMetadata(server.APIKeyMetadata, "read-key"), "Unknown")
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Expect(status.Convert(err).Message()).To(Equal("method /protos.RoomManagementService/Unknown not allowed"))
+			})
+		})
+
+		When("admin token has permission", func() {
+			It("should call handler", func() {
+				token := adminToken([]string{server.PermissionManageRoom}, time.Minute)
+				err := authorize(withMetadata(server.TokenMetadata, token), "CreateRoom")
+				Expect(err).To(BeNil())
+				err = authorize(withMetadata(server.TokenMetadata, token), "RegisterUser")
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+		})
+
+		When("admin token expired", func() {
+			It("should refuse call as unauthenticated", func() {
+				token := adminToken([]string{server.PermissionRead}, -time.Minute)
+				err := authorize(withMetadata(server.TokenMetadata, token), "GetUser")
+				Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("admin token revoked", func() {
+			It("should refuse call as unauthenticated", func() {
+				token, tokenID, expiresAt, err := tokens.GenerateAdminToken(utils.Claims{
+					server.ScopeKey:       server.AdminScope,
+					server.PermissionsKey: []string{server.PermissionRead},
+				}, time.Minute)
+				Expect(err).To(BeNil())
+				Expect(tokens.RevokeToken("", tokenID, *expiresAt)).To(BeNil())
+				err = authorize(withMetadata(server.TokenMetadata, *token), "GetUser")
+				Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("token is user access token", func() {
+			It("should refuse call as unauthenticated", func() {
+				res, err := tokens.IssueUserTokens(context.Background(), "u1", nil)
+				Expect(err).To(BeNil())
+				err = authorize(withMetadata(server.TokenMetadata, res.Token), "GetUser")
+				Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("token without expiration time", func() {
+			It("should refuse call as unauthenticated", func() {
+				token, err := tokens.Keys.Sign(utils.Claims{
+					server.ScopeKey:       server.AdminScope,
+					server.PermissionsKey: []string{server.PermissionRead},
+				})
+				Expect(err).To(BeNil())
+				err = authorize(withMetadata(server.TokenMetadata, *token), "GetUser")
+				Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			})
+		})
+	})
+})
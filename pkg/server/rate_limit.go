@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"path"
+	"time"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/ratelimit"
+	"go.sirus.dev/p2p-comm/signalling/pkg/room"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RetryAfterMetadata is response header contain seconds to wait before retry limited call
+const RetryAfterMetadata = "retry-after"
+
+// getPeerIP return IP address of caller
+func getPeerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// limited will convert limiter result to gRPC error with retry-after header,
+// limiter store failure let call pass so signaling keep working
+func (s *SignalingService) limited(
+	retryAfter time.Duration,
+	err error,
+	setHeader func(metadata.MD) error,
+) error {
+	if err == nil {
+		return nil
+	}
+	switch err.Error() {
+	case ratelimit.LimitExceededError:
+		seconds := int64(math.Ceil(retryAfter.Seconds()))
+		if seconds < 1 {
+			seconds = 1
+		}
+		setHeader(metadata.Pairs(RetryAfterMetadata, fmt.Sprintf("%d", seconds)))
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case ratelimit.TooManyStreamsError:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	s.Logger.Errorf("failed to check rate limit -> %v", err)
+	return nil
+}
+
+// LimitIPUnary is unary interceptor that limit calls per IP address,
+// installed before authentication so invalid calls also limited
+func (s *SignalingService) LimitIPUnary(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if s.Limiter == nil {
+		return handler(ctx, req)
+	}
+	retryAfter, err := s.Limiter.AllowIP(ctx, getPeerIP(ctx))
+	err = s.limited(retryAfter, err, func(md metadata.MD) error {
+		return grpc.SetHeader(ctx, md)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// LimitIPStream is stream interceptor that limit streams per IP address
+func (s *SignalingService) LimitIPStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if s.Limiter == nil {
+		return handler(srv, ss)
+	}
+	retryAfter, err := s.Limiter.AllowIP(ss.Context(), getPeerIP(ss.Context()))
+	err = s.limited(retryAfter, err, ss.SetHeader)
+	if err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// LimitUserUnary is unary interceptor that limit calls per user & method,
+// installed after authentication to know the caller
+func (s *SignalingService) LimitUserUnary(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if s.Limiter == nil {
+		return handler(ctx, req)
+	}
+	userID, _ := ctx.Value(room.UserIDKey).(string)
+	retryAfter, err := s.Limiter.AllowUser(ctx, userID, path.Base(info.FullMethod))
+	err = s.limited(retryAfter, err, func(md metadata.MD) error {
+		return grpc.SetHeader(ctx, md)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// LimitUserStream is stream interceptor that limit streams per user & method
+// and number of streams user open at the same time
+func (s *SignalingService) LimitUserStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if s.Limiter == nil {
+		return handler(srv, ss)
+	}
+	ctx := ss.Context()
+	userID, _ := ctx.Value(room.UserIDKey).(string)
+	retryAfter, err := s.Limiter.AllowUser(ctx, userID, path.Base(info.FullMethod))
+	err = s.limited(retryAfter, err, ss.SetHeader)
+	if err != nil {
+		return err
+	}
+	lease, err := s.Limiter.OpenStream(ctx, userID)
+	if err != nil {
+		err = s.limited(0, err, ss.SetHeader)
+		if err != nil {
+			return err
+		}
+	}
+	defer s.Limiter.CloseStream(context.Background(), lease)
+
+	// keep stream counted while it's open
+	if len(lease) > 0 {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			ticker := time.NewTicker(s.Limiter.Config.StreamLease / 2)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					err := s.Limiter.RenewStream(context.Background(), lease)
+					if err != nil {
+						s.Logger.Errorf("failed to renew stream lease -> %v", err)
+					}
+				}
+			}
+		}()
+	}
+	return handler(srv, ss)
+}
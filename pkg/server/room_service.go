@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/nats-io/nats.go"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
 	"go.sirus.dev/p2p-comm/signalling/pkg/room"
 	"go.sirus.dev/p2p-comm/signalling/protos"
 	"go.uber.org/zap"
@@ -16,14 +17,18 @@ func NewRoomManagementService(
 	logger *zap.SugaredLogger,
 	nats *nats.EncodedConn,
 	eventNamespace string,
-	accessSecret string,
+	tokens auth.ITokenManager,
+	adminKeys []AdminKey,
+	adminCertificates []AdminCertificate,
 ) *RoomManagementService {
 	return &RoomManagementService{
 		RoomManager:    roomManager,
 		Logger:         logger,
 		Nats:           nats,
 		EventNamespace: eventNamespace,
-		AccessSecret:   accessSecret,
+		Tokens:         tokens,
+		AdminKeys:      adminKeys,
+		AdminCerts:     adminCertificates,
 	}
 }
 
@@ -34,7 +39,9 @@ type RoomManagementService struct {
 	Logger         *zap.SugaredLogger
 	Nats           *nats.EncodedConn
 	EventNamespace string
-	AccessSecret   string
+	Tokens         auth.ITokenManager
+	AdminKeys      []AdminKey
+	AdminCerts     []AdminCertificate
 }
 
 // RegisterUser will register new user that can participate in a room
@@ -64,7 +71,7 @@ func (s *RoomManagementService) GetUsers(
 // GetUserAccessToken will return access token used by peer as an user identification form
 func (s *RoomManagementService) GetUserAccessToken(
 	ctx context.Context,
-	req *protos.GetUserParam,
+	req *protos.UserAccessTokenParam,
 ) (*protos.UserAccessToken, error) {
 	return s.RoomManager.GetUserAccessToken(ctx, req)
 }
@@ -85,6 +92,14 @@ func (s *RoomManagementService) RemoveUser(
 	return s.RoomManager.RemoveUser(ctx, req)
 }
 
+// RevokeUserTokens will revoke all tokens issued to a user, forcing them to logout
+func (s *RoomManagementService) RevokeUserTokens(
+	ctx context.Context,
+	req *protos.GetUserParam,
+) (*protos.User, error) {
+	return s.RoomManager.RevokeUserTokens(ctx, req)
+}
+
 // CreateRoom will create a new room for user to participate in
 func (s *RoomManagementService) CreateRoom(
 	ctx context.Context,
@@ -1,41 +1,73 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
+	"net/http"
 
 	"go.sirus.dev/p2p-comm/signalling/protos"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // New wil create new instance of server
 func New(
 	signalingSvc *SignalingService,
 	signalingPort int,
+	signalingTLS *TLSConfig,
 	roomMngrSvc *RoomManagementService,
 	roomMngrPort int,
+	roomMngrTLS *TLSConfig,
+	jwksPort int,
 ) *Server {
 	return &Server{
 		SignalingSvc:  signalingSvc,
 		SignalingPort: signalingPort,
+		SignalingTLS:  signalingTLS,
 		RoomMngrSvc:   roomMngrSvc,
 		RoomMngrPort:  roomMngrPort,
+		RoomMngrTLS:   roomMngrTLS,
+		JWKSPort:      jwksPort,
 	}
 }
 
+// JWKSPath is HTTP path where JWKS document published
+const JWKSPath = "/.well-known/jwks.json"
+
 // Server act as transport layer
 type Server struct {
 	SignalingSvc    *SignalingService
 	SignalingServer *grpc.Server
 	SignalingPort   int
+	SignalingTLS    *TLSConfig
 	RoomMngrSvc     *RoomManagementService
 	RoomMngrServer  *grpc.Server
 	RoomMngrPort    int
+	RoomMngrTLS     *TLSConfig
+	JWKSServer      *http.Server
+	JWKSPort        int
 }
 
 // StartSignaling will start serve signaling service in GRPC server
 func (s *Server) StartSignaling() error {
-	options := []grpc.ServerOption{}
+	options := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			s.SignalingSvc.LimitIPUnary,
+			s.SignalingSvc.AuthenticateUnary,
+			s.SignalingSvc.LimitUserUnary,
+		),
+		grpc.ChainStreamInterceptor(
+			s.SignalingSvc.LimitIPStream,
+			s.SignalingSvc.AuthenticateStream,
+			s.SignalingSvc.LimitUserStream,
+		),
+	}
+	options, err := withTLS(options, s.SignalingTLS, s.SignalingSvc.Logger)
+	if err != nil {
+		return err
+	}
 	s.SignalingServer = grpc.NewServer(options...)
 	go s.SignalingSvc.Run()
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.SignalingPort))
@@ -49,7 +81,13 @@ func (s *Server) StartSignaling() error {
 
 // StartRoomManager will start serve room management service in GRPC server
 func (s *Server) StartRoomManager() error {
-	options := []grpc.ServerOption{}
+	options := []grpc.ServerOption{
+		grpc.UnaryInterceptor(s.RoomMngrSvc.Authorize),
+	}
+	options, err := withTLS(options, s.RoomMngrTLS, s.RoomMngrSvc.Logger)
+	if err != nil {
+		return err
+	}
 	s.RoomMngrServer = grpc.NewServer(options...)
 	go s.RoomMngrSvc.Run()
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.RoomMngrPort))
@@ -60,6 +98,42 @@ func (s *Server) StartRoomManager() error {
 	return s.RoomMngrServer.Serve(lis)
 }
 
+// withTLS will add TLS credentials to server options when TLS configured
+func withTLS(
+	options []grpc.ServerOption,
+	config *TLSConfig,
+	logger *zap.SugaredLogger,
+) ([]grpc.ServerOption, error) {
+	if !config.Enabled() {
+		return options, nil
+	}
+	reloader, err := NewCertReloader(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	creds := credentials.NewTLS(reloader.TLSConfig())
+	return append(options, grpc.Creds(creds)), nil
+}
+
+// StartJWKS will start publish JWKS document over HTTP,
+// other services use it to verify tokens without knowing signing secret
+func (s *Server) StartJWKS() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(JWKSPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		err := json.NewEncoder(w).Encode(s.SignalingSvc.Tokens.JWKS())
+		if err != nil {
+			s.SignalingSvc.Logger.Error(err)
+		}
+	})
+	s.JWKSServer = &http.Server{
+		Addr:    fmt.Sprintf(":%d", s.JWKSPort),
+		Handler: mux,
+	}
+	return s.JWKSServer.ListenAndServe()
+}
+
 // Stop will stop all gRPC server
 func (s *Server) Stop() error {
 	if s.SignalingServer != nil {
@@ -68,5 +142,8 @@ func (s *Server) Stop() error {
 	if s.RoomMngrServer != nil {
 		s.RoomMngrServer.Stop()
 	}
+	if s.JWKSServer != nil {
+		return s.JWKSServer.Close()
+	}
 	return nil
 }
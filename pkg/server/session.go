@@ -0,0 +1,366 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/call"
+	"go.sirus.dev/p2p-comm/signalling/pkg/ratelimit"
+	"go.sirus.dev/p2p-comm/signalling/pkg/room"
+	"go.sirus.dev/p2p-comm/signalling/pkg/signaling"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sessionChannels receive NATS messages routed to a Connect session,
+// nil channel mean session not subscribed to that message
+type sessionChannels struct {
+	commands      chan *signaling.SDPCommand
+	events        chan *room.RoomEvent
+	offers        chan *signaling.ICEOffer
+	statusChanges chan *signaling.OnlineStatus
+	// room events used to keep presence visibility up to date
+	presenceEvents chan *room.RoomEvent
+	signals        chan *signaling.Signal
+	calls          chan *call.CallEvent
+	roomCalls      chan *call.RoomCallEvent
+}
+
+// Connect open single signaling session that multiplex SDP, ICE candidates,
+// signals, call & room call events, heartbeats, room events & online status over one stream
+// and one NATS subscription, session end when any subscription end
+func (s *SignalingService) Connect(
+	srv protos.SignalingService_ConnectServer,
+) error {
+	ctx, cancel := context.WithCancel(srv.Context())
+	defer cancel()
+	scope := auth.ScopeFromContext(ctx)
+	canSignal := scope.Can(auth.CapabilitySignal)
+	canPresence := scope.Can(auth.CapabilityPresence)
+
+	channels := &sessionChannels{
+		events: make(chan *room.RoomEvent),
+	}
+	if canSignal {
+		channels.commands = make(chan *signaling.SDPCommand)
+		channels.offers = make(chan *signaling.ICEOffer)
+		channels.signals = make(chan *signaling.Signal)
+		channels.calls = make(chan *call.CallEvent)
+		channels.roomCalls = make(chan *call.RoomCallEvent)
+	}
+	if canPresence {
+		channels.statusChanges = make(chan *signaling.OnlineStatus)
+		channels.presenceEvents = make(chan *room.RoomEvent)
+	}
+	sub, err := s.SubscribeNatsSession(ctx, channels)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	errc := make(chan error, 1)
+	stop := func(err error) {
+		if err != nil {
+			select {
+			case errc <- err:
+			default:
+			}
+		}
+		cancel()
+	}
+	// subscribers joined before session return so their cleanup done,
+	// e.g. presence session released
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	run := func(subscribe func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stop(subscribe())
+		}()
+	}
+
+	protoEvents := make(chan *protos.RoomEvent)
+	protoSDPs := make(chan *protos.SDP)
+	protoOffers := make(chan *protos.ICEOffer)
+	protoSignals := make(chan *protos.Signal)
+	protoStatusChanges := make(chan *protos.OnlineStatus)
+	protoCallEvents := make(chan *protos.CallEvent)
+	protoRoomCallEvents := make(chan *protos.RoomCallEvent)
+	heartbeat := make(chan *protos.Heartbeat)
+	acks := make(chan *protos.Ack)
+	run(func() error {
+		return s.Signaling.SubscribeRoomEvent(ctx, channels.events, protoEvents)
+	})
+	if canSignal {
+		run(func() error {
+			return s.Signaling.SubscribeSDPCommand(ctx, channels.commands, protoSDPs)
+		})
+		run(func() error {
+			return s.Signaling.SubscribeICECandidate(ctx, channels.offers, protoOffers)
+		})
+		run(func() error {
+			return s.Signaling.SubscribeSignal(ctx, channels.signals, protoSignals)
+		})
+		run(func() error {
+			return s.Calls.SubscribeCallEvent(ctx, channels.calls, protoCallEvents)
+		})
+		run(func() error {
+			return s.Calls.SubscribeRoomCallEvent(ctx, channels.roomCalls, protoRoomCallEvents)
+		})
+	}
+	if canPresence {
+		run(func() error {
+			return s.Signaling.SubscribeOnlineStatus(
+				ctx,
+				heartbeat,
+				channels.statusChanges,
+				channels.presenceEvents,
+				protoStatusChanges,
+			)
+		})
+	}
+
+	// handle client messages, not joined since receive only end with the stream
+	go func() {
+		stop(s.receiveClientMessages(ctx, srv, canPresence, heartbeat, acks))
+	}()
+
+	// only this loop send to stream
+	for {
+		msg := &protos.ServerMessage{}
+		select {
+		case ack := <-acks:
+			msg.Payload = &protos.ServerMessage_Ack{Ack: ack}
+		case event := <-protoEvents:
+			msg.Payload = &protos.ServerMessage_RoomEvent{RoomEvent: event}
+		case sdp := <-protoSDPs:
+			msg.Payload = &protos.ServerMessage_Sdp{Sdp: sdp}
+		case offer := <-protoOffers:
+			msg.Payload = &protos.ServerMessage_Ice{Ice: offer}
+		case signal := <-protoSignals:
+			msg.Payload = &protos.ServerMessage_Signal{Signal: signal}
+		case statusChange := <-protoStatusChanges:
+			msg.Payload = &protos.ServerMessage_OnlineStatus{OnlineStatus: statusChange}
+		case event := <-protoCallEvents:
+			msg.Payload = &protos.ServerMessage_CallEvent{CallEvent: event}
+		case event := <-protoRoomCallEvents:
+			msg.Payload = &protos.ServerMessage_RoomCallEvent{RoomCallEvent: event}
+		case <-ctx.Done():
+			select {
+			case err := <-errc:
+				return err
+			default:
+				return nil
+			}
+		}
+		err := srv.Send(msg)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// receiveClientMessages will pass heartbeats & acks of client messages to session
+// until stream end or session context done
+func (s *SignalingService) receiveClientMessages(
+	ctx context.Context,
+	srv protos.SignalingService_ConnectServer,
+	canPresence bool,
+	heartbeat chan<- *protos.Heartbeat,
+	acks chan<- *protos.Ack,
+) error {
+	for {
+		msg, err := srv.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if msg.GetHeartbeat() != nil {
+			if !canPresence {
+				continue
+			}
+			select {
+			case heartbeat <- msg.GetHeartbeat():
+			case <-ctx.Done():
+				return nil
+			}
+			continue
+		}
+		ack := s.HandleClientMessage(ctx, msg)
+		select {
+		case acks <- ack:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// HandleClientMessage will send SDP, ICE candidate or signal of client message
+// with the same capability & rate limit checks as unary calls
+func (s *SignalingService) HandleClientMessage(
+	ctx context.Context,
+	msg *protos.ClientMessage,
+) *protos.Ack {
+	var method string
+	var send func() (*protos.Delivery, error)
+	switch payload := msg.Payload.(type) {
+	case *protos.ClientMessage_Sdp:
+		method = "SendSessionDescription"
+		send = func() (*protos.Delivery, error) { return s.Signaling.SendSDP(ctx, payload.Sdp) }
+	case *protos.ClientMessage_Ice:
+		method = "SendICECandidate"
+		send = func() (*protos.Delivery, error) { return s.Signaling.SendICECandidate(ctx, payload.Ice) }
+	case *protos.ClientMessage_Signal:
+		method = "SendSignal"
+		send = func() (*protos.Delivery, error) {
+			return &protos.Delivery{}, s.Signaling.SendSignal(ctx, payload.Signal)
+		}
+	default:
+		return &protos.Ack{
+			Id:      msg.Id,
+			Code:    int32(codes.InvalidArgument),
+			Message: "empty message",
+		}
+	}
+	ack := &protos.Ack{Id: msg.Id}
+	if !auth.ScopeFromContext(ctx).Can(auth.CapabilitySignal) {
+		ack.Code = int32(codes.PermissionDenied)
+		ack.Message = "token not allowed to " + auth.CapabilitySignal
+		return ack
+	}
+	if s.Limiter != nil {
+		userID, _ := ctx.Value(room.UserIDKey).(string)
+		retryAfter, err := s.Limiter.AllowUser(ctx, userID, method)
+		if err != nil {
+			if err.Error() == ratelimit.LimitExceededError {
+				ack.Code = int32(codes.ResourceExhausted)
+				ack.Message = err.Error()
+				ack.RetryAfter = int64(math.Max(1, math.Ceil(retryAfter.Seconds())))
+				return ack
+			}
+			s.Logger.Errorf("failed to check rate limit -> %v", err)
+		}
+	}
+	delivery, err := send()
+	if err != nil {
+		st := status.Convert(err)
+		ack.Code = int32(st.Code())
+		ack.Message = st.Message()
+		return ack
+	}
+	ack.Delivery = delivery.Status
+	return ack
+}
+
+// SubscribeNatsSession will subscribe every signaling message with single NATS subscription
+// and route it to session channels, routing stop when session context done
+func (s *SignalingService) SubscribeNatsSession(
+	ctx context.Context,
+	channels *sessionChannels,
+) (*nats.Subscription, error) {
+	handler := func(m *nats.Msg) {
+		s.routeSessionMessage(ctx, channels, m)
+	}
+	return s.Nats.Subscribe(s.EventNamespace+".chat.>", handler)
+}
+
+// routeSessionMessage will parse NATS message and send it to session channel
+// subscribed to it's subject, blocks until session receive it or context done
+func (s *SignalingService) routeSessionMessage(
+	ctx context.Context,
+	channels *sessionChannels,
+	m *nats.Msg,
+) {
+	eventPattern := regexp.MustCompile(fmt.Sprintf("^%s.", s.EventNamespace))
+	subject := eventPattern.ReplaceAllString(m.Subject, "")
+	var err error
+	switch {
+	case strings.HasPrefix(subject, "chat.room."):
+		var event *room.RoomEvent
+		event, err = s.parseRoomEvent(m)
+		if err == nil && event != nil {
+			select {
+			case channels.events <- event:
+			case <-ctx.Done():
+			}
+			if channels.presenceEvents != nil {
+				select {
+				case channels.presenceEvents <- event:
+				case <-ctx.Done():
+				}
+			}
+		}
+	case strings.HasPrefix(subject, "chat.sdp.") && channels.commands != nil:
+		var command *signaling.SDPCommand
+		command, err = s.parseSDPCommand(m)
+		if err == nil {
+			select {
+			case channels.commands <- command:
+			case <-ctx.Done():
+			}
+		}
+	case subject == signaling.ICECandidateOffer && channels.offers != nil:
+		offer := &signaling.ICEOffer{}
+		err = json.Unmarshal(m.Data, offer)
+		if err == nil {
+			select {
+			case channels.offers <- offer:
+			case <-ctx.Done():
+			}
+		}
+	case subject == signaling.PeerSignal && channels.signals != nil:
+		signal := &signaling.Signal{}
+		err = json.Unmarshal(m.Data, signal)
+		if err == nil {
+			select {
+			case channels.signals <- signal:
+			case <-ctx.Done():
+			}
+		}
+	case subject == call.CallStateChanged && channels.calls != nil:
+		event := &call.CallEvent{}
+		err = json.Unmarshal(m.Data, event)
+		if err == nil {
+			select {
+			case channels.calls <- event:
+			case <-ctx.Done():
+			}
+		}
+	case subject == call.RoomCallChanged && channels.roomCalls != nil:
+		event := &call.RoomCallEvent{}
+		err = json.Unmarshal(m.Data, event)
+		if err == nil {
+			select {
+			case channels.roomCalls <- event:
+			case <-ctx.Done():
+			}
+		}
+	case subject == signaling.OnlineStatusChangeEvent && channels.statusChanges != nil:
+		statusChange := &signaling.OnlineStatus{}
+		err = json.Unmarshal(m.Data, statusChange)
+		if err == nil {
+			select {
+			case channels.statusChanges <- statusChange:
+			case <-ctx.Done():
+			}
+		}
+	}
+	if err != nil {
+		s.Logger.Error(err)
+	}
+}
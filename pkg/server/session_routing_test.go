@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/nats-io/nats.go"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/call"
+	"go.sirus.dev/p2p-comm/signalling/pkg/room"
+	"go.sirus.dev/p2p-comm/signalling/pkg/signaling"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+	"go.uber.org/zap"
+)
+
+// fakeConnectServer return queued client messages then end of stream
+type fakeConnectServer struct {
+	protos.SignalingService_ConnectServer
+	messages []*protos.ClientMessage
+}
+
+func (f *fakeConnectServer) Recv() (*protos.ClientMessage, error) {
+	if len(f.messages) == 0 {
+		return nil, io.EOF
+	}
+	msg := f.messages[0]
+	f.messages = f.messages[1:]
+	return msg, nil
+}
+
+var _ = Describe("SignalingService session routing", func() {
+	var (
+		svc      *SignalingService
+		ctx      context.Context
+		cancel   context.CancelFunc
+		channels *sessionChannels
+	)
+
+	natsMsg := func(subject string, payload interface{}) *nats.Msg {
+		data, err := json.Marshal(payload)
+		Expect(err).To(BeNil())
+		return &nats.Msg{Subject: "test." + subject, Data: data}
+	}
+
+	BeforeEach(func() {
+		svc = NewSignalingService(nil, zap.NewNop().Sugar(), nil, "test", nil, nil, nil)
+		ctx, cancel = context.WithCancel(context.Background())
+		channels = &sessionChannels{
+			events:         make(chan *room.RoomEvent, 1),
+			commands:       make(chan *signaling.SDPCommand, 1),
+			offers:         make(chan *signaling.ICEOffer, 1),
+			signals:        make(chan *signaling.Signal, 1),
+			calls:          make(chan *call.CallEvent, 1),
+			roomCalls:      make(chan *call.RoomCallEvent, 1),
+			statusChanges:  make(chan *signaling.OnlineStatus, 1),
+			presenceEvents: make(chan *room.RoomEvent, 1),
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("should route room event to room & presence events", func() {
+		svc.routeSessionMessage(ctx, channels, natsMsg(room.UserJoinedRoom, &room.RoomParticipantEventPayload{
+			UserID: "u1",
+			RoomID: "r1",
+		}))
+		event := <-channels.events
+		Expect(event.Event).To(Equal(room.UserJoinedRoom))
+		Expect(event.Payload.(*room.RoomParticipantEventPayload).RoomID).To(Equal("r1"))
+		Expect(<-channels.presenceEvents).To(Equal(event))
+	})
+
+	It("should route SDP command with it's type", func() {
+		svc.routeSessionMessage(ctx, channels, natsMsg("chat.sdp."+signaling.SDPOffer, &SDPPayload{
+			From:         "u1",
+			To:           "u2",
+			ConnectionID: "c1",
+		}))
+		command := <-channels.commands
+		Expect(command.Type).To(Equal(signaling.SDPOffer))
+		Expect(command.From).To(Equal("u1"))
+		Expect(command.ConnectionID).To(Equal("c1"))
+	})
+
+	It("should route ICE candidate offer", func() {
+		svc.routeSessionMessage(ctx, channels, natsMsg(signaling.ICECandidateOffer, &signaling.ICEOffer{
+			From:      "u1",
+			Candidate: "candidate",
+		}))
+		Expect((<-channels.offers).Candidate).To(Equal("candidate"))
+	})
+
+	It("should route peer signal", func() {
+		svc.routeSessionMessage(ctx, channels, natsMsg(signaling.PeerSignal, &signaling.Signal{
+			From: "u1",
+			Type: "mute",
+		}))
+		Expect((<-channels.signals).Type).To(Equal("mute"))
+	})
+
+	It("should route call event", func() {
+		svc.routeSessionMessage(ctx, channels, natsMsg(call.CallStateChanged, &call.CallEvent{
+			Call: &call.CallModel{ID: "call1"},
+		}))
+		Expect((<-channels.calls).Call.ID).To(Equal("call1"))
+	})
+
+	It("should route room call event", func() {
+		svc.routeSessionMessage(ctx, channels, natsMsg(call.RoomCallChanged, &call.RoomCallEvent{
+			RoomID: "r1",
+			UserID: "u1",
+		}))
+		Expect((<-channels.roomCalls).RoomID).To(Equal("r1"))
+	})
+
+	It("should route online status change", func() {
+		svc.routeSessionMessage(ctx, channels, natsMsg(signaling.OnlineStatusChangeEvent, &signaling.OnlineStatus{
+			ID:     "u1",
+			Online: true,
+		}))
+		Expect((<-channels.statusChanges).ID).To(Equal("u1"))
+	})
+
+	It("should skip messages session not subscribed to", func(done Done) {
+		channels = &sessionChannels{
+			events: make(chan *room.RoomEvent, 1),
+		}
+		svc.routeSessionMessage(ctx, channels, natsMsg(signaling.PeerSignal, &signaling.Signal{From: "u1"}))
+		svc.routeSessionMessage(ctx, channels, natsMsg(signaling.OnlineStatusChangeEvent, &signaling.OnlineStatus{ID: "u1"}))
+		svc.routeSessionMessage(ctx, channels, natsMsg(room.UserJoinedRoom, &room.RoomParticipantEventPayload{RoomID: "r1"}))
+		Expect((<-channels.events).Event).To(Equal(room.UserJoinedRoom))
+		close(done)
+	}, 1)
+
+	It("should stop routing when session context done", func(done Done) {
+		channels.signals = make(chan *signaling.Signal)
+		cancel()
+		svc.routeSessionMessage(ctx, channels, natsMsg(signaling.PeerSignal, &signaling.Signal{From: "u1"}))
+		close(done)
+	}, 1)
+
+	It("should ignore invalid payload", func() {
+		svc.routeSessionMessage(ctx, channels, &nats.Msg{
+			Subject: "test." + signaling.PeerSignal,
+			Data:    []byte("{"),
+		})
+		Expect(channels.signals).NotTo(Receive())
+	})
+})
+
+var _ = Describe("SignalingService client messages", func() {
+	var (
+		svc    *SignalingService
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		svc = NewSignalingService(nil, zap.NewNop().Sugar(), nil, "test", nil, nil, nil)
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("should pass heartbeat to session & end with stream", func(done Done) {
+		heartbeat := make(chan *protos.Heartbeat, 1)
+		srv := &fakeConnectServer{messages: []*protos.ClientMessage{
+			{Payload: &protos.ClientMessage_Heartbeat{Heartbeat: &protos.Heartbeat{Beat: true}}},
+		}}
+		err := svc.receiveClientMessages(ctx, srv, true, heartbeat, make(chan *protos.Ack))
+		Expect(err).To(BeNil())
+		Expect((<-heartbeat).Beat).To(BeTrue())
+		close(done)
+	}, 1)
+
+	It("should skip heartbeat without presence capability", func(done Done) {
+		heartbeat := make(chan *protos.Heartbeat)
+		srv := &fakeConnectServer{messages: []*protos.ClientMessage{
+			{Payload: &protos.ClientMessage_Heartbeat{Heartbeat: &protos.Heartbeat{Beat: true}}},
+		}}
+		err := svc.receiveClientMessages(ctx, srv, false, heartbeat, make(chan *protos.Ack))
+		Expect(err).To(BeNil())
+		close(done)
+	}, 1)
+
+	It("should stop when session context done while ack not sent", func(done Done) {
+		srv := &fakeConnectServer{messages: []*protos.ClientMessage{{Id: "m1"}}}
+		cancel()
+		err := svc.receiveClientMessages(ctx, srv, true, make(chan *protos.Heartbeat), make(chan *protos.Ack))
+		Expect(err).To(BeNil())
+		close(done)
+	}, 1)
+})
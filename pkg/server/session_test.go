@@ -0,0 +1,153 @@
+package server_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/ratelimit"
+	"go.sirus.dev/p2p-comm/signalling/pkg/room"
+	"go.sirus.dev/p2p-comm/signalling/pkg/server"
+	"go.sirus.dev/p2p-comm/signalling/pkg/signaling"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeSignaling record sent messages & answer with fixed delivery
+type fakeSignaling struct {
+	signaling.ISignaling
+	delivery *protos.Delivery
+	err      error
+	sent     []string
+}
+
+func (f *fakeSignaling) SendSDP(ctx context.Context, param *protos.SDPParam) (*protos.Delivery, error) {
+	f.sent = append(f.sent, "sdp")
+	return f.delivery, f.err
+}
+
+func (f *fakeSignaling) SendICECandidate(ctx context.Context, param *protos.ICEParam) (*protos.Delivery, error) {
+	f.sent = append(f.sent, "ice")
+	return f.delivery, f.err
+}
+
+func (f *fakeSignaling) SendSignal(ctx context.Context, param *protos.SignalParam) error {
+	f.sent = append(f.sent, "signal")
+	return f.err
+}
+
+var _ = Describe("SignalingService session", func() {
+	var (
+		fake *fakeSignaling
+		svc  *server.SignalingService
+		ctx  context.Context
+	)
+
+	BeforeEach(func() {
+		fake = &fakeSignaling{delivery: &protos.Delivery{Status: protos.DeliveryStatus_Queued}}
+		svc = server.NewSignalingService(fake, zap.NewNop().Sugar(), nil, "test", nil, nil, nil)
+		ctx = context.WithValue(context.Background(), room.UserIDKey, "u1")
+	})
+
+	Describe("HandleClientMessage", func() {
+		It("should send SDP & acknowledge it's id with delivery status", func() {
+			ack := svc.HandleClientMessage(ctx, &protos.ClientMessage{
+				Id:      "m1",
+				Payload: &protos.ClientMessage_Sdp{Sdp: &protos.SDPParam{}},
+			})
+			Expect(ack.Id).To(Equal("m1"))
+			Expect(ack.Code).To(Equal(int32(codes.OK)))
+			Expect(ack.Delivery).To(Equal(protos.DeliveryStatus_Queued))
+			Expect(fake.sent).To(Equal([]string{"sdp"}))
+		})
+
+		It("should send ICE candidate", func() {
+			ack := svc.HandleClientMessage(ctx, &protos.ClientMessage{
+				Id:      "m2",
+				Payload: &protos.ClientMessage_Ice{Ice: &protos.ICEParam{}},
+			})
+			Expect(ack.Id).To(Equal("m2"))
+			Expect(ack.Code).To(Equal(int32(codes.OK)))
+			Expect(fake.sent).To(Equal([]string{"ice"}))
+		})
+
+		It("should send signal", func() {
+			ack := svc.HandleClientMessage(ctx, &protos.ClientMessage{
+				Id:      "m3",
+				Payload: &protos.ClientMessage_Signal{Signal: &protos.SignalParam{}},
+			})
+			Expect(ack.Id).To(Equal("m3"))
+			Expect(ack.Code).To(Equal(int32(codes.OK)))
+			Expect(ack.Delivery).To(Equal(protos.DeliveryStatus_Unspecified))
+			Expect(fake.sent).To(Equal([]string{"signal"}))
+		})
+
+		It("should refuse empty message", func() {
+			ack := svc.HandleClientMessage(ctx, &protos.ClientMessage{Id: "m4"})
+			Expect(ack.Id).To(Equal("m4"))
+			Expect(ack.Code).To(Equal(int32(codes.InvalidArgument)))
+			Expect(fake.sent).To(BeEmpty())
+		})
+
+		It("should return send error on ack", func() {
+			fake.err = status.Error(codes.NotFound, "user not found")
+			ack := svc.HandleClientMessage(ctx, &protos.ClientMessage{
+				Id:      "m5",
+				Payload: &protos.ClientMessage_Sdp{Sdp: &protos.SDPParam{}},
+			})
+			Expect(ack.Id).To(Equal("m5"))
+			Expect(ack.Code).To(Equal(int32(codes.NotFound)))
+			Expect(ack.Message).To(Equal("user not found"))
+			Expect(ack.Delivery).To(Equal(protos.DeliveryStatus_Unspecified))
+		})
+
+		It("should deny token without signal capability", func() {
+			ctx = context.WithValue(ctx, auth.ScopeContextKey, &auth.Scope{
+				Capabilities: []string{auth.CapabilityPresence},
+			})
+			ack := svc.HandleClientMessage(ctx, &protos.ClientMessage{
+				Id:      "m6",
+				Payload: &protos.ClientMessage_Signal{Signal: &protos.SignalParam{}},
+			})
+			Expect(ack.Id).To(Equal("m6"))
+			Expect(ack.Code).To(Equal(int32(codes.PermissionDenied)))
+			Expect(fake.sent).To(BeEmpty())
+		})
+
+		It("should return retry after when rate limited", func() {
+			svc.Limiter = ratelimit.New(ratelimit.NewMemoryStore(), ratelimit.Config{
+				Methods: []ratelimit.MethodLimit{{Method: "SendSignal", Rate: 0.1, Burst: 1}},
+			})
+			msg := &protos.ClientMessage{
+				Id:      "m7",
+				Payload: &protos.ClientMessage_Signal{Signal: &protos.SignalParam{}},
+			}
+			ack := svc.HandleClientMessage(ctx, msg)
+			Expect(ack.Code).To(Equal(int32(codes.OK)))
+
+			msg.Id = "m8"
+			ack = svc.HandleClientMessage(ctx, msg)
+			Expect(ack.Id).To(Equal("m8"))
+			Expect(ack.Code).To(Equal(int32(codes.ResourceExhausted)))
+			Expect(ack.RetryAfter).To(BeNumerically(">=", 1))
+			Expect(ack.RetryAfter).To(BeNumerically("<=", 10))
+			Expect(fake.sent).To(Equal([]string{"signal"}))
+		})
+
+		It("should limit each method separately", func() {
+			svc.Limiter = ratelimit.New(ratelimit.NewMemoryStore(), ratelimit.Config{
+				Methods: []ratelimit.MethodLimit{{Method: "SendSignal", Rate: 0.1, Burst: 1}},
+			})
+			svc.HandleClientMessage(ctx, &protos.ClientMessage{
+				Payload: &protos.ClientMessage_Signal{Signal: &protos.SignalParam{}},
+			})
+			ack := svc.HandleClientMessage(ctx, &protos.ClientMessage{
+				Payload: &protos.ClientMessage_Ice{Ice: &protos.ICEParam{}},
+			})
+			Expect(ack.Code).To(Equal(int32(codes.OK)))
+		})
+	})
+})
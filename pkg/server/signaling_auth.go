@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RequestIDMetadata is metadata key of request id,
+	// generated when client not send one and returned on response header
+	RequestIDMetadata = "x-request-id"
+	// RequestIDKey is context key of request id
+	RequestIDKey = "request_id"
+)
+
+// PublicSignalingMethods list signaling methods callable without access token
+var PublicSignalingMethods = map[string]bool{
+	"/protos.SignalingService/RefreshAccessToken": true,
+}
+
+// AnyCapability mark signaling method callable using any token
+const AnyCapability = ""
+
+// SignalingCapabilities map signaling method to capability required to call it
+// using scoped token, methods not listed are refused for every token
+var SignalingCapabilities = map[string]string{
+	"/protos.SignalingService/GetProfile":               AnyCapability,
+	"/protos.SignalingService/GetMyRooms":               AnyCapability,
+	"/protos.SignalingService/GetRoom":                  AnyCapability,
+	"/protos.SignalingService/GetUser":                  AnyCapability,
+	"/protos.SignalingService/SubscribeRoomEvent":       AnyCapability,
+	"/protos.SignalingService/Connect":                  AnyCapability,
+	"/protos.SignalingService/UpdateProfile":            auth.CapabilityProfile,
+	"/protos.SignalingService/GetICEServers":            auth.CapabilitySignal,
+	"/protos.SignalingService/OfferSessionDescription":  auth.CapabilitySignal,
+	"/protos.SignalingService/AnswerSessionDescription": auth.CapabilitySignal,
+	"/protos.SignalingService/SendSessionDescription":   auth.CapabilitySignal,
+	"/protos.SignalingService/SubscribeSDPCommand":      auth.CapabilitySignal,
+	"/protos.SignalingService/SendICECandidate":         auth.CapabilitySignal,
+	"/protos.SignalingService/SubscribeICECandidate":    auth.CapabilitySignal,
+	"/protos.SignalingService/SendSignal":               auth.CapabilitySignal,
+	"/protos.SignalingService/SubscribeSignal":          auth.CapabilitySignal,
+	"/protos.SignalingService/StartCall":                auth.CapabilitySignal,
+	"/protos.SignalingService/AcceptCall":               auth.CapabilitySignal,
+	"/protos.SignalingService/RejectCall":               auth.CapabilitySignal,
+	"/protos.SignalingService/CancelCall":               auth.CapabilitySignal,
+	"/protos.SignalingService/HangupCall":               auth.CapabilitySignal,
+	"/protos.SignalingService/SubscribeCallEvent":       auth.CapabilitySignal,
+	"/protos.SignalingService/JoinRoomCall":             auth.CapabilitySignal,
+	"/protos.SignalingService/LeaveRoomCall":            auth.CapabilitySignal,
+	"/protos.SignalingService/SubscribeRoomCallEvent":   auth.CapabilitySignal,
+	"/protos.SignalingService/SubscribeOnlineStatus":    auth.CapabilityPresence,
+	"/protos.SignalingService/SetPresence":              auth.CapabilityPresence,
+	"/protos.SignalingService/GetPresence":              auth.CapabilityPresence,
+	"/protos.SignalingService/GetRoomPresence":          auth.CapabilityPresence,
+}
+
+// SetRequestContext will set request id of a call to context,
+// use request id sent by client when exists
+func (s *SignalingService) SetRequestContext(ctx context.Context) (context.Context, string) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDMetadata); len(ids) > 0 {
+			requestID = ids[0]
+		}
+	}
+	if len(requestID) == 0 {
+		requestID, _ = utils.GenerateTokenID()
+	}
+	return context.WithValue(ctx, RequestIDKey, requestID), requestID
+}
+
+// authenticate will set request & user context of a call,
+// user context not required on public methods
+func (s *SignalingService) authenticate(
+	ctx context.Context,
+	method string,
+) (context.Context, string, error) {
+	ctx, requestID := s.SetRequestContext(ctx)
+	if PublicSignalingMethods[method] {
+		return ctx, requestID, nil
+	}
+	ctx, err := s.SetUserContext(ctx)
+	if err == nil {
+		capability, ok := SignalingCapabilities[method]
+		if !ok {
+			err = status.Errorf(codes.PermissionDenied, "method %s not allowed", method)
+		} else if capability != AnyCapability && !auth.ScopeFromContext(ctx).Can(capability) {
+			err = status.Errorf(codes.PermissionDenied, "capability %s required", capability)
+		}
+	}
+	if err != nil {
+		s.Logger.Debugw("signaling call refused",
+			"method", method,
+			"request_id", requestID,
+			"reason", err,
+		)
+		return nil, requestID, err
+	}
+	return ctx, requestID, nil
+}
+
+// AuthenticateUnary is unary interceptor that authenticate
+// every signaling calls except public methods
+func (s *SignalingService) AuthenticateUnary(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	authCtx, requestID, err := s.authenticate(ctx, info.FullMethod)
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, requestID))
+	if err != nil {
+		return nil, err
+	}
+	return handler(authCtx, req)
+}
+
+// AuthenticateStream is stream interceptor that authenticate
+// every signaling streams except public methods
+func (s *SignalingService) AuthenticateStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, requestID, err := s.authenticate(ss.Context(), info.FullMethod)
+	ss.SetHeader(metadata.Pairs(RequestIDMetadata, requestID))
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream wrap server stream to carry authenticated context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context return authenticated context of stream
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,243 @@
+package server_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/connector"
+	"go.sirus.dev/p2p-comm/signalling/pkg/room"
+	"go.sirus.dev/p2p-comm/signalling/pkg/server"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"syreclabs.com/go/faker"
+)
+
+// fakeServerStream is server stream carrying context & headers of a call
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+var _ = Describe("SignalingService authentication", func() {
+	var (
+		db     *gorm.DB
+		tokens *auth.API
+		svc    *server.SignalingService
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = connector.ConnectToMemmory(auth.Models)
+		if err != nil {
+			Fail(err.Error())
+		}
+		logger := zap.NewNop().Sugar()
+		tokens = auth.NewAPI(db, logger, &auth.KeySet{Secret: faker.RandomString(20)}, time.Minute, time.Hour)
+		tokens.SetRevocations(make(chan *auth.Revocation, 10))
+		svc = server.NewSignalingService(nil, logger, nil, "test", tokens, nil, nil)
+	})
+
+	AfterEach(func() {
+		if db != nil {
+			db.Close()
+		}
+	})
+
+	accessToken := func(userID string, scope *auth.Scope) string {
+		res, err := tokens.IssueUserTokens(context.Background(), userID, scope)
+		Expect(err).To(BeNil())
+		return res.Token
+	}
+	withMetadata := func(kv ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	}
+	// callUnary return context received by handler
+	callUnary := func(ctx context.Context, method string) (context.Context, error) {
+		var handled context.Context
+		_, err := svc.AuthenticateUnary(ctx, nil,
+			&grpc.UnaryServerInfo{FullMethod: "/protos.SignalingService/" + method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				handled = ctx
+				return nil, nil
+			},
+		)
+		return handled, err
+	}
+	// callStream return context received by handler and stream headers
+	callStream := func(ctx context.Context, method string) (context.Context, metadata.MD, error) {
+		var handled context.Context
+		stream := &fakeServerStream{ctx: ctx}
+		err := svc.AuthenticateStream(nil, stream,
+			&grpc.StreamServerInfo{FullMethod: "/protos.SignalingService/" + method},
+			func(srv interface{}, ss grpc.ServerStream) error {
+				handled = ss.Context()
+				return nil
+			},
+		)
+		return handled, stream.header, err
+	}
+
+	Describe("SignalingCapabilities", func() {
+		It("should list every signaling method except public methods", func() {
+			srv := grpc.NewServer()
+			protos.RegisterSignalingServiceServer(srv, svc)
+			info := srv.GetServiceInfo()["protos.SignalingService"]
+			Expect(info.Methods).NotTo(BeEmpty())
+			for _, method := range info.Methods {
+				name := "/protos.SignalingService/" + method.Name
+				if server.PublicSignalingMethods[name] {
+					continue
+				}
+				Expect(server.SignalingCapabilities).To(HaveKey(name), method.Name)
+			}
+		})
+	})
+
+	Describe("AuthenticateUnary", func() {
+		When("method is public", func() {
+			It("should call handler without token", func() {
+				ctx, err := callUnary(context.Background(), "RefreshAccessToken")
+				Expect(err).To(BeNil())
+				Expect(ctx.Value(server.RequestIDKey)).NotTo(BeEmpty())
+				Expect(ctx.Value(room.UserIDKey)).To(BeNil())
+			})
+		})
+
+		When("token not found", func() {
+			It("should refuse call as permission denied", func() {
+				_, err := callUnary(context.Background(), "GetMyRooms")
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				_, err = callUnary(withMetadata("other", "value"), "GetMyRooms")
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+		})
+
+		When("token is valid", func() {
+			It("should propagate user, device, scope & request id to handler", func() {
+				scope := &auth.Scope{RoomIDs: []string{"r1"}}
+				ctx, err := callUnary(withMetadata(
+					server.TokenMetadata, accessToken("u1", scope),
+					server.DeviceIDMetadata, "phone",
+					server.RequestIDMetadata, "req-1",
+				), "GetMyRooms")
+				Expect(err).To(BeNil())
+				Expect(ctx.Value(room.UserIDKey)).To(Equal("u1"))
+				Expect(auth.DeviceIDFromContext(ctx)).To(Equal("phone"))
+				Expect(auth.ScopeFromContext(ctx)).To(Equal(scope))
+				Expect(ctx.Value(server.RequestIDKey)).To(Equal("req-1"))
+			})
+		})
+
+		When("token is invalid", func() {
+			It("should refuse call as permission denied", func() {
+				_, err := callUnary(withMetadata(server.TokenMetadata, "invalid"), "GetMyRooms")
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+		})
+
+		When("token expired", func() {
+			It("should refuse call as unauthenticated", func() {
+				tokens.AccessTokenTTL = -time.Minute
+				_, err := callUnary(withMetadata(server.TokenMetadata, accessToken("u1", nil)), "GetMyRooms")
+				Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("token revoked", func() {
+			It("should refuse call as unauthenticated", func() {
+				token := accessToken("u1", nil)
+				Expect(tokens.RevokeUserTokens(context.Background(), "u1")).To(BeNil())
+				_, err := callUnary(withMetadata(server.TokenMetadata, token), "GetMyRooms")
+				Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("refresh token used as access token", func() {
+			It("should refuse call as permission denied", func() {
+				res, _ := tokens.IssueUserTokens(context.Background(), "u1", nil)
+				_, err := callUnary(withMetadata(server.TokenMetadata, res.RefreshToken), "GetMyRooms")
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+		})
+
+		When("scoped token lack capability", func() {
+			It("should refuse call as permission denied", func() {
+				token := accessToken("u1", &auth.Scope{Capabilities: []string{auth.CapabilityPresence}})
+				_, err := callUnary(withMetadata(server.TokenMetadata, token), "OfferSessionDescription")
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				_, err = callUnary(withMetadata(server.TokenMetadata, token), "SetPresence")
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("unlisted method", func() {
+		It("should refuse call even for unrestricted token", func() {
+			_, err := callUnary(withMetadata(server.TokenMetadata, accessToken("u1", nil)), "NewMethod")
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+	})
+
+	Describe("method allowed for any token", func() {
+		It("should call handler using scoped token", func() {
+			token := accessToken("u1", &auth.Scope{Capabilities: []string{auth.CapabilityPresence}})
+			_, err := callUnary(withMetadata(server.TokenMetadata, token), "GetProfile")
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("AuthenticateStream", func() {
+		It("should wrap stream with authenticated context", func() {
+			ctx, header, err := callStream(withMetadata(
+				server.TokenMetadata, accessToken("u1", nil),
+				server.RequestIDMetadata, "req-1",
+			), "SubscribeSDPCommand")
+			Expect(err).To(BeNil())
+			Expect(ctx.Value(room.UserIDKey)).To(Equal("u1"))
+			Expect(ctx.Value(server.RequestIDKey)).To(Equal("req-1"))
+			Expect(header.Get(server.RequestIDMetadata)).To(Equal([]string{"req-1"}))
+		})
+
+		It("should return generated request id on header", func() {
+			_, header, err := callStream(withMetadata(server.TokenMetadata, accessToken("u1", nil)), "Connect")
+			Expect(err).To(BeNil())
+			Expect(header.Get(server.RequestIDMetadata)).To(HaveLen(1))
+			Expect(header.Get(server.RequestIDMetadata)[0]).NotTo(BeEmpty())
+		})
+
+		When("token not found", func() {
+			It("should refuse stream without calling handler", func() {
+				ctx, header, err := callStream(context.Background(), "SubscribeSDPCommand")
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Expect(ctx).To(BeNil())
+				Expect(header.Get(server.RequestIDMetadata)).To(HaveLen(1))
+			})
+		})
+
+		When("scoped token lack capability", func() {
+			It("should refuse stream as permission denied", func() {
+				token := accessToken("u1", &auth.Scope{Capabilities: []string{auth.CapabilitySignal}})
+				_, _, err := callStream(withMetadata(server.TokenMetadata, token), "SubscribeOnlineStatus")
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+		})
+	})
+})
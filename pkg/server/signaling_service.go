@@ -10,6 +10,9 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/nats-io/nats.go"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/call"
+	"go.sirus.dev/p2p-comm/signalling/pkg/ratelimit"
 	"go.sirus.dev/p2p-comm/signalling/pkg/room"
 	"go.sirus.dev/p2p-comm/signalling/pkg/signaling"
 	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
@@ -26,14 +29,18 @@ func NewSignalingService(
 	logger *zap.SugaredLogger,
 	nats *nats.EncodedConn,
 	eventNamespace string,
-	accessSecret string,
+	tokens auth.ITokenManager,
+	limiter *ratelimit.Limiter,
+	calls call.ICall,
 ) *SignalingService {
 	return &SignalingService{
 		Signaling:      signaling,
+		Calls:          calls,
 		Logger:         logger,
 		Nats:           nats,
 		EventNamespace: eventNamespace,
-		AccessSecret:   accessSecret,
+		Tokens:         tokens,
+		Limiter:        limiter,
 	}
 }
 
@@ -44,29 +51,44 @@ type SignalingService struct {
 	Logger         *zap.SugaredLogger
 	Nats           *nats.EncodedConn
 	EventNamespace string
-	AccessSecret   string
+	Tokens         auth.ITokenManager
+	Limiter        *ratelimit.Limiter
+	Calls          call.ICall
 }
 
-// SetUserContext will set user access context for each grpc calls
-// based on access token at metadata
+// SetUserContext will set user access context of a grpc call
+// based on access token at metadata, called by authentication interceptors
 func (s *SignalingService) SetUserContext(ctx context.Context) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.PermissionDenied, "metadata not found")
 	}
-	tokens := md.Get("token")
+	tokens := md.Get(TokenMetadata)
 	if len(tokens) == 0 {
 		return nil, status.Error(codes.PermissionDenied, "token not found on metadata")
 	}
-	claims, err := utils.ValidateToken(s.AccessSecret, tokens[0])
+	claims, err := s.Tokens.ValidateAccessToken(ctx, tokens[0])
 	if err != nil {
+		switch err.Error() {
+		case utils.TokenExpiredError, auth.TokenRevokedError:
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
 		return nil, status.Error(codes.PermissionDenied, "invalid token")
 	}
 	userID, ok := claims[room.UserIDKey].(string)
 	if !ok {
 		return nil, status.Error(codes.PermissionDenied, "user context not found")
 	}
+	// device bound on token take precedence over device claimed on metadata
+	deviceID, _ := claims[auth.DeviceIDKey].(string)
+	if len(deviceID) == 0 {
+		if devices := md.Get(DeviceIDMetadata); len(devices) > 0 {
+			deviceID = devices[0]
+		}
+	}
 	ctx = context.WithValue(ctx, room.UserIDKey, userID)
+	ctx = context.WithValue(ctx, auth.DeviceIDKey, deviceID)
+	ctx = context.WithValue(ctx, auth.ScopeContextKey, auth.ScopeFromClaims(claims))
 	return ctx, nil
 }
 
@@ -75,22 +97,22 @@ func (s *SignalingService) GetProfile(
 	ctx context.Context,
 	req *empty.Empty,
 ) (*protos.Profile, error) {
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return nil, err
-	}
 	return s.Signaling.MyProfile(ctx)
 }
 
+// GetICEServers return ICE servers with fresh TURN credentials
+func (s *SignalingService) GetICEServers(
+	ctx context.Context,
+	req *empty.Empty,
+) (*protos.ICEServers, error) {
+	return s.Signaling.MyICEServers(ctx)
+}
+
 // UpdateProfile will update user profile information like photo, name etc.
 func (s *SignalingService) UpdateProfile(
 	ctx context.Context,
 	req *protos.UpdateProfileParam,
 ) (*protos.Profile, error) {
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return nil, err
-	}
 	return s.Signaling.UpdateProfile(ctx, req)
 }
 
@@ -99,10 +121,6 @@ func (s *SignalingService) GetMyRooms(
 	ctx context.Context,
 	req *empty.Empty,
 ) (*protos.Rooms, error) {
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return nil, err
-	}
 	return s.Signaling.MyRooms(ctx)
 }
 
@@ -111,10 +129,6 @@ func (s *SignalingService) GetRoom(
 	ctx context.Context,
 	req *protos.GetRoomParam,
 ) (*protos.Room, error) {
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return nil, err
-	}
 	return s.Signaling.MyRoomInfo(ctx, req)
 }
 
@@ -126,36 +140,41 @@ func (s *SignalingService) GetUser(
 	return s.Signaling.GetUser(ctx, req)
 }
 
+// RefreshAccessToken will rotate access & refresh token pair using refresh token
+func (s *SignalingService) RefreshAccessToken(
+	ctx context.Context,
+	req *protos.RefreshTokenParam,
+) (*protos.UserAccessToken, error) {
+	tokens, err := s.Tokens.RefreshUserTokens(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return tokens, nil
+}
+
 // OfferSessionDescription will send session description offer from a peer to target peers
 func (s *SignalingService) OfferSessionDescription(
 	ctx context.Context,
 	req *protos.SDPParam,
-) (*empty.Empty, error) {
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-	err = s.Signaling.OfferSDP(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return &empty.Empty{}, nil
+) (*protos.Delivery, error) {
+	return s.Signaling.OfferSDP(ctx, req)
 }
 
 // AnswerSessionDescription will answer SDP offer from a peer
 func (s *SignalingService) AnswerSessionDescription(
 	ctx context.Context,
 	req *protos.SDPParam,
-) (*empty.Empty, error) {
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-	err = s.Signaling.AnswerSDP(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return &empty.Empty{}, nil
+) (*protos.Delivery, error) {
+	return s.Signaling.AnswerSDP(ctx, req)
+}
+
+// SendSessionDescription will send session description of any type to target peer,
+// covering offer, answer, provisional answer & rollback
+func (s *SignalingService) SendSessionDescription(
+	ctx context.Context,
+	req *protos.SDPParam,
+) (*protos.Delivery, error) {
+	return s.Signaling.SendSDP(ctx, req)
 }
 
 // SubscribeSDPCommand will subscribe SDP commands from other peers
@@ -164,10 +183,6 @@ func (s *SignalingService) SubscribeSDPCommand(
 	srv protos.SignalingService_SubscribeSDPCommandServer,
 ) error {
 	ctx := srv.Context()
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return err
-	}
 	commands := make(chan *signaling.SDPCommand)
 	sdps := make(chan *protos.SDP)
 	var errc error
@@ -203,14 +218,10 @@ func (s *SignalingService) SubscribeRoomEvent(
 	srv protos.SignalingService_SubscribeRoomEventServer,
 ) error {
 	ctx := srv.Context()
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return err
-	}
 	events := make(chan *room.RoomEvent)
 	protoEvents := make(chan *protos.RoomEvent)
 	var errc error
-	sub, err := s.SubscribeNatsRoomEvent(events, nil)
+	sub, err := s.SubscribeNatsRoomEvent(ctx, events, nil)
 	if err != nil {
 		return err
 	}
@@ -220,7 +231,8 @@ func (s *SignalingService) SubscribeRoomEvent(
 		if err != nil {
 			errc = err
 		}
-		close(events)
+		// events left open, NATS handler may still be sending to it
+		sub.Unsubscribe()
 		close(protoEvents)
 	}()
 	for event := range protoEvents {
@@ -236,16 +248,8 @@ func (s *SignalingService) SubscribeRoomEvent(
 func (s *SignalingService) SendICECandidate(
 	ctx context.Context,
 	req *protos.ICEParam,
-) (*empty.Empty, error) {
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-	err = s.Signaling.SendICECandidate(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return &empty.Empty{}, nil
+) (*protos.Delivery, error) {
+	return s.Signaling.SendICECandidate(ctx, req)
 }
 
 // SubscribeICECandidate will subscribe ICE candidate offers to a user
@@ -254,10 +258,6 @@ func (s *SignalingService) SubscribeICECandidate(
 	srv protos.SignalingService_SubscribeICECandidateServer,
 ) error {
 	ctx := srv.Context()
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return err
-	}
 	offers := make(chan *signaling.ICEOffer)
 	protoOffers := make(chan *protos.ICEOffer)
 	var errc error
@@ -283,6 +283,202 @@ func (s *SignalingService) SubscribeICECandidate(
 	return errc
 }
 
+// SetPresence will set presence & status text seen by other users
+func (s *SignalingService) SetPresence(
+	ctx context.Context,
+	req *protos.PresenceParam,
+) (*empty.Empty, error) {
+	err := s.Signaling.SetPresence(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// GetPresence return online status of users sharing room with caller
+func (s *SignalingService) GetPresence(
+	ctx context.Context,
+	req *protos.GetPresenceParam,
+) (*protos.OnlineStatuses, error) {
+	return s.Signaling.GetPresence(ctx, req)
+}
+
+// GetRoomPresence return online status of room members
+func (s *SignalingService) GetRoomPresence(
+	ctx context.Context,
+	req *protos.GetRoomParam,
+) (*protos.OnlineStatuses, error) {
+	return s.Signaling.GetRoomPresence(ctx, req)
+}
+
+// SendSignal will send app-level signal to a user or room members
+func (s *SignalingService) SendSignal(
+	ctx context.Context,
+	req *protos.SignalParam,
+) (*empty.Empty, error) {
+	err := s.Signaling.SendSignal(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// SubscribeSignal will subscribe app-level signals sent to a user
+func (s *SignalingService) SubscribeSignal(
+	req *empty.Empty,
+	srv protos.SignalingService_SubscribeSignalServer,
+) error {
+	ctx := srv.Context()
+	signals := make(chan *signaling.Signal)
+	protoSignals := make(chan *protos.Signal)
+	var errc error
+	sub, err := s.SubscribeNatsSignal(ctx, signals, nil)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	go func() {
+		err := s.Signaling.SubscribeSignal(ctx, signals, protoSignals)
+		if err != nil {
+			errc = err
+		}
+		// signals left open, NATS handler may still be sending to it
+		sub.Unsubscribe()
+		close(protoSignals)
+	}()
+	for signal := range protoSignals {
+		err := srv.Send(signal)
+		if err != nil {
+			return err
+		}
+	}
+	return errc
+}
+
+// StartCall will ring target user
+func (s *SignalingService) StartCall(
+	ctx context.Context,
+	req *protos.StartCallParam,
+) (*protos.Call, error) {
+	return s.Calls.StartCall(ctx, req)
+}
+
+// AcceptCall will answer ringing call
+func (s *SignalingService) AcceptCall(
+	ctx context.Context,
+	req *protos.CallParam,
+) (*protos.Call, error) {
+	return s.Calls.AcceptCall(ctx, req)
+}
+
+// RejectCall will decline ringing call
+func (s *SignalingService) RejectCall(
+	ctx context.Context,
+	req *protos.CallParam,
+) (*protos.Call, error) {
+	return s.Calls.RejectCall(ctx, req)
+}
+
+// CancelCall will stop ringing call started by user
+func (s *SignalingService) CancelCall(
+	ctx context.Context,
+	req *protos.CallParam,
+) (*protos.Call, error) {
+	return s.Calls.CancelCall(ctx, req)
+}
+
+// HangupCall will end active call
+func (s *SignalingService) HangupCall(
+	ctx context.Context,
+	req *protos.CallParam,
+) (*protos.Call, error) {
+	return s.Calls.HangupCall(ctx, req)
+}
+
+// SubscribeCallEvent will subscribe state changes of calls user participate in
+func (s *SignalingService) SubscribeCallEvent(
+	req *empty.Empty,
+	srv protos.SignalingService_SubscribeCallEventServer,
+) error {
+	ctx := srv.Context()
+	events := make(chan *call.CallEvent)
+	protoEvents := make(chan *protos.CallEvent)
+	var errc error
+	sub, err := s.SubscribeNatsCallEvent(ctx, events, nil)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	go func() {
+		err := s.Calls.SubscribeCallEvent(ctx, events, protoEvents)
+		if err != nil {
+			errc = err
+		}
+		// events left open, NATS handler may still be sending to it
+		sub.Unsubscribe()
+		close(protoEvents)
+	}()
+	for event := range protoEvents {
+		err := srv.Send(event)
+		if err != nil {
+			return err
+		}
+	}
+	return errc
+}
+
+// JoinRoomCall will add user to mesh call of a room
+func (s *SignalingService) JoinRoomCall(
+	ctx context.Context,
+	req *protos.RoomCallParam,
+) (*protos.RoomCall, error) {
+	return s.Calls.JoinRoomCall(ctx, req)
+}
+
+// LeaveRoomCall will remove user from mesh call of a room
+func (s *SignalingService) LeaveRoomCall(
+	ctx context.Context,
+	req *protos.RoomCallParam,
+) (*empty.Empty, error) {
+	err := s.Calls.LeaveRoomCall(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// SubscribeRoomCallEvent will subscribe join & leave of room calls user participate in
+func (s *SignalingService) SubscribeRoomCallEvent(
+	req *empty.Empty,
+	srv protos.SignalingService_SubscribeRoomCallEventServer,
+) error {
+	ctx := srv.Context()
+	events := make(chan *call.RoomCallEvent)
+	protoEvents := make(chan *protos.RoomCallEvent)
+	var errc error
+	sub, err := s.SubscribeNatsRoomCallEvent(ctx, events, nil)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	go func() {
+		err := s.Calls.SubscribeRoomCallEvent(ctx, events, protoEvents)
+		if err != nil {
+			errc = err
+		}
+		// events left open, NATS handler may still be sending to it
+		sub.Unsubscribe()
+		close(protoEvents)
+	}()
+	for event := range protoEvents {
+		err := srv.Send(event)
+		if err != nil {
+			return err
+		}
+	}
+	return errc
+}
+
 // SubscribeOnlineStatus act as pull-on switch mechanism for user online state
 // when user call this function user status will change to online
 // status will pull back to offline after this function exit
@@ -290,18 +486,21 @@ func (s *SignalingService) SubscribeOnlineStatus(
 	srv protos.SignalingService_SubscribeOnlineStatusServer,
 ) error {
 	ctx := srv.Context()
-	ctx, err := s.SetUserContext(ctx)
-	if err != nil {
-		return err
-	}
 	statusChanges := make(chan *signaling.OnlineStatus)
+	events := make(chan *room.RoomEvent)
 	protoStatusChanges := make(chan *protos.OnlineStatus)
 	var errc error
-	sub, err := s.SubscribeNatsOnlineStatus(statusChanges, nil)
+	sub, err := s.SubscribeNatsOnlineStatus(ctx, statusChanges, nil)
 	if err != nil {
 		return err
 	}
 	defer sub.Unsubscribe()
+	// room membership changes who's status visible to user
+	eventSub, err := s.SubscribeNatsRoomEvent(ctx, events, nil)
+	if err != nil {
+		return err
+	}
+	defer eventSub.Unsubscribe()
 
 	// forward heartbeat
 	heartbeat := make(chan *protos.Heartbeat)
@@ -314,16 +513,22 @@ func (s *SignalingService) SubscribeOnlineStatus(
 				}
 				return err
 			}
-			heartbeat <- beat
+			select {
+			case heartbeat <- beat:
+			case <-ctx.Done():
+				return nil
+			}
 		}
 	}()
 
 	go func() {
-		err := s.Signaling.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, protoStatusChanges)
+		err := s.Signaling.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, events, protoStatusChanges)
 		if err != nil {
 			errc = err
 		}
-		close(statusChanges)
+		// status changes & events left open, NATS handlers may still be sending to them
+		sub.Unsubscribe()
+		eventSub.Unsubscribe()
 		close(protoStatusChanges)
 	}()
 	for statusChange := range protoStatusChanges {
@@ -344,18 +549,42 @@ func (s *SignalingService) Run() {
 	s1c := make(chan *signaling.SDPCommand)
 	ioc := make(chan *signaling.ICEOffer)
 	uol := make(chan *signaling.OnlineStatus)
+	sgc := make(chan *signaling.Signal)
+	clc := make(chan *call.CallEvent)
+	rcc := make(chan *call.RoomCallEvent)
+	rvc := make(chan *auth.Revocation, auth.RevocationBufferSize)
 	defer close(r1c)
 	defer close(s1c)
 	defer close(ioc)
 	defer close(uol)
+	defer close(sgc)
+	defer close(clc)
+	defer close(rcc)
+	defer close(rvc)
 	s.Signaling.SetRoomEvents(r1c)
 	s.Signaling.SetCommands(s1c)
 	s.Signaling.SetICEOffers(ioc)
 	s.Signaling.SetOnlineStatus(uol)
+	s.Signaling.SetSignals(sgc)
+	s.Calls.SetEvents(clc)
+	s.Calls.SetRoomCallEvents(rcc)
+	s.Tokens.SetRevocations(rvc)
 	go s.PublishRoomEvent(r1c)
 	go s.PublishSDPCommand(s1c)
 	go s.PublishICEOffer(ioc)
 	go s.PublishOnlineStatus(uol)
+	go s.PublishSignal(sgc)
+	go s.PublishCallEvent(clc)
+	go s.PublishRoomCallEvent(rcc)
+	go s.PublishRevocation(rvc)
+
+	// apply token revocations from other instances
+	sub, err := s.SubscribeNatsRevocation(nil)
+	if err != nil {
+		s.Logger.Error(err)
+	} else {
+		defer sub.Unsubscribe()
+	}
 
 	// keep it running
 	for {
@@ -382,68 +611,24 @@ func (s *SignalingService) PublishRoomEvent(
 }
 
 // SubscribeNatsRoomEvent will subscribe native nats event
-// parsed event payload and passed it to room events channel
+// parsed event payload and passed it to room events channel until context done
 func (s *SignalingService) SubscribeNatsRoomEvent(
+	ctx context.Context,
 	events chan<- *room.RoomEvent,
 	queue *string,
 ) (*nats.Subscription, error) {
 	handler := func(m *nats.Msg) {
-		eventPattern := regexp.MustCompile(fmt.Sprintf("^%s.", s.EventNamespace))
-		subject := eventPattern.ReplaceAllString(m.Subject, "")
-		var payload interface{}
-
-		switch {
-
-		// participant event on room
-		case utils.ContainString([]string{
-			room.UserLeftRoom,
-			room.UserJoinedRoom,
-		}, subject):
-			payload = &room.RoomParticipantEventPayload{}
-			err := json.Unmarshal(m.Data, payload)
-			if err != nil {
-				s.Logger.Error(err)
-				return
-			}
-
-		// room instance event
-		case utils.ContainString([]string{
-			room.RoomCreated,
-			room.RoomProfileUpdated,
-			room.RoomDestroyed,
-		}, subject):
-			payload = &room.RoomInstanceEventPayload{}
-			err := json.Unmarshal(m.Data, payload)
-			if err != nil {
-				s.Logger.Error(err)
-				return
-			}
-
-		// user instance event
-		case utils.ContainString([]string{
-			room.UserRegistered,
-			room.UserProfileUpdated,
-			room.UserRemoved,
-		}, subject):
-			payload = &room.UserInstanceEventPayload{}
-			err := json.Unmarshal(m.Data, payload)
-			if err != nil {
-				s.Logger.Error(err)
-				return
-			}
-
-		default:
+		event, err := s.parseRoomEvent(m)
+		if err != nil {
+			s.Logger.Error(err)
 			return
 		}
-
-		// send event to channel
-		if payload == nil {
+		if event == nil {
 			return
 		}
-		events <- &room.RoomEvent{
-			Event:   subject,
-			Payload: payload,
-			Time:    time.Now(),
+		select {
+		case events <- event:
+		case <-ctx.Done():
 		}
 	}
 	if queue != nil {
@@ -452,11 +637,64 @@ func (s *SignalingService) SubscribeNatsRoomEvent(
 	return s.Nats.Subscribe(s.EventNamespace+".chat.room.*", handler)
 }
 
+// parseRoomEvent will parse room event on NATS message,
+// return nil event when subject is not a room event
+func (s *SignalingService) parseRoomEvent(m *nats.Msg) (*room.RoomEvent, error) {
+	eventPattern := regexp.MustCompile(fmt.Sprintf("^%s.", s.EventNamespace))
+	subject := eventPattern.ReplaceAllString(m.Subject, "")
+	var payload interface{}
+
+	switch {
+
+	// participant event on room
+	case utils.ContainString([]string{
+		room.UserLeftRoom,
+		room.UserJoinedRoom,
+	}, subject):
+		payload = &room.RoomParticipantEventPayload{}
+
+	// room instance event
+	case utils.ContainString([]string{
+		room.RoomCreated,
+		room.RoomProfileUpdated,
+		room.RoomDestroyed,
+	}, subject):
+		payload = &room.RoomInstanceEventPayload{}
+
+	// user instance event
+	case utils.ContainString([]string{
+		room.UserRegistered,
+		room.UserProfileUpdated,
+		room.UserRemoved,
+	}, subject):
+		payload = &room.UserInstanceEventPayload{}
+
+	default:
+		return nil, nil
+	}
+
+	err := json.Unmarshal(m.Data, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &room.RoomEvent{
+		Event:   subject,
+		Payload: payload,
+		Time:    time.Now(),
+	}, nil
+}
+
 // SDPPayload data structure on NATS message
 type SDPPayload struct {
-	From        string `json:"from"`
-	To          string `json:"to"`
-	Description string `json:"description"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	Description  string `json:"description"`
+	CallID       string `json:"call_id,omitempty"`
+	Collision    bool   `json:"collision,omitempty"`
+	FromDevice   string `json:"from_device,omitempty"`
+	ToDevice     string `json:"to_device,omitempty"`
+	ExceptDevice string `json:"except_device,omitempty"`
+	ConnectionID string `json:"connection_id,omitempty"`
 }
 
 // PublishSDPCommand will publish SDP command to NATS
@@ -469,9 +707,15 @@ func (s *SignalingService) PublishSDPCommand(
 		}
 		subject := s.EventNamespace + ".chat.sdp." + command.Type
 		err := s.Nats.Publish(subject, &SDPPayload{
-			From:        command.From,
-			To:          command.To,
-			Description: command.Description,
+			From:         command.From,
+			To:           command.To,
+			Description:  command.Description,
+			CallID:       command.CallID,
+			Collision:    command.Collision,
+			FromDevice:   command.FromDevice,
+			ToDevice:     command.ToDevice,
+			ExceptDevice: command.ExceptDevice,
+			ConnectionID: command.ConnectionID,
 		})
 		if err != nil {
 			s.Logger.Error(err)
@@ -488,20 +732,12 @@ func (s *SignalingService) SubscribeNatsSDPCommand(
 	queue *string,
 ) (*nats.Subscription, error) {
 	handler := func(m *nats.Msg) {
-		eventPattern := regexp.MustCompile(fmt.Sprintf("^%s.chat.sdp.", s.EventNamespace))
-		SDPType := eventPattern.ReplaceAllString(m.Subject, "")
-		payload := &SDPPayload{}
-		err := json.Unmarshal(m.Data, payload)
+		command, err := s.parseSDPCommand(m)
 		if err != nil {
 			s.Logger.Error(err)
 			return
 		}
-		commands <- &signaling.SDPCommand{
-			Type:        SDPType,
-			Description: payload.Description,
-			From:        payload.From,
-			To:          payload.To,
-		}
+		commands <- command
 	}
 	if queue != nil {
 		return s.Nats.QueueSubscribe(s.EventNamespace+".chat.sdp.*", *queue, handler)
@@ -509,6 +745,29 @@ func (s *SignalingService) SubscribeNatsSDPCommand(
 	return s.Nats.Subscribe(s.EventNamespace+".chat.sdp.*", handler)
 }
 
+// parseSDPCommand will parse SDP command on NATS message
+func (s *SignalingService) parseSDPCommand(m *nats.Msg) (*signaling.SDPCommand, error) {
+	eventPattern := regexp.MustCompile(fmt.Sprintf("^%s.chat.sdp.", s.EventNamespace))
+	SDPType := eventPattern.ReplaceAllString(m.Subject, "")
+	payload := &SDPPayload{}
+	err := json.Unmarshal(m.Data, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &signaling.SDPCommand{
+		Type:         SDPType,
+		Description:  payload.Description,
+		From:         payload.From,
+		To:           payload.To,
+		CallID:       payload.CallID,
+		Collision:    payload.Collision,
+		FromDevice:   payload.FromDevice,
+		ToDevice:     payload.ToDevice,
+		ExceptDevice: payload.ExceptDevice,
+		ConnectionID: payload.ConnectionID,
+	}, nil
+}
+
 // PublishICEOffer will publish ICE candidate offer to NATS
 func (s *SignalingService) PublishICEOffer(
 	offers chan *signaling.ICEOffer,
@@ -548,6 +807,135 @@ func (s *SignalingService) SubscribeNatsICEOffer(
 	return s.Nats.Subscribe(s.EventNamespace+"."+signaling.ICECandidateOffer, handler)
 }
 
+// PublishSignal will publish app-level signal to NATS
+func (s *SignalingService) PublishSignal(
+	signals chan *signaling.Signal,
+) error {
+	for signal := range signals {
+		if signal == nil {
+			continue
+		}
+		subject := s.EventNamespace + "." + signaling.PeerSignal
+		err := s.Nats.Publish(subject, signal)
+		if err != nil {
+			s.Logger.Error(err)
+			continue
+		}
+	}
+	return nil
+}
+
+// SubscribeNatsSignal will subscribe native nats message
+// parsed the payload and passed it to signal channel until context done
+func (s *SignalingService) SubscribeNatsSignal(
+	ctx context.Context,
+	signals chan<- *signaling.Signal,
+	queue *string,
+) (*nats.Subscription, error) {
+	handler := func(m *nats.Msg) {
+		signal := &signaling.Signal{}
+		err := json.Unmarshal(m.Data, signal)
+		if err != nil {
+			s.Logger.Error(err)
+			return
+		}
+		select {
+		case signals <- signal:
+		case <-ctx.Done():
+		}
+	}
+	if queue != nil {
+		return s.Nats.QueueSubscribe(s.EventNamespace+"."+signaling.PeerSignal, *queue, handler)
+	}
+	return s.Nats.Subscribe(s.EventNamespace+"."+signaling.PeerSignal, handler)
+}
+
+// PublishCallEvent will publish call state changes to NATS
+func (s *SignalingService) PublishCallEvent(
+	events chan *call.CallEvent,
+) error {
+	for event := range events {
+		if event == nil {
+			continue
+		}
+		subject := s.EventNamespace + "." + call.CallStateChanged
+		err := s.Nats.Publish(subject, event)
+		if err != nil {
+			s.Logger.Error(err)
+			continue
+		}
+	}
+	return nil
+}
+
+// SubscribeNatsCallEvent will subscribe native nats message
+// parsed the payload and passed it to call events channel until context done
+func (s *SignalingService) SubscribeNatsCallEvent(
+	ctx context.Context,
+	events chan<- *call.CallEvent,
+	queue *string,
+) (*nats.Subscription, error) {
+	handler := func(m *nats.Msg) {
+		event := &call.CallEvent{}
+		err := json.Unmarshal(m.Data, event)
+		if err != nil {
+			s.Logger.Error(err)
+			return
+		}
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}
+	if queue != nil {
+		return s.Nats.QueueSubscribe(s.EventNamespace+"."+call.CallStateChanged, *queue, handler)
+	}
+	return s.Nats.Subscribe(s.EventNamespace+"."+call.CallStateChanged, handler)
+}
+
+// PublishRoomCallEvent will publish room call changes to NATS
+func (s *SignalingService) PublishRoomCallEvent(
+	events chan *call.RoomCallEvent,
+) error {
+	for event := range events {
+		if event == nil {
+			continue
+		}
+		subject := s.EventNamespace + "." + call.RoomCallChanged
+		err := s.Nats.Publish(subject, event)
+		if err != nil {
+			s.Logger.Error(err)
+			continue
+		}
+	}
+	return nil
+}
+
+// SubscribeNatsRoomCallEvent will subscribe native nats message
+// parsed the payload and passed it to room call events channel until context done
+func (s *SignalingService) SubscribeNatsRoomCallEvent(
+	ctx context.Context,
+	events chan<- *call.RoomCallEvent,
+	queue *string,
+) (*nats.Subscription, error) {
+	handler := func(m *nats.Msg) {
+		event := &call.RoomCallEvent{}
+		err := json.Unmarshal(m.Data, event)
+		if err != nil {
+			s.Logger.Error(err)
+			return
+		}
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}
+	if queue != nil {
+		return s.Nats.QueueSubscribe(s.EventNamespace+"."+call.RoomCallChanged, *queue, handler)
+	}
+	return s.Nats.Subscribe(s.EventNamespace+"."+call.RoomCallChanged, handler)
+}
+
 // PublishOnlineStatus will publish user online status changes
 func (s *SignalingService) PublishOnlineStatus(
 	statusChanges chan *signaling.OnlineStatus,
@@ -567,8 +955,9 @@ func (s *SignalingService) PublishOnlineStatus(
 }
 
 // SubscribeNatsOnlineStatus will subscribe native nats message
-// parsed the payload and passed it to online status channel
+// parsed the payload and passed it to online status channel until context done
 func (s *SignalingService) SubscribeNatsOnlineStatus(
+	ctx context.Context,
 	statusChanges chan *signaling.OnlineStatus,
 	queue *string,
 ) (*nats.Subscription, error) {
@@ -579,10 +968,52 @@ func (s *SignalingService) SubscribeNatsOnlineStatus(
 			s.Logger.Error(err)
 			return
 		}
-		statusChanges <- statusChange
+		select {
+		case statusChanges <- statusChange:
+		case <-ctx.Done():
+		}
 	}
 	if queue != nil {
 		return s.Nats.QueueSubscribe(s.EventNamespace+"."+signaling.OnlineStatusChangeEvent, *queue, handler)
 	}
 	return s.Nats.Subscribe(s.EventNamespace+"."+signaling.OnlineStatusChangeEvent, handler)
 }
+
+// PublishRevocation will publish token revocations to NATS
+// so every instance reject revoked tokens
+func (s *SignalingService) PublishRevocation(
+	revocations chan *auth.Revocation,
+) error {
+	for revocation := range revocations {
+		if revocation == nil {
+			continue
+		}
+		subject := s.EventNamespace + "." + auth.TokenRevoked
+		err := s.Nats.Publish(subject, revocation)
+		if err != nil {
+			s.Logger.Error(err)
+			continue
+		}
+	}
+	return nil
+}
+
+// SubscribeNatsRevocation will subscribe native nats message
+// parsed the payload and apply it to token manager
+func (s *SignalingService) SubscribeNatsRevocation(
+	queue *string,
+) (*nats.Subscription, error) {
+	handler := func(m *nats.Msg) {
+		revocation := &auth.Revocation{}
+		err := json.Unmarshal(m.Data, revocation)
+		if err != nil {
+			s.Logger.Error(err)
+			return
+		}
+		s.Tokens.ApplyRevocation(revocation)
+	}
+	if queue != nil {
+		return s.Nats.QueueSubscribe(s.EventNamespace+"."+auth.TokenRevoked, *queue, handler)
+	}
+	return s.Nats.Subscribe(s.EventNamespace+"."+auth.TokenRevoked, handler)
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TLSConfig define certificate used by gRPC listener,
+// listener serve plain TCP when certificate not configured
+type TLSConfig struct {
+	CertFile string `json:"cert_file" mapstructure:"cert_file"`
+	KeyFile  string `json:"key_file" mapstructure:"key_file"`
+	// ClientCAFile enable mutual TLS, client certificate verified using this CA
+	ClientCAFile string `json:"client_ca_file" mapstructure:"client_ca_file"`
+	// RequireClientCert reject connection without valid client certificate
+	RequireClientCert bool `json:"require_client_cert" mapstructure:"require_client_cert"`
+}
+
+// Enabled return true when certificate configured
+func (c *TLSConfig) Enabled() bool {
+	return c != nil && len(c.CertFile) > 0 && len(c.KeyFile) > 0
+}
+
+// NewCertReloader will create certificate reloader and load it's files
+func NewCertReloader(config *TLSConfig, logger *zap.SugaredLogger) (*CertReloader, error) {
+	r := &CertReloader{
+		Config:   config,
+		Logger:   logger,
+		modTimes: map[string]time.Time{},
+	}
+	err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CertReloader serve TLS certificate & client CA from disk,
+// files reloaded on next handshake after they changed so
+// certificate can be rotated without restart
+type CertReloader struct {
+	Config      *TLSConfig
+	Logger      *zap.SugaredLogger
+	mutex       sync.RWMutex
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+	modTimes    map[string]time.Time
+}
+
+// files return list of files watched by reloader
+func (r *CertReloader) files() []string {
+	files := []string{r.Config.CertFile, r.Config.KeyFile}
+	if len(r.Config.ClientCAFile) > 0 {
+		files = append(files, r.Config.ClientCAFile)
+	}
+	return files
+}
+
+// changed return true when one of watched files modified since last load
+func (r *CertReloader) changed() bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+// load will read certificate & client CA from their files
+func (r *CertReloader) load() error {
+	modTimes := map[string]time.Time{}
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+	}
+	certificate, clientCAs, err := r.read()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	// broken files only retried after they changed again
+	r.modTimes = modTimes
+	if err != nil {
+		return err
+	}
+	r.certificate = certificate
+	r.clientCAs = clientCAs
+	return nil
+}
+
+// read will parse certificate & client CA files
+func (r *CertReloader) read() (*tls.Certificate, *x509.CertPool, error) {
+	certificate, err := tls.LoadX509KeyPair(r.Config.CertFile, r.Config.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	var clientCAs *x509.CertPool
+	if len(r.Config.ClientCAFile) > 0 {
+		pem, err := ioutil.ReadFile(r.Config.ClientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return nil, nil, fmt.Errorf("no certificate found on %s", r.Config.ClientCAFile)
+		}
+	}
+	return &certificate, clientCAs, nil
+}
+
+// GetConfigForClient return TLS config of a handshake,
+// reload certificate first when it's files changed
+func (r *CertReloader) GetConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	if r.changed() {
+		// keep serving previous certificate when new one is broken
+		err := r.load()
+		if err != nil {
+			r.Logger.Errorf("failed to reload certificate %s -> %v", r.Config.CertFile, err)
+		} else {
+			r.Logger.Infof("certificate %s reloaded", r.Config.CertFile)
+		}
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.certificate},
+		// required by gRPC since config replace the one negotiated by credentials
+		NextProtos: []string{"h2"},
+	}
+	if r.clientCAs != nil {
+		config.ClientCAs = r.clientCAs
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if r.Config.RequireClientCert {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	return config, nil
+}
+
+// TLSConfig return TLS config of listener
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.GetConfigForClient,
+	}
+}
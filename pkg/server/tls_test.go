@@ -0,0 +1,112 @@
+package server_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/server"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// writeCertificate will write self-signed certificate of common name & it's key,
+// files modification time set to modTime
+func writeCertificate(certFile string, keyFile string, commonName string, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).To(BeNil())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).To(BeNil())
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	Expect(ioutil.WriteFile(certFile, certPEM, 0600)).To(BeNil())
+	Expect(ioutil.WriteFile(keyFile, keyPEM, 0600)).To(BeNil())
+	Expect(os.Chtimes(certFile, modTime, modTime)).To(BeNil())
+	Expect(os.Chtimes(keyFile, modTime, modTime)).To(BeNil())
+}
+
+var _ = Describe("CertReloader", func() {
+	var (
+		dir      string
+		certFile string
+		keyFile  string
+		logs     *observer.ObservedLogs
+		reloader *server.CertReloader
+		start    time.Time
+	)
+
+	// servedCommonName return common name of certificate served on next handshake
+	servedCommonName := func() string {
+		config, err := reloader.GetConfigForClient(&tls.ClientHelloInfo{})
+		Expect(err).To(BeNil())
+		cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+		Expect(err).To(BeNil())
+		return cert.Subject.CommonName
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "certs")
+		Expect(err).To(BeNil())
+		certFile = filepath.Join(dir, "cert.pem")
+		keyFile = filepath.Join(dir, "key.pem")
+		start = time.Now().Add(-time.Minute)
+		writeCertificate(certFile, keyFile, "first", start)
+		var core zapcore.Core
+		core, logs = observer.New(zap.InfoLevel)
+		reloader, err = server.NewCertReloader(&server.TLSConfig{
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		}, zap.New(core).Sugar())
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should serve loaded certificate", func() {
+		Expect(servedCommonName()).To(Equal("first"))
+		Expect(logs.Len()).To(Equal(0))
+	})
+
+	When("certificate files changed", func() {
+		It("should serve new certificate", func() {
+			writeCertificate(certFile, keyFile, "second", start.Add(time.Second))
+			Expect(servedCommonName()).To(Equal("second"))
+			Expect(logs.FilterMessageSnippet("reloaded").Len()).To(Equal(1))
+		})
+	})
+
+	When("new certificate is broken", func() {
+		It("should keep previous certificate and retry only after files changed", func() {
+			Expect(ioutil.WriteFile(certFile, []byte("broken"), 0600)).To(BeNil())
+			Expect(os.Chtimes(certFile, start.Add(time.Second), start.Add(time.Second))).To(BeNil())
+			Expect(servedCommonName()).To(Equal("first"))
+			Expect(servedCommonName()).To(Equal("first"))
+			Expect(logs.FilterMessageSnippet("failed to reload").Len()).To(Equal(1))
+			writeCertificate(certFile, keyFile, "fixed", start.Add(time.Second*2))
+			Expect(servedCommonName()).To(Equal("fixed"))
+		})
+	})
+})
@@ -1,16 +1,26 @@
 package signaling
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"time"
 
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/ptypes"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jinzhu/gorm"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/call"
 	"go.sirus.dev/p2p-comm/signalling/pkg/room"
 	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
 	"go.sirus.dev/p2p-comm/signalling/protos"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -20,19 +30,48 @@ const (
 )
 
 const (
-	ContextInvalidError = "context invalid"
+	ContextInvalidError      = "context invalid"
+	NoSharedRoomError        = "user not share any room with target user"
+	InvalidSDPTypeError      = "invalid session description type"
+	InvalidSignalError       = "signal must have a type and either target user or room"
+	InvalidICEError          = "ICE candidate required unless end of candidates or ICE restart"
+	SignalTooLargeError      = "signal payload too large"
+	InvalidPresenceError     = "presence must be one of online, away, busy, do not disturb or invisible"
+	InvalidConnectionIDError = "connection id must be at most 64 letters, digits, dot, colon, dash or underscore"
 )
 
+// MaxSignalSize is maximum size of signal payload & data in bytes
+const MaxSignalSize = 64 * 1024
+
+// MaxConnectionIDLength is maximum length of peer connection id
+const MaxConnectionIDLength = 64
+
+// connectionIDPattern match characters allowed on peer connection id
+var connectionIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]*$`)
+
+// ValidateConnectionID return invalid argument error when connection id
+// too long or contain characters not allowed
+func ValidateConnectionID(connectionID string) error {
+	if len(connectionID) > MaxConnectionIDLength || !connectionIDPattern.MatchString(connectionID) {
+		return status.Error(codes.InvalidArgument, InvalidConnectionIDError)
+	}
+	return nil
+}
+
 // NewAPI will create new instance of signaling API
 func NewAPI(
 	db *gorm.DB,
 	logger *zap.SugaredLogger,
 	ICEServers *[]ICEServer,
+	queue *QueueConfig,
+	presenceGrace time.Duration,
 ) *API {
 	return &API{
-		DB:         db,
-		Logger:     logger,
-		ICEServers: ICEServers,
+		DB:            db,
+		Logger:        logger,
+		ICEServers:    ICEServers,
+		Queue:         queue,
+		PresenceGrace: presenceGrace,
 	}
 }
 
@@ -44,6 +83,11 @@ type ICEServer struct {
 	Password       string `json:"password" mapstructure:"password"`
 	AccessToken    string `json:"access_token" mapstructure:"access_token"`
 	MacKey         string `json:"mac_key" mapstructure:"mac_key"`
+	// Secret shared with TURN server, when set each user get
+	// time-limited credential instead of static username & password
+	Secret string        `json:"-" mapstructure:"secret"`
+	TTL    time.Duration `json:"ttl" mapstructure:"ttl"`
+	Realm  string        `json:"realm" mapstructure:"realm"`
 }
 
 // SDPTypeProtoToCommand mapping from  proto to command
@@ -60,6 +104,8 @@ var SDPTypeCommandToProto = map[string]protos.SDPTypes{
 	SDPAnswer:   protos.SDPTypes(1),
 	SDPPranswer: protos.SDPTypes(2),
 	SDPRollback: protos.SDPTypes(3),
+	// only sent by server, peers can't send it
+	SDPAnsweredElsewhere: protos.SDPTypes(4),
 }
 
 // API act as intermediate between peers,
@@ -72,6 +118,12 @@ type API struct {
 	Events     chan *room.RoomEvent
 	ICEs       chan *ICEOffer
 	Onlines    chan *OnlineStatus
+	Signals    chan *Signal
+	Queue      *QueueConfig
+	// how long user stay online after it's last session ended
+	PresenceGrace time.Duration
+	// instance owning sessions opened on this API, set when instance registered
+	InstanceID string
 }
 
 // GetCommands return SDP command channel
@@ -114,6 +166,16 @@ func (a *API) SetOnlineStatus(statusChanges chan *OnlineStatus) {
 	a.Onlines = statusChanges
 }
 
+// GetSignals will return channel use to publish app-level signals
+func (a *API) GetSignals() chan *Signal {
+	return a.Signals
+}
+
+// SetSignals will set channel use to publish app-level signals
+func (a *API) SetSignals(signals chan *Signal) {
+	a.Signals = signals
+}
+
 // GetUserContext will return user context for an invocation
 func (a *API) GetUserContext(ctx context.Context) (*room.UserModel, error) {
 	userID, ok := ctx.Value(room.UserIDKey).(string)
@@ -139,12 +201,27 @@ func (a *API) MyProfile(ctx context.Context) (*protos.Profile, error) {
 	if err != nil {
 		return nil, err
 	}
-	return a.GetMyProfile(user), nil
+	return a.GetMyProfile(user, auth.ScopeFromContext(ctx)), nil
+}
+
+// GetMyProfile will add user & ICE server config to profile information,
+// ICE servers only given to token allowed to signal since they carry TURN credentials
+func (a *API) GetMyProfile(user *room.UserModel, scope *auth.Scope) *protos.Profile {
+	profile := &protos.Profile{
+		Id:      user.ID,
+		Name:    user.Name,
+		Photo:   user.Photo,
+		Servers: []*protos.ICEServer{},
+	}
+	if scope.Can(auth.CapabilitySignal) {
+		profile.Servers = a.GetICEServers(user, time.Now())
+	}
+	return profile
 }
 
-// GetMyProfile will add user & ICE server config to profile information
-func (a *API) GetMyProfile(user *room.UserModel) *protos.Profile {
-	// map ice server configurations
+// GetICEServers will map ICE server config for a user,
+// TURN server with shared secret get credential valid for it's TTL since now
+func (a *API) GetICEServers(user *room.UserModel, now time.Time) []*protos.ICEServer {
 	servers := []*protos.ICEServer{}
 	for _, ice := range *a.ICEServers {
 		server := &protos.ICEServer{Url: ice.URL}
@@ -153,14 +230,32 @@ func (a *API) GetMyProfile(user *room.UserModel) *protos.Profile {
 		server.Password = ice.Password
 		server.AccessToken = ice.AccessToken
 		server.MacKey = ice.MacKey
+		server.Realm = ice.Realm
+		if len(ice.Secret) > 0 {
+			ttl := ice.TTL
+			if ttl <= 0 {
+				ttl = DefaultTURNCredentialTTL
+			}
+			expiresAt := now.Add(ttl)
+			server.CredentialType = protos.ICECredentialType_PASSWORD
+			server.Username, server.Password = GenerateTURNCredential(ice.Secret, user.ID, expiresAt)
+			server.ExpiresAt, _ = ptypes.TimestampProto(expiresAt)
+		}
 		servers = append(servers, server)
 	}
-	return &protos.Profile{
-		Id:      user.ID,
-		Name:    user.Name,
-		Photo:   user.Photo,
-		Servers: servers,
+	return servers
+}
+
+// MyICEServers will return ICE servers with fresh TURN credentials,
+// used to refresh credentials before they expired on long session
+func (a *API) MyICEServers(ctx context.Context) (*protos.ICEServers, error) {
+	user, err := a.GetUserContext(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return &protos.ICEServers{
+		Servers: a.GetICEServers(user, time.Now()),
+	}, nil
 }
 
 // UpdateProfile will update user profile information like photo, name etc.
@@ -198,7 +293,7 @@ func (a *API) UpdateProfile(ctx context.Context, param *protos.UpdateProfilePara
 		},
 	}
 	// return profile information
-	return a.GetMyProfile(user), nil
+	return a.GetMyProfile(user, auth.ScopeFromContext(ctx)), nil
 }
 
 // MyRooms will return list of room peer participates in
@@ -226,6 +321,12 @@ func (a *API) MyRooms(ctx context.Context) (*protos.Rooms, error) {
 	for _, data := range datas {
 		roomIds = append(roomIds, data.ID)
 	}
+	// only show rooms allowed by token scope
+	scope := auth.ScopeFromContext(ctx)
+	if scope.IsRoomRestricted() {
+		roomIds = scope.FilterRooms(roomIds)
+		count = len(roomIds)
+	}
 	err = a.DB.Preload("Members").
 		Find(&datas, "id IN (?)", roomIds).
 		Error
@@ -245,14 +346,28 @@ func (a *API) MyRooms(ctx context.Context) (*protos.Rooms, error) {
 
 // MyRoomInfo will return detailed information about a room peer participates in
 func (a *API) MyRoomInfo(ctx context.Context, param *protos.GetRoomParam) (*protos.Room, error) {
+	r, err := a.getMyRoom(ctx, param.Id)
+	if err != nil {
+		return nil, err
+	}
+	// return room with members
+	return room.RoomModelToProto(r), nil
+}
+
+// getMyRoom return room with it's members when user participates in it
+func (a *API) getMyRoom(ctx context.Context, roomID string) (*room.RoomModel, error) {
 	user, err := a.GetUserContext(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// room outside token scope treated as not exist
+	if !auth.ScopeFromContext(ctx).AllowRoom(roomID) {
+		return nil, fmt.Errorf(room.RoomNotFoundError)
+	}
 	// get room of this user
 	r := &room.RoomModel{}
 	err = a.DB.Preload("Members").
-		First(r, "id = ?", param.Id).
+		First(r, "id = ?", roomID).
 		Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -271,14 +386,18 @@ func (a *API) MyRoomInfo(ctx context.Context, param *protos.GetRoomParam) (*prot
 	if !exist {
 		return nil, fmt.Errorf(room.RoomNotFoundError)
 	}
-	// return room with members
-	return room.RoomModelToProto(r), nil
+	return r, nil
 }
 
-// GetUser return user information by it's id
+// GetUser return user information by it's id,
+// presence of user not sharing any room with caller seen as offline
 func (a *API) GetUser(ctx context.Context, param *protos.GetUserParam) (*protos.User, error) {
+	me, err := a.GetUserContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	user := &room.UserModel{}
-	err := a.DB.Where(&room.UserModel{ID: param.Id}).
+	err = a.DB.Where(&room.UserModel{ID: param.Id}).
 		First(user).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -286,37 +405,143 @@ func (a *API) GetUser(ctx context.Context, param *protos.GetUserParam) (*protos.
 		}
 		return nil, err
 	}
-	return room.UserModelToProto(user), nil
+	res := room.UserModelToProto(user)
+	if user.ID == me.ID {
+		return res, nil
+	}
+	members, err := a.GetCoMemberIDs(me, auth.ScopeFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if !members[user.ID] {
+		res.Online = false
+		res.Presence = room.PresenceToProto[room.PresenceOffline]
+		res.StatusText = ""
+		res.LastSeenAt = nil
+	}
+	return res, nil
 }
 
 // OfferSDP will send session description offer from a peer to target peers
-func (a *API) OfferSDP(ctx context.Context, param *protos.SDPParam) error {
+func (a *API) OfferSDP(ctx context.Context, param *protos.SDPParam) (*protos.Delivery, error) {
+	return a.sendSDP(ctx, SDPOffer, param)
+}
+
+// AnswerSDP will answer SDP offer from a peer
+func (a *API) AnswerSDP(ctx context.Context, param *protos.SDPParam) (*protos.Delivery, error) {
+	return a.sendSDP(ctx, SDPAnswer, param)
+}
+
+// SendSDP will send session description of any type to target peer,
+// used to send provisional answer & rollback during renegotiation
+func (a *API) SendSDP(ctx context.Context, param *protos.SDPParam) (*protos.Delivery, error) {
+	sdpType, ok := SDPTypeProtoToCommand[param.Type]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, InvalidSDPTypeError)
+	}
+	return a.sendSDP(ctx, sdpType, param)
+}
+
+// sendSDP will publish SDP command of a type from user to target peer,
+// queued when target peer not subscribed
+func (a *API) sendSDP(ctx context.Context, sdpType string, param *protos.SDPParam) (*protos.Delivery, error) {
 	user, err := a.GetUserContext(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	a.Commands <- &SDPCommand{
-		Type:        SDPOffer,
-		From:        user.ID,
-		To:          param.UserID,
-		Description: param.Description,
+	err = ValidateConnectionID(param.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	device := auth.DeviceIDFromContext(ctx)
+	err = a.AuthorizePeer(ctx, user, param.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(param.CallID) > 0 {
+		err = call.AuthorizeSession(a.DB, param.CallID, user.ID, param.UserID)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return nil
-}
 
-// AnswerSDP will answer SDP offer from a peer
-func (a *API) AnswerSDP(ctx context.Context, param *protos.SDPParam) error {
-	user, err := a.GetUserContext(ctx)
+	// detect offers sent by both peers at the same time
+	switch sdpType {
+	case SDPOffer:
+		collided, err := a.trackOffer(user.ID, param.UserID, param.CallID, param.ConnectionID)
+		if err != nil {
+			return nil, err
+		}
+		if collided && IsPolite(user.ID, param.UserID) {
+			// drop polite peer offer, impolite peer offer already on the way
+			_, err := a.rollback(user.ID, device, param.UserID, param.CallID, param.ConnectionID)
+			if err != nil {
+				return nil, err
+			}
+			return &protos.Delivery{Status: protos.DeliveryStatus_Collided}, nil
+		}
+		if collided {
+			_, err := a.rollback(param.UserID, "", user.ID, param.CallID, param.ConnectionID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// peer reply to device sending the offer
+		err = a.pinDevice(param.UserID, user.ID, param.CallID, param.ConnectionID, device)
+	case SDPAnswer:
+		err = a.clearOffer(param.UserID, user.ID, param.CallID, param.ConnectionID)
+		if err != nil {
+			return nil, err
+		}
+		// rest of negotiation continue on device answering the offer
+		err = a.pinDevice(param.UserID, user.ID, param.CallID, param.ConnectionID, device)
+		if err != nil {
+			return nil, err
+		}
+		a.answeredElsewhere(user.ID, device, param.UserID, param.CallID, param.ConnectionID)
+	case SDPRollback:
+		err = a.clearOffer(user.ID, param.UserID, param.CallID, param.ConnectionID)
+		if err != nil {
+			return nil, err
+		}
+		// offer withdrawn, peer reply no longer bound to device sending it
+		err = a.unpinDevice(param.UserID, user.ID, param.CallID, param.ConnectionID)
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
-	a.Commands <- &SDPCommand{
-		Type:        SDPAnswer,
-		From:        user.ID,
-		To:          param.UserID,
-		Description: param.Description,
+
+	toDevice, err := a.routeDevice(user.ID, param.UserID, param.CallID, param.ConnectionID, param.DeviceID, DeliveryKindSDP)
+	if err != nil {
+		return nil, err
+	}
+	command := &SDPCommand{
+		Type:         sdpType,
+		From:         user.ID,
+		To:           param.UserID,
+		Description:  param.Description,
+		CallID:       param.CallID,
+		FromDevice:   device,
+		ToDevice:     toDevice,
+		ConnectionID: param.ConnectionID,
+	}
+	return a.deliver(command.From, command.To, command.ToDevice, command.ConnectionID, DeliveryKindSDP, command, func() {
+		a.Commands <- command
+	})
+}
+
+// sdpCommandToProto convert SDP command to protobuf
+func sdpCommandToProto(command *SDPCommand) *protos.SDP {
+	return &protos.SDP{
+		Type:           SDPTypeCommandToProto[command.Type],
+		SenderID:       command.From,
+		Description:    command.Description,
+		CallID:         command.CallID,
+		Polite:         IsPolite(command.To, command.From),
+		Collision:      command.Collision,
+		SenderDeviceID: command.FromDevice,
+		ConnectionID:   command.ConnectionID,
 	}
-	return nil
 }
 
 // SubscribeSDPCommand will subscribe SDP commands from other peers
@@ -329,6 +554,36 @@ func (a *API) SubscribeSDPCommand(
 	if err != nil {
 		return err
 	}
+	scope := auth.ScopeFromContext(ctx)
+	device := auth.DeviceIDFromContext(ctx)
+	release, err := a.KeepSubscribed(user.ID, device, DeliveryKindSDP)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// send SDP commands queued while user not subscribed
+	pending, err := a.DrainPending(user.ID, device, DeliveryKindSDP)
+	if err != nil {
+		a.Logger.Error(err)
+	}
+	for _, message := range pending {
+		command := &SDPCommand{}
+		err := json.Unmarshal([]byte(message.Payload), command)
+		if err != nil {
+			a.Logger.Error(err)
+			continue
+		}
+		if !a.AcceptFrom(user, command.From, scope) {
+			continue
+		}
+		select {
+		case protoEvents <- sdpCommandToProto(command):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
 	for {
 		select {
 		case command := <-commands:
@@ -338,10 +593,16 @@ func (a *API) SubscribeSDPCommand(
 			if command.To != user.ID {
 				continue
 			}
-			protoEvents <- &protos.SDP{
-				Type:        SDPTypeCommandToProto[command.Type],
-				SenderID:    command.From,
-				Description: command.Description,
+			if !acceptDevice(device, command.ToDevice, command.ExceptDevice) {
+				continue
+			}
+			if !a.AcceptFrom(user, command.From, scope) {
+				continue
+			}
+			select {
+			case protoEvents <- sdpCommandToProto(command):
+			case <-ctx.Done():
+				return nil
 			}
 		case <-ctx.Done():
 			return nil
@@ -373,6 +634,65 @@ func (a *API) IsItMyRooms(
 	return &exist, nil
 }
 
+// IsSharingRoomWith return true when me and target user are member of at least one same room,
+// only rooms allowed by token scope considered
+func (a *API) IsSharingRoomWith(
+	me *room.UserModel,
+	userID string,
+	scope *auth.Scope,
+) (*bool, error) {
+	// get target user rooms
+	theirRooms := &[]room.RoomModel{}
+	err := a.DB.
+		Model(&room.UserModel{ID: userID}).
+		Related(theirRooms, "Rooms").
+		Error
+	if err != nil {
+		return nil, err
+	}
+	roomIDs := []string{}
+	for _, r := range *theirRooms {
+		roomIDs = append(roomIDs, r.ID)
+	}
+	return a.IsItMyRooms(me, scope.FilterRooms(roomIDs))
+}
+
+// AuthorizePeer will reject signal from user to target peer
+// when both of them not share any room allowed by token scope
+func (a *API) AuthorizePeer(
+	ctx context.Context,
+	me *room.UserModel,
+	userID string,
+) error {
+	shared, err := a.IsSharingRoomWith(me, userID, auth.ScopeFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if !(*shared) {
+		a.Logger.Warnf("rejected signal from user %s to user %s: %s", me.ID, userID, NoSharedRoomError)
+		return status.Error(codes.PermissionDenied, NoSharedRoomError)
+	}
+	return nil
+}
+
+// AcceptFrom return true when user can receive signal from a peer,
+// token restricted to some rooms only accept signal from those rooms members
+func (a *API) AcceptFrom(
+	me *room.UserModel,
+	userID string,
+	scope *auth.Scope,
+) bool {
+	if !scope.IsRoomRestricted() {
+		return true
+	}
+	shared, err := a.IsSharingRoomWith(me, userID, scope)
+	if err != nil {
+		a.Logger.Error(err)
+		return false
+	}
+	return *shared
+}
+
 // SubscribeRoomEvent will subscribe changes in a rooms or channel
 func (a *API) SubscribeRoomEvent(
 	ctx context.Context,
@@ -383,6 +703,7 @@ func (a *API) SubscribeRoomEvent(
 	if err != nil {
 		return err
 	}
+	scope := auth.ScopeFromContext(ctx)
 	for {
 		select {
 		case event := <-events:
@@ -401,6 +722,9 @@ func (a *API) SubscribeRoomEvent(
 					if !utils.ContainString(payload.ParticipantIDs, user.ID) {
 						continue
 					}
+					if !scope.AllowRoom(payload.RoomID) {
+						continue
+					}
 					roomEvent = &protos.RoomEvent{
 						Event: protos.RoomEvents_UserJoinedRoom,
 						Payload: &protos.RoomEvent_RoomParticipant{
@@ -420,6 +744,9 @@ func (a *API) SubscribeRoomEvent(
 					if !utils.ContainString(payload.ParticipantIDs, user.ID) {
 						continue
 					}
+					if !scope.AllowRoom(payload.RoomID) {
+						continue
+					}
 					roomEvent = &protos.RoomEvent{
 						Event: protos.RoomEvents_UserLeftRoom,
 						Payload: &protos.RoomEvent_RoomParticipant{
@@ -439,6 +766,9 @@ func (a *API) SubscribeRoomEvent(
 					if !utils.ContainString(payload.MemberIDs, user.ID) {
 						continue
 					}
+					if !scope.AllowRoom(payload.ID) {
+						continue
+					}
 					roomEvent = &protos.RoomEvent{
 						Event: protos.RoomEvents_RoomCreated,
 						Payload: &protos.RoomEvent_RoomInstance{
@@ -460,6 +790,9 @@ func (a *API) SubscribeRoomEvent(
 					if !utils.ContainString(payload.MemberIDs, user.ID) {
 						continue
 					}
+					if !scope.AllowRoom(payload.ID) {
+						continue
+					}
 					roomEvent = &protos.RoomEvent{
 						Event: protos.RoomEvents_RoomProfileUpdated,
 						Payload: &protos.RoomEvent_RoomInstance{
@@ -481,6 +814,9 @@ func (a *API) SubscribeRoomEvent(
 					if !utils.ContainString(payload.MemberIDs, user.ID) {
 						continue
 					}
+					if !scope.AllowRoom(payload.ID) {
+						continue
+					}
 					roomEvent = &protos.RoomEvent{
 						Event: protos.RoomEvents_RoomDestroyed,
 						Payload: &protos.RoomEvent_RoomInstance{
@@ -499,7 +835,7 @@ func (a *API) SubscribeRoomEvent(
 					if !ok {
 						continue
 					}
-					inMyRoom, err := a.IsItMyRooms(user, payload.RoomIDs)
+					inMyRoom, err := a.IsItMyRooms(user, scope.FilterRooms(payload.RoomIDs))
 					if err != nil {
 						a.Logger.Error(err)
 						continue
@@ -524,7 +860,7 @@ func (a *API) SubscribeRoomEvent(
 					if !ok {
 						continue
 					}
-					inMyRoom, err := a.IsItMyRooms(user, payload.RoomIDs)
+					inMyRoom, err := a.IsItMyRooms(user, scope.FilterRooms(payload.RoomIDs))
 					if err != nil {
 						a.Logger.Error(err)
 						continue
@@ -554,7 +890,11 @@ func (a *API) SubscribeRoomEvent(
 				continue
 			}
 			roomEvent.Time = timestamp
-			protoEvents <- roomEvent
+			select {
+			case protoEvents <- roomEvent:
+			case <-ctx.Done():
+				return nil
+			}
 		case <-ctx.Done():
 			return nil
 		}
@@ -565,18 +905,83 @@ func (a *API) SubscribeRoomEvent(
 func (a *API) SendICECandidate(
 	ctx context.Context,
 	param *protos.ICEParam,
-) error {
+) (*protos.Delivery, error) {
 	user, err := a.GetUserContext(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	a.ICEs <- &ICEOffer{
-		From:      user.ID,
-		To:        param.UserID,
-		IsRemote:  param.IsRemote,
-		Candidate: param.Candidate,
+	if len(param.Candidate) == 0 && !param.EndOfCandidates && !param.IceRestart {
+		return nil, status.Error(codes.InvalidArgument, InvalidICEError)
 	}
-	return nil
+	err = ValidateConnectionID(param.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	err = a.AuthorizePeer(ctx, user, param.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(param.CallID) > 0 {
+		err = call.AuthorizeSession(a.DB, param.CallID, user.ID, param.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	toDevice, err := a.routeDevice(user.ID, param.UserID, param.CallID, param.ConnectionID, param.DeviceID, DeliveryKindICE)
+	if err != nil {
+		return nil, err
+	}
+	offer := &ICEOffer{
+		From:             user.ID,
+		To:               param.UserID,
+		IsRemote:         param.IsRemote,
+		Candidate:        param.Candidate,
+		SDPMid:           param.SdpMid,
+		UsernameFragment: param.UsernameFragment,
+		EndOfCandidates:  param.EndOfCandidates,
+		ICERestart:       param.IceRestart,
+		FromDevice:       auth.DeviceIDFromContext(ctx),
+		ToDevice:         toDevice,
+		ConnectionID:     param.ConnectionID,
+		CallID:           param.CallID,
+	}
+	if param.SdpMLineIndex != nil {
+		index := param.SdpMLineIndex.Value
+		offer.SDPMLineIndex = &index
+	}
+	// candidates of connection queued before restart no longer valid
+	if offer.ICERestart {
+		_, err = a.deletePending(
+			"user_id = ? AND sender_id = ? AND kind = ? AND connection_id = ?",
+			offer.To, offer.From, DeliveryKindICE, offer.ConnectionID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a.deliver(offer.From, offer.To, offer.ToDevice, offer.ConnectionID, DeliveryKindICE, offer, func() {
+		a.ICEs <- offer
+	})
+}
+
+// iceOfferToProto convert ICE candidate offer to protobuf
+func iceOfferToProto(offer *ICEOffer) *protos.ICEOffer {
+	protoOffer := &protos.ICEOffer{
+		SenderID:         offer.From,
+		IsRemote:         offer.IsRemote,
+		Candidate:        offer.Candidate,
+		SdpMid:           offer.SDPMid,
+		UsernameFragment: offer.UsernameFragment,
+		EndOfCandidates:  offer.EndOfCandidates,
+		IceRestart:       offer.ICERestart,
+		SenderDeviceID:   offer.FromDevice,
+		ConnectionID:     offer.ConnectionID,
+		CallID:           offer.CallID,
+	}
+	if offer.SDPMLineIndex != nil {
+		protoOffer.SdpMLineIndex = &wrappers.UInt32Value{Value: *offer.SDPMLineIndex}
+	}
+	return protoOffer
 }
 
 // SubscribeICECandidate will return all ICE candidate offer to this user
@@ -589,6 +994,36 @@ func (a *API) SubscribeICECandidate(
 	if err != nil {
 		return err
 	}
+	scope := auth.ScopeFromContext(ctx)
+	device := auth.DeviceIDFromContext(ctx)
+	release, err := a.KeepSubscribed(user.ID, device, DeliveryKindICE)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// send ICE candidates queued while user not subscribed
+	pending, err := a.DrainPending(user.ID, device, DeliveryKindICE)
+	if err != nil {
+		a.Logger.Error(err)
+	}
+	for _, message := range pending {
+		offer := &ICEOffer{}
+		err := json.Unmarshal([]byte(message.Payload), offer)
+		if err != nil {
+			a.Logger.Error(err)
+			continue
+		}
+		if !a.AcceptFrom(user, offer.From, scope) {
+			continue
+		}
+		select {
+		case protoOffers <- iceOfferToProto(offer):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
 	for {
 		select {
 		case offer := <-offers:
@@ -598,10 +1033,16 @@ func (a *API) SubscribeICECandidate(
 			if offer.To != user.ID {
 				continue
 			}
-			protoOffers <- &protos.ICEOffer{
-				SenderID:  offer.From,
-				IsRemote:  offer.IsRemote,
-				Candidate: offer.Candidate,
+			if !acceptDevice(device, offer.ToDevice, "") {
+				continue
+			}
+			if !a.AcceptFrom(user, offer.From, scope) {
+				continue
+			}
+			select {
+			case protoOffers <- iceOfferToProto(offer):
+			case <-ctx.Done():
+				return nil
 			}
 		case <-ctx.Done():
 			return nil
@@ -611,40 +1052,64 @@ func (a *API) SubscribeICECandidate(
 
 // SubscribeOnlineStatus act as pull-on switch mechanism for user online state
 // when user call this function user status will change to online
-// status will pull back to offline after this function exit
+// status will pull back to offline after this function exit,
+// stream start with current status of users sharing room with user, then only
+// their status changes received, kept up to date by room events
 func (a *API) SubscribeOnlineStatus(
 	ctx context.Context,
 	heartbeat <-chan *protos.Heartbeat,
 	statusChanges <-chan *OnlineStatus,
+	roomEvents <-chan *room.RoomEvent,
 	protoStatusChanges chan<- *protos.OnlineStatus,
 ) error {
 	user, err := a.GetUserContext(ctx)
 	if err != nil {
 		return err
 	}
-	// set user status as online
+	scope := auth.ScopeFromContext(ctx)
+	members, err := a.GetCoMemberIDs(user, scope)
+	if err != nil {
+		return err
+	}
+	// count session on any instance, user online while it has a session
+	release, err := a.KeepPresenceSession(user.ID, auth.DeviceIDFromContext(ctx))
+	if err != nil {
+		return err
+	}
 	err = a.SetUserOnlineStatus(user.ID, true)
 	if err != nil {
+		release()
 		return err
 	}
-	// pull off online status after user check-out
-	defer func() error {
-		return a.SetUserOnlineStatus(user.ID, false)
+	// pull off online status after last session of user check-out
+	defer func() {
+		release()
+		a.LeavePresence(user.ID)
 	}()
 
-	// when heartbeat stop anything dead
-	dead := make(chan bool)
-	timeout := make(chan bool)
+	// when heartbeat stop anything dead, buffered so watcher never block after session ended
+	dead := make(chan bool, 1)
+	timeout := make(chan bool, 1)
 	ttl := time.Second * 5
 	go func() {
 		var timer *time.Timer
 		timer = time.AfterFunc(ttl, func() {
 			timeout <- true
 		})
+		defer timer.Stop()
 		for {
 			select {
-			case <-heartbeat:
+			case <-ctx.Done():
+				return
+			case beat := <-heartbeat:
 				timer.Reset(ttl)
+				// presence changed along with heartbeat
+				if beat != nil && beat.Presence != nil {
+					err := a.SetUserPresence(user.ID, beat.Presence)
+					if err != nil {
+						a.Logger.Error(err)
+					}
+				}
 			case <-timeout:
 				dead <- true
 				return
@@ -652,19 +1117,47 @@ func (a *API) SubscribeOnlineStatus(
 		}
 	}()
 
+	// snapshot current presence so client don't need to ask every user
+	snapshot, err := a.getOnlineStatuses(members)
+	if err != nil {
+		return err
+	}
+	for _, status := range snapshot {
+		select {
+		case protoStatusChanges <- onlineStatusToProto(status):
+		case <-ctx.Done():
+			return nil
+		case <-dead:
+			return nil
+		}
+	}
+
 	for {
 		select {
 		case status := <-statusChanges:
 			if status == nil {
 				continue
 			}
-			if status.ID == user.ID {
+			if !members[status.ID] {
 				continue
 			}
-			protoStatusChanges <- &protos.OnlineStatus{
-				Id:     status.ID,
-				Online: status.Online,
+			select {
+			case protoStatusChanges <- onlineStatusToProto(status):
+			case <-ctx.Done():
+				return nil
+			case <-dead:
+				return nil
+			}
+		case event := <-roomEvents:
+			if event == nil || !membershipChanged(user.ID, members, event) {
+				continue
 			}
+			changed, err := a.GetCoMemberIDs(user, scope)
+			if err != nil {
+				a.Logger.Error(err)
+				continue
+			}
+			members = changed
 		case <-ctx.Done():
 			return nil
 		case <-dead:
@@ -673,24 +1166,246 @@ func (a *API) SubscribeOnlineStatus(
 	}
 }
 
-// SetUserOnlineStatus will set user online status
+// SetUserOnlineStatus will set user online status,
+// last seen time recorded when user go offline
 func (a *API) SetUserOnlineStatus(
 	id string,
 	online bool,
 ) error {
 	a.Logger.Debugf("set user %s online status to %v", id, online)
-	status := &room.UserModel{}
-	err := a.DB.Model(&status).
+	updates := map[string]interface{}{"online": online}
+	if !online {
+		updates["last_seen_at"] = lastSeenUnlessInvisible(time.Now())
+	}
+	err := a.DB.Model(&room.UserModel{}).
 		Where(&room.UserModel{ID: id}).
-		Update("online", online).
+		Updates(updates).
+		Error
+	if err != nil {
+		return err
+	}
+	return a.publishOnlineStatus(id)
+}
+
+// SetPresence will set presence & status text of user
+func (a *API) SetPresence(ctx context.Context, param *protos.PresenceParam) error {
+	user, err := a.GetUserContext(ctx)
+	if err != nil {
+		return err
+	}
+	return a.SetUserPresence(user.ID, param)
+}
+
+// SetUserPresence will set presence & status text of user,
+// going invisible recorded as last seen like going offline
+func (a *API) SetUserPresence(id string, param *protos.PresenceParam) error {
+	presence := room.PresenceProtoToState[param.State]
+	if len(presence) == 0 || presence == room.PresenceOffline {
+		return status.Error(codes.InvalidArgument, InvalidPresenceError)
+	}
+	user := &room.UserModel{}
+	err := a.DB.Where(&room.UserModel{ID: id}).First(user).Error
+	if err != nil {
+		return err
+	}
+	a.Logger.Debugf("set user %s presence to %s", user.ID, presence)
+	updates := map[string]interface{}{
+		"presence":    presence,
+		"status_text": param.StatusText,
+	}
+	if presence == room.PresenceInvisible && user.VisiblePresence() != room.PresenceOffline {
+		updates["last_seen_at"] = time.Now()
+	}
+	err = a.DB.Model(&room.UserModel{}).
+		Where(&room.UserModel{ID: user.ID}).
+		Updates(updates).
 		Error
 	if err != nil {
 		return err
 	}
-	// publish status changes
-	a.Onlines <- &OnlineStatus{
-		ID:     id,
-		Online: online,
+	return a.publishOnlineStatus(user.ID)
+}
+
+// lastSeenUnlessInvisible return last seen time update of user going offline,
+// invisible user keep time it went invisible so it's hidden activity not revealed
+func lastSeenUnlessInvisible(now time.Time) interface{} {
+	return gorm.Expr("CASE WHEN presence = ? THEN last_seen_at ELSE ? END", room.PresenceInvisible, now)
+}
+
+// publishOnlineStatus will publish online status & presence of user seen by other users
+func (a *API) publishOnlineStatus(id string) error {
+	user := &room.UserModel{}
+	err := a.DB.Where(&room.UserModel{ID: id}).First(user).Error
+	if err != nil {
+		return err
+	}
+	a.Onlines <- userOnlineStatus(user)
+	return nil
+}
+
+// userOnlineStatus return online status of user seen by other users
+func userOnlineStatus(user *room.UserModel) *OnlineStatus {
+	presence := user.VisiblePresence()
+	return &OnlineStatus{
+		ID:         user.ID,
+		Online:     presence != room.PresenceOffline,
+		Presence:   presence,
+		StatusText: user.VisibleStatusText(),
+		LastSeenAt: user.LastSeenAt,
+	}
+}
+
+// onlineStatusToProto convert online status to protobuf
+func onlineStatusToProto(onlineStatus *OnlineStatus) *protos.OnlineStatus {
+	protoStatus := &protos.OnlineStatus{
+		Id:         onlineStatus.ID,
+		Online:     onlineStatus.Online,
+		Presence:   room.PresenceToProto[onlineStatus.Presence],
+		StatusText: onlineStatus.StatusText,
+	}
+	if onlineStatus.LastSeenAt != nil {
+		protoStatus.LastSeenAt, _ = ptypes.TimestampProto(*onlineStatus.LastSeenAt)
+	}
+	return protoStatus
+}
+
+// SendSignal will send app-level signal to a user or every other members of a room,
+// sender must share a room with target user or be a member of target room
+func (a *API) SendSignal(ctx context.Context, param *protos.SignalParam) error {
+	user, err := a.GetUserContext(ctx)
+	if err != nil {
+		return err
+	}
+	hasUser := len(param.UserID) > 0
+	hasRoom := len(param.RoomID) > 0
+	if len(param.Type) == 0 || hasUser == hasRoom {
+		return status.Error(codes.InvalidArgument, InvalidSignalError)
+	}
+	var data []byte
+	if param.Data != nil {
+		buf := &bytes.Buffer{}
+		err = (&jsonpb.Marshaler{}).Marshal(buf, param.Data)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		data = buf.Bytes()
+	}
+	if len(param.Payload)+len(data) > MaxSignalSize {
+		return status.Error(codes.InvalidArgument, SignalTooLargeError)
 	}
+	signal := &Signal{
+		From:    user.ID,
+		Type:    param.Type,
+		Payload: param.Payload,
+		Data:    data,
+		Time:    time.Now(),
+	}
+	if hasUser {
+		err = a.AuthorizePeer(ctx, user, param.UserID)
+		if err != nil {
+			return err
+		}
+		signal.To = param.UserID
+	} else {
+		recipients, err := a.GetRoomRecipients(ctx, user, param.RoomID)
+		if err != nil {
+			return err
+		}
+		signal.RoomID = param.RoomID
+		signal.Recipients = recipients
+	}
+	a.Signals <- signal
 	return nil
 }
+
+// GetRoomRecipients return other members of a room user allowed to signal
+func (a *API) GetRoomRecipients(
+	ctx context.Context,
+	me *room.UserModel,
+	roomID string,
+) ([]string, error) {
+	if !auth.ScopeFromContext(ctx).AllowRoom(roomID) {
+		return nil, status.Error(codes.PermissionDenied, room.RoomNotFoundError)
+	}
+	r := &room.RoomModel{}
+	err := a.DB.Preload("Members").
+		First(r, "id = ?", roomID).
+		Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.PermissionDenied, room.RoomNotFoundError)
+		}
+		return nil, err
+	}
+	member := false
+	recipients := []string{}
+	for _, m := range r.Members {
+		if m.ID == me.ID {
+			member = true
+			continue
+		}
+		recipients = append(recipients, m.ID)
+	}
+	if !member {
+		return nil, status.Error(codes.PermissionDenied, room.RoomNotFoundError)
+	}
+	return recipients, nil
+}
+
+// SubscribeSignal will return all app-level signals sent to this user
+func (a *API) SubscribeSignal(
+	ctx context.Context,
+	signals <-chan *Signal,
+	protoSignals chan<- *protos.Signal,
+) error {
+	user, err := a.GetUserContext(ctx)
+	if err != nil {
+		return err
+	}
+	scope := auth.ScopeFromContext(ctx)
+	for {
+		select {
+		case signal := <-signals:
+			if signal == nil {
+				continue
+			}
+			if signal.To != user.ID && !utils.ContainString(signal.Recipients, user.ID) {
+				continue
+			}
+			if len(signal.RoomID) > 0 {
+				if !scope.AllowRoom(signal.RoomID) {
+					continue
+				}
+			} else if !a.AcceptFrom(user, signal.From, scope) {
+				continue
+			}
+			protoSignal := &protos.Signal{
+				Type:     signal.Type,
+				Payload:  signal.Payload,
+				SenderID: signal.From,
+				RoomID:   signal.RoomID,
+			}
+			if len(signal.Data) > 0 {
+				data := &_struct.Struct{}
+				err := jsonpb.Unmarshal(bytes.NewReader(signal.Data), data)
+				if err != nil {
+					a.Logger.Error(err)
+					continue
+				}
+				protoSignal.Data = data
+			}
+			protoSignal.Time, err = ptypes.TimestampProto(signal.Time)
+			if err != nil {
+				a.Logger.Error(err)
+				continue
+			}
+			select {
+			case protoSignals <- protoSignal:
+			case <-ctx.Done():
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
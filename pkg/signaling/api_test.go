@@ -2,16 +2,28 @@ package signaling_test
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jinzhu/gorm"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/call"
 	"go.sirus.dev/p2p-comm/signalling/pkg/connector"
 	"go.sirus.dev/p2p-comm/signalling/pkg/room"
 	"go.sirus.dev/p2p-comm/signalling/pkg/signaling"
 	"go.sirus.dev/p2p-comm/signalling/protos"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"syreclabs.com/go/faker"
 )
 
@@ -24,12 +36,18 @@ var _ = Describe("API", func() {
 		roomEvents   chan *room.RoomEvent
 		ICEOffers    chan *signaling.ICEOffer
 		OnlineStatus chan *signaling.OnlineStatus
+		signals      chan *signaling.Signal
+		queue        *signaling.QueueConfig
 		api          signaling.API
 	)
 
 	BeforeEach(func() {
 		var err error
-		db, err = connector.ConnectToMemmory(room.Models)
+		models := []interface{}{}
+		models = append(models, room.Models...)
+		models = append(models, signaling.Models...)
+		models = append(models, call.Models...)
+		db, err = connector.ConnectToMemmory(models)
 		if err != nil {
 			Fail(err.Error())
 		}
@@ -44,6 +62,7 @@ var _ = Describe("API", func() {
 		SDPCommands = make(chan *signaling.SDPCommand)
 		ICEOffers = make(chan *signaling.ICEOffer)
 		OnlineStatus = make(chan *signaling.OnlineStatus)
+		signals = make(chan *signaling.Signal)
 		// add some public stun server
 		// and private turn server
 		ICEServers = &[]signaling.ICEServer{
@@ -67,7 +86,7 @@ var _ = Describe("API", func() {
 		api = signaling.API{
 			db, logger, ICEServers,
 			SDPCommands, roomEvents, ICEOffers,
-			OnlineStatus,
+			OnlineStatus, signals, queue, 0, "",
 		}
 	})
 
@@ -198,6 +217,21 @@ var _ = Describe("API", func() {
 		})
 	})
 
+	Describe("GetSignals", func() {
+		It("should return signals channel", func() {
+			e := api.GetSignals()
+			Expect(e).To(Equal(signals))
+		})
+	})
+
+	Describe("SetSignals", func() {
+		It("should set signals channel", func() {
+			e := make(chan *signaling.Signal)
+			api.SetSignals(e)
+			Expect(api.Signals).To(Equal(e))
+		})
+	})
+
 	Describe("GetUserContext", func() {
 		It("should return current user context", func() {
 			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
@@ -255,6 +289,67 @@ var _ = Describe("API", func() {
 				},
 			))
 		})
+
+		When("token not allowed to signal", func() {
+			It("should not return ICE servers on profile", func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				ctx = context.WithValue(ctx, auth.ScopeContextKey, &auth.Scope{
+					Capabilities: []string{auth.CapabilityPresence},
+				})
+				res, err := api.MyProfile(ctx)
+				Expect(err).To(BeNil())
+				Expect(res.Id).To(Equal(u1.ID))
+				Expect(res.Servers).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetICEServers", func() {
+		It("should generate time-limited TURN credential from shared secret", func() {
+			secret := faker.RandomString(20)
+			*ICEServers = append(*ICEServers, signaling.ICEServer{
+				URL:    "turn:turn.example.com:3478",
+				Secret: secret,
+				TTL:    time.Hour,
+				Realm:  "example.com",
+			})
+			now := time.Now()
+			servers := api.GetICEServers(u1, now)
+			Expect(servers).To(HaveLen(4))
+			turn := servers[3]
+			expiresAt := now.Add(time.Hour)
+			Expect(turn.CredentialType).To(Equal(protos.ICECredentialType_PASSWORD))
+			Expect(turn.Realm).To(Equal("example.com"))
+			Expect(turn.Username).To(Equal(fmt.Sprintf("%d:%s", expiresAt.Unix(), u1.ID)))
+			mac := hmac.New(sha1.New, []byte(secret))
+			mac.Write([]byte(turn.Username))
+			Expect(turn.Password).To(Equal(base64.StdEncoding.EncodeToString(mac.Sum(nil))))
+			Expect(turn.ExpiresAt.Seconds).To(Equal(expiresAt.Unix()))
+			// static credentials kept as is
+			Expect(servers[1].Username).To(Equal((*ICEServers)[1].Username))
+			Expect(servers[1].ExpiresAt).To(BeNil())
+		})
+
+		It("should return fresh credential for each user", func() {
+			*ICEServers = []signaling.ICEServer{
+				{URL: "turn:turn.example.com:3478", Secret: faker.RandomString(20)},
+			}
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			res, err := api.MyICEServers(ctx)
+			Expect(err).To(BeNil())
+			Expect(res.Servers).To(HaveLen(1))
+			Expect(res.Servers[0].Username).To(HaveSuffix(":" + u1.ID))
+			other := api.GetICEServers(u2, time.Now())
+			Expect(other[0].Password).NotTo(Equal(res.Servers[0].Password))
+			Expect(res.Servers[0].ExpiresAt.Seconds).To(BeNumerically("~",
+				time.Now().Add(signaling.DefaultTURNCredentialTTL).Unix(), 2))
+		})
+
+		It("should not return ICE servers without user context", func() {
+			res, err := api.MyICEServers(context.Background())
+			Expect(res).To(BeNil())
+			Expect(err.Error()).To(Equal(signaling.ContextInvalidError))
+		})
 	})
 
 	Describe("UpdateProfile", func() {
@@ -382,6 +477,20 @@ var _ = Describe("API", func() {
 				Expect(res.Rooms).To(HaveLen(0))
 			})
 		})
+
+		When("token restricted to some rooms", func() {
+			It("should only return allowed rooms", func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				ctx = context.WithValue(ctx, auth.ScopeContextKey, &auth.Scope{
+					RoomIDs: []string{r2.ID},
+				})
+				res, err := api.MyRooms(ctx)
+				Expect(err).To(BeNil())
+				Expect(res.Count).To(Equal(uint64(1)))
+				Expect(res.Rooms).To(HaveLen(1))
+				Expect(res.Rooms[0].Id).To(Equal(r2.ID))
+			})
+		})
 	})
 
 	Describe("MyRoomInfo", func() {
@@ -423,11 +532,25 @@ var _ = Describe("API", func() {
 				Expect(err.Error()).To(Equal(room.RoomNotFoundError))
 			})
 		})
+
+		When("room outside token scope", func() {
+			It("should return room not found error", func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				ctx = context.WithValue(ctx, auth.ScopeContextKey, &auth.Scope{
+					RoomIDs: []string{r2.ID},
+				})
+				res, err := api.MyRoomInfo(ctx, &protos.GetRoomParam{
+					Id: r1.ID,
+				})
+				Expect(res).To(BeNil())
+				Expect(err.Error()).To(Equal(room.RoomNotFoundError))
+			})
+		})
 	})
 
 	Describe("GetUser", func() {
 		It("should return user by it's id", func() {
-			ctx := context.Background()
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
 			res, err := api.GetUser(ctx, &protos.GetUserParam{
 				Id: u1.ID,
 			})
@@ -438,9 +561,55 @@ var _ = Describe("API", func() {
 			Expect(res.Online).To(Equal(u1.Online))
 		})
 
+		It("should return presence of user sharing room", func() {
+			now := time.Now()
+			db.Model(u2).Updates(map[string]interface{}{
+				"online":       true,
+				"presence":     room.PresenceBusy,
+				"status_text":  "meeting",
+				"last_seen_at": now,
+			})
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			res, err := api.GetUser(ctx, &protos.GetUserParam{Id: u2.ID})
+			Expect(err).To(BeNil())
+			Expect(res.Online).To(BeTrue())
+			Expect(res.Presence).To(Equal(protos.PresenceState_Busy))
+			Expect(res.StatusText).To(Equal("meeting"))
+			Expect(res.LastSeenAt).NotTo(BeNil())
+		})
+
+		It("should hide presence of user not sharing any room", func() {
+			now := time.Now()
+			db.Model(u4).Updates(map[string]interface{}{
+				"online":       true,
+				"presence":     room.PresenceBusy,
+				"status_text":  "meeting",
+				"last_seen_at": now,
+			})
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			res, err := api.GetUser(ctx, &protos.GetUserParam{Id: u4.ID})
+			Expect(err).To(BeNil())
+			Expect(res.Id).To(Equal(u4.ID))
+			Expect(res.Name).To(Equal(u4.Name))
+			Expect(res.Online).To(BeFalse())
+			Expect(res.Presence).To(Equal(protos.PresenceState_Offline))
+			Expect(res.StatusText).To(BeEmpty())
+			Expect(res.LastSeenAt).To(BeNil())
+		})
+
+		When("context has no user", func() {
+			It("should return invalid context error", func() {
+				res, err := api.GetUser(context.Background(), &protos.GetUserParam{
+					Id: u1.ID,
+				})
+				Expect(res).To(BeNil())
+				Expect(err.Error()).To(Equal(signaling.ContextInvalidError))
+			})
+		})
+
 		When("user not exist", func() {
 			It("should return user not found error", func() {
-				ctx := context.Background()
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
 				res, err := api.GetUser(ctx, &protos.GetUserParam{
 					Id: "non-exist-id",
 				})
@@ -458,7 +627,7 @@ var _ = Describe("API", func() {
 				UserID:      u2.ID,
 			}
 			go func() {
-				err := api.OfferSDP(ctx, param)
+				_, err := api.OfferSDP(ctx, param)
 				Expect(err).To(BeNil())
 			}()
 			command := <-SDPCommands
@@ -468,6 +637,211 @@ var _ = Describe("API", func() {
 			Expect(command.Description).To(Equal(param.Description))
 			close(done)
 		}, 0.3)
+
+		When("target user not share any room", func() {
+			It("should return permission denied error", func(done Done) {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				param := &protos.SDPParam{
+					Description: faker.Lorem().Paragraph(3),
+					UserID:      u4.ID,
+				}
+				_, err := api.OfferSDP(ctx, param)
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Consistently(SDPCommands).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
+		})
+
+		When("target user only share room outside token scope", func() {
+			It("should return permission denied error", func(done Done) {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				ctx = context.WithValue(ctx, auth.ScopeContextKey, &auth.Scope{
+					RoomIDs: []string{r2.ID},
+				})
+				param := &protos.SDPParam{
+					Description: faker.Lorem().Paragraph(3),
+					UserID:      u2.ID,
+				}
+				_, err := api.OfferSDP(ctx, param)
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Consistently(SDPCommands).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
+		})
+	})
+
+	Describe("OfferSDP with call", func() {
+		It("should publish SDP with call id of open call", func(done Done) {
+			db.Create(&call.CallModel{ID: "c1", CallerID: u1.ID, CalleeID: u2.ID, State: call.CallActive})
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			go func() {
+				_, err := api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID, CallID: "c1"})
+				Expect(err).To(BeNil())
+			}()
+			command := <-SDPCommands
+			Expect(command.CallID).To(Equal("c1"))
+			close(done)
+		}, 0.3)
+
+		When("call not exist or target not participate in it", func() {
+			It("should return not found error", func() {
+				db.Create(&call.CallModel{ID: "c1", CallerID: u1.ID, CalleeID: u3.ID, State: call.CallActive})
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				_, err := api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID, CallID: "c1"})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+				_, err = api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID, CallID: "c2"})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+
+		When("call already ended", func() {
+			It("should return failed precondition error", func() {
+				db.Create(&call.CallModel{ID: "c1", CallerID: u1.ID, CalleeID: u2.ID, State: call.CallEnded})
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				_, err := api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID, CallID: "c1"})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			})
+		})
+	})
+
+	Describe("Glare", func() {
+		var ctx1, ctx2 context.Context
+
+		JustBeforeEach(func() {
+			ctx1 = context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			ctx2 = context.WithValue(context.Background(), room.UserIDKey, u2.ID)
+		})
+
+		When("polite peer offer after impolite peer", func() {
+			It("should drop polite offer and tell it to roll back", func(done Done) {
+				go api.OfferSDP(ctx1, &protos.SDPParam{UserID: u2.ID})
+				<-SDPCommands
+				go func() {
+					delivery, err := api.OfferSDP(ctx2, &protos.SDPParam{UserID: u1.ID})
+					Expect(err).To(BeNil())
+					Expect(delivery.Status).To(Equal(protos.DeliveryStatus_Collided))
+				}()
+				command := <-SDPCommands
+				Expect(command.Type).To(Equal(signaling.SDPRollback))
+				Expect(command.To).To(Equal(u2.ID))
+				Expect(command.From).To(Equal(u1.ID))
+				Expect(command.Collision).To(BeTrue())
+				Consistently(SDPCommands).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
+		})
+
+		When("impolite peer offer after polite peer", func() {
+			It("should tell polite peer to roll back and relay impolite offer", func(done Done) {
+				go api.OfferSDP(ctx2, &protos.SDPParam{UserID: u1.ID})
+				<-SDPCommands
+				go api.OfferSDP(ctx1, &protos.SDPParam{UserID: u2.ID})
+				rollback := <-SDPCommands
+				Expect(rollback.Type).To(Equal(signaling.SDPRollback))
+				Expect(rollback.To).To(Equal(u2.ID))
+				Expect(rollback.Collision).To(BeTrue())
+				offer := <-SDPCommands
+				Expect(offer.Type).To(Equal(signaling.SDPOffer))
+				Expect(offer.To).To(Equal(u2.ID))
+				close(done)
+			}, 0.3)
+		})
+
+		When("offer already answered", func() {
+			It("should not detect collision", func(done Done) {
+				go api.OfferSDP(ctx1, &protos.SDPParam{UserID: u2.ID})
+				<-SDPCommands
+				go api.AnswerSDP(ctx2, &protos.SDPParam{UserID: u1.ID})
+				<-SDPCommands
+				go api.OfferSDP(ctx2, &protos.SDPParam{UserID: u1.ID})
+				offer := <-SDPCommands
+				Expect(offer.Type).To(Equal(signaling.SDPOffer))
+				Expect(offer.From).To(Equal(u2.ID))
+				close(done)
+			}, 0.3)
+		})
+
+		When("offers belong to different calls", func() {
+			It("should not detect collision", func(done Done) {
+				db.Create(&call.CallModel{ID: "c1", CallerID: u1.ID, CalleeID: u2.ID, State: call.CallActive})
+				go api.OfferSDP(ctx1, &protos.SDPParam{UserID: u2.ID, CallID: "c1"})
+				<-SDPCommands
+				go api.OfferSDP(ctx2, &protos.SDPParam{UserID: u1.ID})
+				offer := <-SDPCommands
+				Expect(offer.Type).To(Equal(signaling.SDPOffer))
+				Expect(offer.From).To(Equal(u2.ID))
+				close(done)
+			}, 0.3)
+		})
+
+		When("offers belong to different connections", func() {
+			It("should not detect collision", func(done Done) {
+				go api.OfferSDP(ctx1, &protos.SDPParam{UserID: u2.ID, ConnectionID: "camera"})
+				<-SDPCommands
+				go api.OfferSDP(ctx2, &protos.SDPParam{UserID: u1.ID, ConnectionID: "screen"})
+				offer := <-SDPCommands
+				Expect(offer.Type).To(Equal(signaling.SDPOffer))
+				Expect(offer.ConnectionID).To(Equal("screen"))
+				close(done)
+			}, 0.3)
+
+			It("should refuse invalid connection id", func() {
+				_, err := api.OfferSDP(ctx1, &protos.SDPParam{UserID: u2.ID, ConnectionID: "screen/1"})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				_, err = api.OfferSDP(ctx1, &protos.SDPParam{
+					UserID:       u2.ID,
+					ConnectionID: strings.Repeat("a", signaling.MaxConnectionIDLength+1),
+				})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				_, err = api.SendICECandidate(ctx1, &protos.ICEParam{
+					UserID:       u2.ID,
+					Candidate:    "candidate",
+					ConnectionID: "screen 1",
+				})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				Expect(SDPCommands).NotTo(Receive())
+				Expect(ICEOffers).NotTo(Receive())
+			})
+
+			It("should roll back offer on the same connection", func(done Done) {
+				go api.OfferSDP(ctx1, &protos.SDPParam{UserID: u2.ID, ConnectionID: "screen"})
+				<-SDPCommands
+				go api.OfferSDP(ctx2, &protos.SDPParam{UserID: u1.ID, ConnectionID: "screen"})
+				rollback := <-SDPCommands
+				Expect(rollback.Type).To(Equal(signaling.SDPRollback))
+				Expect(rollback.ConnectionID).To(Equal("screen"))
+				close(done)
+			}, 0.3)
+		})
+
+		Describe("PruneExpiredOffers", func() {
+			It("should remove offers past offer timeout", func(done Done) {
+				go api.OfferSDP(ctx1, &protos.SDPParam{UserID: u2.ID})
+				<-SDPCommands
+				removed, err := api.PruneExpiredOffers(time.Now())
+				Expect(err).To(BeNil())
+				Expect(removed).To(BeZero())
+				removed, err = api.PruneExpiredOffers(time.Now().Add(signaling.DefaultOfferTimeout))
+				Expect(err).To(BeNil())
+				Expect(removed).To(BeEquivalentTo(1))
+				count := 0
+				db.Model(&signaling.OutstandingOfferModel{}).Count(&count)
+				Expect(count).To(BeZero())
+				close(done)
+			}, 0.3)
+		})
+
+		It("should tell receiver it's role", func(done Done) {
+			in := make(chan *signaling.SDPCommand)
+			out := make(chan *protos.SDP)
+			go api.SubscribeSDPCommand(ctx2, in, out)
+			go func() {
+				in <- &signaling.SDPCommand{Type: signaling.SDPOffer, From: u1.ID, To: u2.ID}
+			}()
+			sdp := <-out
+			Expect(sdp.Polite).To(BeTrue())
+			close(done)
+		}, 0.3)
 	})
 
 	Describe("AnswerSDP", func() {
@@ -478,7 +852,7 @@ var _ = Describe("API", func() {
 				UserID:      u1.ID,
 			}
 			go func() {
-				err := api.AnswerSDP(ctx, param)
+				_, err := api.AnswerSDP(ctx, param)
 				Expect(err).To(BeNil())
 			}()
 			command := <-SDPCommands
@@ -488,6 +862,82 @@ var _ = Describe("API", func() {
 			Expect(command.Description).To(Equal(param.Description))
 			close(done)
 		}, 0.3)
+
+		When("target user not share any room", func() {
+			It("should return permission denied error", func(done Done) {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u7.ID)
+				param := &protos.SDPParam{
+					Description: faker.Lorem().Paragraph(3),
+					UserID:      u1.ID,
+				}
+				_, err := api.AnswerSDP(ctx, param)
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Consistently(SDPCommands).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
+		})
+	})
+
+	Describe("SendSDP", func() {
+		It("should publish SDP provisional answer from user", func(done Done) {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u2.ID)
+			param := &protos.SDPParam{
+				Description: faker.Lorem().Paragraph(3),
+				UserID:      u1.ID,
+				Type:        protos.SDPTypes_Pranswer,
+			}
+			go func() {
+				_, err := api.SendSDP(ctx, param)
+				Expect(err).To(BeNil())
+			}()
+			command := <-SDPCommands
+			Expect(command.Type).To(Equal(signaling.SDPPranswer))
+			Expect(command.From).To(Equal(u2.ID))
+			Expect(command.To).To(Equal(u1.ID))
+			Expect(command.Description).To(Equal(param.Description))
+			close(done)
+		}, 0.3)
+
+		It("should publish SDP rollback from user", func(done Done) {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			param := &protos.SDPParam{
+				UserID: u2.ID,
+				Type:   protos.SDPTypes_Rollback,
+			}
+			go func() {
+				_, err := api.SendSDP(ctx, param)
+				Expect(err).To(BeNil())
+			}()
+			command := <-SDPCommands
+			Expect(command.Type).To(Equal(signaling.SDPRollback))
+			Expect(command.From).To(Equal(u1.ID))
+			Expect(command.To).To(Equal(u2.ID))
+			close(done)
+		}, 0.3)
+
+		When("SDP type unknown", func() {
+			It("should return invalid argument error", func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				_, err := api.SendSDP(ctx, &protos.SDPParam{
+					UserID: u2.ID,
+					Type:   protos.SDPTypes(10),
+				})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("target user not share any room", func() {
+			It("should return permission denied error", func(done Done) {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				_, err := api.SendSDP(ctx, &protos.SDPParam{
+					UserID: u4.ID,
+					Type:   protos.SDPTypes_Rollback,
+				})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Consistently(SDPCommands).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
+		})
 	})
 
 	Describe("SubscribeSDPCommand", func() {
@@ -516,6 +966,30 @@ var _ = Describe("API", func() {
 			}, 0.3)
 		})
 
+		When("sender only share room outside token scope", func() {
+			It("should not send SDP event to target user", func(done Done) {
+				commands := make(chan *signaling.SDPCommand)
+				sdps := make(chan *protos.SDP)
+				go func() {
+					ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+					ctx = context.WithValue(ctx, auth.ScopeContextKey, &auth.Scope{
+						RoomIDs: []string{r2.ID},
+					})
+					api.SubscribeSDPCommand(ctx, commands, sdps)
+				}()
+				go func() {
+					commands <- &signaling.SDPCommand{
+						Type:        signaling.SDPOffer,
+						From:        u2.ID,
+						To:          u1.ID,
+						Description: faker.Lorem().Paragraph(5),
+					}
+				}()
+				Consistently(sdps).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
+		})
+
 		When("a peer send SDP answer command", func() {
 			It("should send SDP answer event to target user", func(done Done) {
 				commands := make(chan *signaling.SDPCommand)
@@ -590,77 +1064,455 @@ var _ = Describe("API", func() {
 		})
 	})
 
-	Describe("IsItMyRooms", func() {
-		When("one of my rooms is on room id list", func() {
-			It("should return true", func() {
-				exist, err := api.IsItMyRooms(u1, []string{
-					r4.ID, r1.ID, r3.ID,
-				})
-				Expect(err).To(BeNil())
-				Expect(*exist).To(BeTrue())
-			})
+	Describe("Delivery", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			api.Queue = &signaling.QueueConfig{TTL: time.Minute, Size: 2}
 		})
 
-		When("any of my rooms are not on room id list", func() {
-			It("should return false", func() {
-				exist, err := api.IsItMyRooms(u1, []string{
-					r3.ID, r4.ID,
-				})
-				Expect(err).To(BeNil())
-				Expect(*exist).To(BeFalse())
-			})
+		JustBeforeEach(func() {
+			ctx = context.WithValue(context.Background(), room.UserIDKey, u1.ID)
 		})
-	})
 
-	Describe("SubscribeRoomEvent", func() {
-		When("user joined my room", func() {
-			It("should receive user joined room event", func(done Done) {
-				events := make(chan *room.RoomEvent)
-				myRoomEvents := make(chan *protos.RoomEvent)
-				eventPayload := &room.RoomParticipantEventPayload{
-					RoomID: r1.ID,
-					UserID: u4.ID,
-					ParticipantIDs: []string{
-						u1.ID, u2.ID,
-					},
-				}
-				event := &room.RoomEvent{
-					Event:   room.UserJoinedRoom,
-					Payload: eventPayload,
-				}
-				go func() {
-					ctx := context.WithValue(
-						context.Background(), room.UserIDKey, u1.ID)
-					api.SubscribeRoomEvent(ctx, events, myRoomEvents)
-				}()
+		When("target subscribed", func() {
+			It("should publish SDP and report live delivery", func(done Done) {
+				release, err := api.KeepSubscribed(u2.ID, "", signaling.DeliveryKindSDP)
+				Expect(err).To(BeNil())
+				defer release()
 				go func() {
-					events <- event
+					delivery, err := api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID})
+					Expect(err).To(BeNil())
+					Expect(delivery.Status).To(Equal(protos.DeliveryStatus_Live))
 				}()
-				e := <-myRoomEvents
-				Expect(e.Event).To(Equal(protos.RoomEvents_UserJoinedRoom))
-				payload := e.Payload.(*protos.RoomEvent_RoomParticipant)
-				Expect(payload.RoomParticipant.ParticipantID).
-					To(Equal(eventPayload.UserID))
-				Expect(payload.RoomParticipant.RoomID).
-					To(Equal(eventPayload.RoomID))
+				command := <-SDPCommands
+				Expect(command.To).To(Equal(u2.ID))
 				close(done)
 			}, 0.3)
 		})
 
-		When("user joined to other room", func() {
-			It("should not receive user joined room event", func(done Done) {
-				events := make(chan *room.RoomEvent)
-				myRoomEvents := make(chan *protos.RoomEvent)
-				eventPayload := &room.RoomParticipantEventPayload{
-					RoomID: r3.ID,
-					UserID: u7.ID,
-					ParticipantIDs: []string{
-						u2.ID, u3.ID, u4.ID, u5.ID, u6.ID,
-					},
-				}
-				event := &room.RoomEvent{
-					Event:   room.UserJoinedRoom,
-					Payload: eventPayload,
+		When("target not subscribed", func() {
+			It("should queue SDP instead of publish it", func(done Done) {
+				delivery, err := api.OfferSDP(ctx, &protos.SDPParam{
+					UserID:      u2.ID,
+					Description: "sdp",
+				})
+				Expect(err).To(BeNil())
+				Expect(delivery.Status).To(Equal(protos.DeliveryStatus_Queued))
+				Consistently(SDPCommands).ShouldNot(Receive())
+				pending, err := api.DrainPending(u2.ID, "", signaling.DeliveryKindSDP)
+				Expect(err).To(BeNil())
+				Expect(pending).To(HaveLen(1))
+				close(done)
+			}, 0.3)
+
+			It("should report expired when queue full", func() {
+				for i := 0; i < 2; i++ {
+					_, err := api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, Candidate: "candidate"})
+					Expect(err).To(BeNil())
+				}
+				delivery, err := api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID})
+				Expect(err).To(BeNil())
+				Expect(delivery.Status).To(Equal(protos.DeliveryStatus_Expired))
+			})
+
+			It("should keep queue of other senders when one sender queue full", func() {
+				for i := 0; i < 2; i++ {
+					_, err := api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, Candidate: "candidate"})
+					Expect(err).To(BeNil())
+				}
+				otherCtx := context.WithValue(context.Background(), room.UserIDKey, u3.ID)
+				delivery, err := api.SendICECandidate(otherCtx, &protos.ICEParam{UserID: u2.ID, Candidate: "candidate"})
+				Expect(err).To(BeNil())
+				Expect(delivery.Status).To(Equal(protos.DeliveryStatus_Queued))
+				pending, err := api.DrainPending(u2.ID, "", signaling.DeliveryKindICE)
+				Expect(err).To(BeNil())
+				Expect(pending).To(HaveLen(3))
+			})
+
+			It("should not drain expired messages", func() {
+				api.Queue.TTL = time.Millisecond
+				delivery, err := api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID})
+				Expect(err).To(BeNil())
+				Expect(delivery.Status).To(Equal(protos.DeliveryStatus_Queued))
+				time.Sleep(time.Millisecond * 5)
+				pending, err := api.DrainPending(u2.ID, "", signaling.DeliveryKindSDP)
+				Expect(err).To(BeNil())
+				Expect(pending).To(BeEmpty())
+			})
+
+			It("should prune expired messages & their receipts", func() {
+				_, err := api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID})
+				Expect(err).To(BeNil())
+				_, err = api.SendICECandidate(ctx, &protos.ICEParam{UserID: u3.ID, Candidate: "candidate"})
+				Expect(err).To(BeNil())
+				pending, err := api.DrainPending(u2.ID, "d1", signaling.DeliveryKindSDP)
+				Expect(err).To(BeNil())
+				Expect(pending).To(HaveLen(1))
+				count := 0
+				db.Model(&signaling.PendingReceiptModel{}).Count(&count)
+				Expect(count).To(Equal(1))
+
+				removed, err := api.PruneExpiredPending(time.Now())
+				Expect(err).To(BeNil())
+				Expect(removed).To(BeZero())
+				removed, err = api.PruneExpiredPending(time.Now().Add(time.Minute))
+				Expect(err).To(BeNil())
+				Expect(removed).To(BeEquivalentTo(2))
+				db.Model(&signaling.PendingMessageModel{}).Count(&count)
+				Expect(count).To(BeZero())
+				db.Model(&signaling.PendingReceiptModel{}).Count(&count)
+				Expect(count).To(BeZero())
+			})
+		})
+
+		When("queue disabled", func() {
+			It("should publish and report unconfirmed delivery", func(done Done) {
+				api.Queue = nil
+				go func() {
+					delivery, err := api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID})
+					Expect(err).To(BeNil())
+					Expect(delivery.Status).To(Equal(protos.DeliveryStatus_Unconfirmed))
+				}()
+				<-SDPCommands
+				close(done)
+			}, 0.3)
+		})
+
+		When("target subscribe after SDP queued", func() {
+			It("should receive queued SDP", func(done Done) {
+				_, err := api.OfferSDP(ctx, &protos.SDPParam{
+					UserID:      u2.ID,
+					Description: "queued",
+				})
+				Expect(err).To(BeNil())
+				protoEvents := make(chan *protos.SDP)
+				go func() {
+					ctx := context.WithValue(context.Background(), room.UserIDKey, u2.ID)
+					api.SubscribeSDPCommand(ctx, make(chan *signaling.SDPCommand), protoEvents)
+				}()
+				sdp := <-protoEvents
+				Expect(sdp.SenderID).To(Equal(u1.ID))
+				Expect(sdp.Type).To(Equal(protos.SDPTypes_Offer))
+				Expect(sdp.Description).To(Equal("queued"))
+				close(done)
+			}, 0.3)
+		})
+
+		When("target subscribe after ICE candidate queued", func() {
+			It("should receive queued ICE candidate", func(done Done) {
+				_, err := api.SendICECandidate(ctx, &protos.ICEParam{
+					UserID:    u2.ID,
+					Candidate: "candidate",
+				})
+				Expect(err).To(BeNil())
+				protoOffers := make(chan *protos.ICEOffer)
+				go func() {
+					ctx := context.WithValue(context.Background(), room.UserIDKey, u2.ID)
+					api.SubscribeICECandidate(ctx, make(chan *signaling.ICEOffer), protoOffers)
+				}()
+				offer := <-protoOffers
+				Expect(offer.SenderID).To(Equal(u1.ID))
+				Expect(offer.Candidate).To(Equal("candidate"))
+				close(done)
+			}, 0.3)
+		})
+	})
+
+	Describe("Devices", func() {
+		deviceContext := func(userID string, deviceID string) context.Context {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, userID)
+			return context.WithValue(ctx, auth.DeviceIDKey, deviceID)
+		}
+
+		It("should send first offer to all devices of target user", func(done Done) {
+			go api.OfferSDP(deviceContext(u1.ID, "laptop"), &protos.SDPParam{UserID: u2.ID})
+			command := <-SDPCommands
+			Expect(command.FromDevice).To(Equal("laptop"))
+			Expect(command.ToDevice).To(BeEmpty())
+			close(done)
+		}, 0.3)
+
+		It("should send answer to device sending the offer", func(done Done) {
+			go api.OfferSDP(deviceContext(u1.ID, "laptop"), &protos.SDPParam{UserID: u2.ID})
+			<-SDPCommands
+			release, err := api.KeepSubscribed(u1.ID, "laptop", signaling.DeliveryKindSDP)
+			Expect(err).To(BeNil())
+			defer release()
+			go api.AnswerSDP(context.WithValue(context.Background(), room.UserIDKey, u2.ID), &protos.SDPParam{UserID: u1.ID})
+			command := <-SDPCommands
+			Expect(command.Type).To(Equal(signaling.SDPAnswer))
+			Expect(command.ToDevice).To(Equal("laptop"))
+			close(done)
+		}, 0.3)
+
+		When("device answer the offer", func() {
+			It("should tell other devices it answered elsewhere", func(done Done) {
+				go api.AnswerSDP(deviceContext(u2.ID, "phone"), &protos.SDPParam{UserID: u1.ID})
+				command := <-SDPCommands
+				Expect(command.Type).To(Equal(signaling.SDPAnsweredElsewhere))
+				Expect(command.From).To(Equal(u1.ID))
+				Expect(command.To).To(Equal(u2.ID))
+				Expect(command.ExceptDevice).To(Equal("phone"))
+				command = <-SDPCommands
+				Expect(command.Type).To(Equal(signaling.SDPAnswer))
+				Expect(command.FromDevice).To(Equal("phone"))
+				close(done)
+			}, 0.3)
+
+			It("should pin rest of negotiation to answering device", func(done Done) {
+				go api.AnswerSDP(deviceContext(u2.ID, "phone"), &protos.SDPParam{UserID: u1.ID})
+				<-SDPCommands
+				<-SDPCommands
+				releaseSDP, err := api.KeepSubscribed(u2.ID, "phone", signaling.DeliveryKindSDP)
+				Expect(err).To(BeNil())
+				defer releaseSDP()
+				releaseICE, err := api.KeepSubscribed(u2.ID, "phone", signaling.DeliveryKindICE)
+				Expect(err).To(BeNil())
+				defer releaseICE()
+				ctx := deviceContext(u1.ID, "laptop")
+				go api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID})
+				command := <-SDPCommands
+				Expect(command.ToDevice).To(Equal("phone"))
+				go api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, Candidate: "candidate"})
+				offer := <-ICEOffers
+				Expect(offer.ToDevice).To(Equal("phone"))
+				Expect(offer.FromDevice).To(Equal("laptop"))
+				close(done)
+			}, 0.3)
+
+			It("should send offer of other call to all devices", func(done Done) {
+				db.Create(&call.CallModel{ID: "c1", CallerID: u1.ID, CalleeID: u2.ID, State: call.CallActive})
+				db.Create(&call.CallModel{ID: "c2", CallerID: u1.ID, CalleeID: u2.ID, State: call.CallRinging, RingingUntil: time.Now().Add(time.Minute)})
+				go api.AnswerSDP(deviceContext(u2.ID, "phone"), &protos.SDPParam{UserID: u1.ID, CallID: "c1"})
+				<-SDPCommands
+				<-SDPCommands
+				releaseSDP, err := api.KeepSubscribed(u2.ID, "phone", signaling.DeliveryKindSDP)
+				Expect(err).To(BeNil())
+				defer releaseSDP()
+				releaseICE, err := api.KeepSubscribed(u2.ID, "phone", signaling.DeliveryKindICE)
+				Expect(err).To(BeNil())
+				defer releaseICE()
+				ctx := deviceContext(u1.ID, "laptop")
+				go api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, Candidate: "candidate", CallID: "c1"})
+				offer := <-ICEOffers
+				Expect(offer.ToDevice).To(Equal("phone"))
+				Expect(offer.CallID).To(Equal("c1"))
+				go api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID, CallID: "c2"})
+				command := <-SDPCommands
+				Expect(command.CallID).To(Equal("c2"))
+				Expect(command.ToDevice).To(BeEmpty())
+				close(done)
+			}, 0.3)
+
+			It("should send to all devices after offer rolled back", func(done Done) {
+				go api.OfferSDP(deviceContext(u1.ID, "laptop"), &protos.SDPParam{UserID: u2.ID})
+				<-SDPCommands
+				release, err := api.KeepSubscribed(u1.ID, "laptop", signaling.DeliveryKindSDP)
+				Expect(err).To(BeNil())
+				defer release()
+				go api.SendSDP(deviceContext(u1.ID, "laptop"), &protos.SDPParam{UserID: u2.ID, Type: protos.SDPTypes_Rollback})
+				<-SDPCommands
+				go api.OfferSDP(deviceContext(u2.ID, "phone"), &protos.SDPParam{UserID: u1.ID})
+				command := <-SDPCommands
+				Expect(command.Type).To(Equal(signaling.SDPOffer))
+				Expect(command.ToDevice).To(BeEmpty())
+				close(done)
+			}, 0.3)
+
+			It("should send to all devices when pinned device not subscribed", func(done Done) {
+				go api.AnswerSDP(deviceContext(u2.ID, "phone"), &protos.SDPParam{UserID: u1.ID})
+				<-SDPCommands
+				<-SDPCommands
+				go api.OfferSDP(deviceContext(u1.ID, "laptop"), &protos.SDPParam{UserID: u2.ID})
+				command := <-SDPCommands
+				Expect(command.ToDevice).To(BeEmpty())
+				close(done)
+			}, 0.3)
+		})
+
+		When("sender target a device", func() {
+			It("should send to that device", func(done Done) {
+				go api.SendICECandidate(deviceContext(u1.ID, "laptop"), &protos.ICEParam{
+					UserID:    u2.ID,
+					Candidate: "candidate",
+					DeviceID:  "tablet",
+				})
+				offer := <-ICEOffers
+				Expect(offer.ToDevice).To(Equal("tablet"))
+				close(done)
+			}, 0.3)
+		})
+
+		When("peer send answered elsewhere", func() {
+			It("should return invalid argument error", func() {
+				_, err := api.SendSDP(deviceContext(u1.ID, "laptop"), &protos.SDPParam{
+					UserID: u2.ID,
+					Type:   protos.SDPTypes_AnsweredElsewhere,
+				})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		It("should only subscribe SDP routed to device", func(done Done) {
+			commands := make(chan *signaling.SDPCommand)
+			sdps := make(chan *protos.SDP)
+			go api.SubscribeSDPCommand(deviceContext(u2.ID, "phone"), commands, sdps)
+			go func() {
+				commands <- &signaling.SDPCommand{Type: signaling.SDPOffer, From: u1.ID, To: u2.ID, ToDevice: "tablet"}
+				commands <- &signaling.SDPCommand{Type: signaling.SDPAnsweredElsewhere, From: u1.ID, To: u2.ID, ExceptDevice: "phone"}
+				commands <- &signaling.SDPCommand{Type: signaling.SDPAnswer, From: u1.ID, To: u2.ID, ToDevice: "phone", FromDevice: "laptop"}
+				commands <- &signaling.SDPCommand{Type: signaling.SDPAnsweredElsewhere, From: u1.ID, To: u2.ID, ExceptDevice: "tablet"}
+			}()
+			sdp := <-sdps
+			Expect(sdp.Type).To(Equal(protos.SDPTypes_Answer))
+			Expect(sdp.SenderDeviceID).To(Equal("laptop"))
+			sdp = <-sdps
+			Expect(sdp.Type).To(Equal(protos.SDPTypes_AnsweredElsewhere))
+			close(done)
+		}, 0.3)
+
+		It("should only subscribe ICE candidates routed to device", func(done Done) {
+			offers := make(chan *signaling.ICEOffer)
+			protoOffers := make(chan *protos.ICEOffer)
+			go api.SubscribeICECandidate(deviceContext(u2.ID, "phone"), offers, protoOffers)
+			go func() {
+				offers <- &signaling.ICEOffer{From: u1.ID, To: u2.ID, Candidate: "tablet", ToDevice: "tablet"}
+				offers <- &signaling.ICEOffer{From: u1.ID, To: u2.ID, Candidate: "phone", ToDevice: "phone"}
+			}()
+			offer := <-protoOffers
+			Expect(offer.Candidate).To(Equal("phone"))
+			close(done)
+		}, 0.3)
+
+		It("should only drain messages queued for device", func() {
+			api.Queue = &signaling.QueueConfig{TTL: time.Minute, Size: 10}
+			ctx := deviceContext(u1.ID, "laptop")
+			_, err := api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, Candidate: "tablet", DeviceID: "tablet"})
+			Expect(err).To(BeNil())
+			_, err = api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, Candidate: "any"})
+			Expect(err).To(BeNil())
+			pending, err := api.DrainPending(u2.ID, "phone", signaling.DeliveryKindICE)
+			Expect(err).To(BeNil())
+			Expect(pending).To(HaveLen(1))
+			Expect(pending[0].Payload).To(ContainSubstring(`"candidate":"any"`))
+		})
+
+		It("should drain message sent to all devices once on each device", func() {
+			api.Queue = &signaling.QueueConfig{TTL: time.Minute, Size: 10}
+			_, err := api.SendICECandidate(deviceContext(u1.ID, "laptop"), &protos.ICEParam{UserID: u2.ID, Candidate: "any"})
+			Expect(err).To(BeNil())
+			pending, err := api.DrainPending(u2.ID, "phone", signaling.DeliveryKindICE)
+			Expect(err).To(BeNil())
+			Expect(pending).To(HaveLen(1))
+			pending, err = api.DrainPending(u2.ID, "tablet", signaling.DeliveryKindICE)
+			Expect(err).To(BeNil())
+			Expect(pending).To(HaveLen(1))
+			pending, err = api.DrainPending(u2.ID, "phone", signaling.DeliveryKindICE)
+			Expect(err).To(BeNil())
+			Expect(pending).To(BeEmpty())
+		})
+	})
+
+	Describe("IsItMyRooms", func() {
+		When("one of my rooms is on room id list", func() {
+			It("should return true", func() {
+				exist, err := api.IsItMyRooms(u1, []string{
+					r4.ID, r1.ID, r3.ID,
+				})
+				Expect(err).To(BeNil())
+				Expect(*exist).To(BeTrue())
+			})
+		})
+
+		When("any of my rooms are not on room id list", func() {
+			It("should return false", func() {
+				exist, err := api.IsItMyRooms(u1, []string{
+					r3.ID, r4.ID,
+				})
+				Expect(err).To(BeNil())
+				Expect(*exist).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("IsSharingRoomWith", func() {
+		When("target user member of one of my rooms", func() {
+			It("should return true", func() {
+				shared, err := api.IsSharingRoomWith(u1, u3.ID, nil)
+				Expect(err).To(BeNil())
+				Expect(*shared).To(BeTrue())
+			})
+		})
+
+		When("target user not member of any of my rooms", func() {
+			It("should return false", func() {
+				shared, err := api.IsSharingRoomWith(u1, u4.ID, nil)
+				Expect(err).To(BeNil())
+				Expect(*shared).To(BeFalse())
+			})
+		})
+
+		When("shared room outside token scope", func() {
+			It("should return false", func() {
+				shared, err := api.IsSharingRoomWith(u1, u3.ID, &auth.Scope{
+					RoomIDs: []string{r1.ID},
+				})
+				Expect(err).To(BeNil())
+				Expect(*shared).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("SubscribeRoomEvent", func() {
+		When("user joined my room", func() {
+			It("should receive user joined room event", func(done Done) {
+				events := make(chan *room.RoomEvent)
+				myRoomEvents := make(chan *protos.RoomEvent)
+				eventPayload := &room.RoomParticipantEventPayload{
+					RoomID: r1.ID,
+					UserID: u4.ID,
+					ParticipantIDs: []string{
+						u1.ID, u2.ID,
+					},
+				}
+				event := &room.RoomEvent{
+					Event:   room.UserJoinedRoom,
+					Payload: eventPayload,
+				}
+				go func() {
+					ctx := context.WithValue(
+						context.Background(), room.UserIDKey, u1.ID)
+					api.SubscribeRoomEvent(ctx, events, myRoomEvents)
+				}()
+				go func() {
+					events <- event
+				}()
+				e := <-myRoomEvents
+				Expect(e.Event).To(Equal(protos.RoomEvents_UserJoinedRoom))
+				payload := e.Payload.(*protos.RoomEvent_RoomParticipant)
+				Expect(payload.RoomParticipant.ParticipantID).
+					To(Equal(eventPayload.UserID))
+				Expect(payload.RoomParticipant.RoomID).
+					To(Equal(eventPayload.RoomID))
+				close(done)
+			}, 0.3)
+		})
+
+		When("user joined to other room", func() {
+			It("should not receive user joined room event", func(done Done) {
+				events := make(chan *room.RoomEvent)
+				myRoomEvents := make(chan *protos.RoomEvent)
+				eventPayload := &room.RoomParticipantEventPayload{
+					RoomID: r3.ID,
+					UserID: u7.ID,
+					ParticipantIDs: []string{
+						u2.ID, u3.ID, u4.ID, u5.ID, u6.ID,
+					},
+				}
+				event := &room.RoomEvent{
+					Event:   room.UserJoinedRoom,
+					Payload: eventPayload,
 				}
 				go func() {
 					ctx := context.WithValue(
@@ -1095,7 +1947,7 @@ var _ = Describe("API", func() {
 				Candidate: faker.RandomString(200),
 			}
 			go func() {
-				err := api.SendICECandidate(ctx, param)
+				_, err := api.SendICECandidate(ctx, param)
 				Expect(err).To(BeNil())
 			}()
 			ice := <-api.ICEs
@@ -1105,59 +1957,389 @@ var _ = Describe("API", func() {
 			Expect(ice.Candidate).To(Equal(param.Candidate))
 			close(done)
 		}, 0.3)
-	})
 
-	Describe("SubscribeICECandidate", func() {
-		When("other user send ICE candidate to user", func() {
-			It("should receive ICE candiate", func(done Done) {
-				offers := make(chan *signaling.ICEOffer)
-				protoOffers := make(chan *protos.ICEOffer)
-				offer := &signaling.ICEOffer{
-					From:      u2.ID,
-					To:        u1.ID,
+		When("target user not share any room", func() {
+			It("should return permission denied error", func(done Done) {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				param := &protos.ICEParam{
+					UserID:    u5.ID,
 					Candidate: faker.RandomString(200),
-					IsRemote:  true,
 				}
-				go func() {
-					ctx := context.WithValue(
-						context.Background(), room.UserIDKey, u1.ID)
-					api.SubscribeICECandidate(ctx, offers, protoOffers)
-				}()
-				go func() {
-					offers <- offer
-				}()
-				p := <-protoOffers
-				Expect(p.SenderID).To(Equal(offer.From))
-				Expect(p.IsRemote).To(Equal(offer.IsRemote))
-				Expect(p.Candidate).To(Equal(offer.Candidate))
+				_, err := api.SendICECandidate(ctx, param)
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Consistently(api.ICEs).ShouldNot(Receive())
 				close(done)
 			}, 0.3)
 		})
+	})
 
-		When("other user send ICE candidate to other user", func() {
-			It("should not receive ICE candiate", func(done Done) {
-				offers := make(chan *signaling.ICEOffer)
-				protoOffers := make(chan *protos.ICEOffer)
-				offer := &signaling.ICEOffer{
-					From:      u2.ID,
-					To:        u3.ID,
-					Candidate: faker.RandomString(200),
-					IsRemote:  true,
-				}
-				go func() {
-					ctx := context.WithValue(
-						context.Background(), room.UserIDKey, u1.ID)
-					api.SubscribeICECandidate(ctx, offers, protoOffers)
-				}()
-				go func() {
-					offers <- offer
-				}()
-				Consistently(protoOffers).ShouldNot(Receive())
-				close(done)
-			}, 0.3)
+	Describe("SendICECandidate fields", func() {
+		It("should keep media line & username fragment", func(done Done) {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			go api.SendICECandidate(ctx, &protos.ICEParam{
+				UserID:           u2.ID,
+				Candidate:        "candidate",
+				SdpMid:           "0",
+				SdpMLineIndex:    &wrappers.UInt32Value{Value: 0},
+				UsernameFragment: "ufrag",
+			})
+			offer := <-api.ICEs
+			Expect(offer.SDPMid).To(Equal("0"))
+			Expect(*offer.SDPMLineIndex).To(BeNumerically("==", 0))
+			Expect(offer.UsernameFragment).To(Equal("ufrag"))
+			close(done)
+		}, 0.3)
+
+		It("should send end of candidates marker", func(done Done) {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			go api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, EndOfCandidates: true})
+			offer := <-api.ICEs
+			Expect(offer.EndOfCandidates).To(BeTrue())
+			Expect(offer.Candidate).To(BeEmpty())
+			close(done)
+		}, 0.3)
+
+		When("candidate empty without marker", func() {
+			It("should return invalid argument error", func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				_, err := api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("sender restart ICE", func() {
+			It("should drop candidates queued before restart", func(done Done) {
+				api.Queue = &signaling.QueueConfig{TTL: time.Minute, Size: 10}
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				delivery, _ := api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, Candidate: "old"})
+				Expect(delivery.Status).To(Equal(protos.DeliveryStatus_Queued))
+				delivery, _ = api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, IceRestart: true})
+				Expect(delivery.Status).To(Equal(protos.DeliveryStatus_Queued))
+				pending, err := api.DrainPending(u2.ID, "", signaling.DeliveryKindICE)
+				Expect(err).To(BeNil())
+				Expect(pending).To(HaveLen(1))
+				Expect(pending[0].Payload).To(ContainSubstring(`"iceRestart":true`))
+				close(done)
+			}, 0.3)
+		})
+	})
+
+	Describe("Connections", func() {
+		It("should keep connection of SDP", func(done Done) {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			go api.OfferSDP(ctx, &protos.SDPParam{UserID: u2.ID, ConnectionID: "screen"})
+			command := <-SDPCommands
+			Expect(command.ConnectionID).To(Equal("screen"))
+			in := make(chan *signaling.SDPCommand)
+			out := make(chan *protos.SDP)
+			go api.SubscribeSDPCommand(context.WithValue(context.Background(), room.UserIDKey, u2.ID), in, out)
+			go func() { in <- command }()
+			sdp := <-out
+			Expect(sdp.ConnectionID).To(Equal("screen"))
+			close(done)
+		}, 0.3)
+
+		It("should keep connection of ICE candidate", func(done Done) {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			go api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, Candidate: "candidate", ConnectionID: "screen"})
+			offer := <-ICEOffers
+			Expect(offer.ConnectionID).To(Equal("screen"))
+			in := make(chan *signaling.ICEOffer)
+			out := make(chan *protos.ICEOffer)
+			go api.SubscribeICECandidate(context.WithValue(context.Background(), room.UserIDKey, u2.ID), in, out)
+			go func() { in <- offer }()
+			protoOffer := <-out
+			Expect(protoOffer.ConnectionID).To(Equal("screen"))
+			close(done)
+		}, 0.3)
+
+		When("sender restart ICE of a connection", func() {
+			It("should keep candidates queued for other connections", func() {
+				api.Queue = &signaling.QueueConfig{TTL: time.Minute, Size: 10}
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				_, err := api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, Candidate: "camera", ConnectionID: "camera"})
+				Expect(err).To(BeNil())
+				_, err = api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, Candidate: "screen", ConnectionID: "screen"})
+				Expect(err).To(BeNil())
+				_, err = api.SendICECandidate(ctx, &protos.ICEParam{UserID: u2.ID, IceRestart: true, ConnectionID: "screen"})
+				Expect(err).To(BeNil())
+				pending, err := api.DrainPending(u2.ID, "", signaling.DeliveryKindICE)
+				Expect(err).To(BeNil())
+				Expect(pending).To(HaveLen(2))
+				Expect(pending[0].Payload).To(ContainSubstring(`"candidate":"camera"`))
+				Expect(pending[1].Payload).To(ContainSubstring(`"iceRestart":true`))
+			})
+		})
+	})
+
+	Describe("SubscribeICECandidate fields", func() {
+		It("should convert media line index", func(done Done) {
+			in := make(chan *signaling.ICEOffer)
+			out := make(chan *protos.ICEOffer)
+			index := uint32(1)
+			go func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				api.SubscribeICECandidate(ctx, in, out)
+			}()
+			go func() {
+				in <- &signaling.ICEOffer{
+					From: u2.ID, To: u1.ID, Candidate: "candidate",
+					SDPMid: "1", SDPMLineIndex: &index, UsernameFragment: "ufrag",
+				}
+				in <- &signaling.ICEOffer{From: u2.ID, To: u1.ID, ICERestart: true}
+			}()
+			offer := <-out
+			Expect(offer.SdpMid).To(Equal("1"))
+			Expect(offer.SdpMLineIndex.Value).To(BeNumerically("==", 1))
+			Expect(offer.UsernameFragment).To(Equal("ufrag"))
+			restart := <-out
+			Expect(restart.IceRestart).To(BeTrue())
+			Expect(restart.SdpMLineIndex).To(BeNil())
+			close(done)
+		}, 0.3)
+	})
+
+	Describe("SubscribeICECandidate", func() {
+		When("other user send ICE candidate to user", func() {
+			It("should receive ICE candiate", func(done Done) {
+				offers := make(chan *signaling.ICEOffer)
+				protoOffers := make(chan *protos.ICEOffer)
+				offer := &signaling.ICEOffer{
+					From:      u2.ID,
+					To:        u1.ID,
+					Candidate: faker.RandomString(200),
+					IsRemote:  true,
+				}
+				go func() {
+					ctx := context.WithValue(
+						context.Background(), room.UserIDKey, u1.ID)
+					api.SubscribeICECandidate(ctx, offers, protoOffers)
+				}()
+				go func() {
+					offers <- offer
+				}()
+				p := <-protoOffers
+				Expect(p.SenderID).To(Equal(offer.From))
+				Expect(p.IsRemote).To(Equal(offer.IsRemote))
+				Expect(p.Candidate).To(Equal(offer.Candidate))
+				close(done)
+			}, 0.3)
+		})
+
+		When("other user send ICE candidate to other user", func() {
+			It("should not receive ICE candiate", func(done Done) {
+				offers := make(chan *signaling.ICEOffer)
+				protoOffers := make(chan *protos.ICEOffer)
+				offer := &signaling.ICEOffer{
+					From:      u2.ID,
+					To:        u3.ID,
+					Candidate: faker.RandomString(200),
+					IsRemote:  true,
+				}
+				go func() {
+					ctx := context.WithValue(
+						context.Background(), room.UserIDKey, u1.ID)
+					api.SubscribeICECandidate(ctx, offers, protoOffers)
+				}()
+				go func() {
+					offers <- offer
+				}()
+				Consistently(protoOffers).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
 		})
 	})
 
+	Describe("SendSignal", func() {
+		It("should publish signal to a user", func(done Done) {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			param := &protos.SignalParam{
+				Type:    "mute",
+				Payload: []byte("audio"),
+				Data: &_struct.Struct{
+					Fields: map[string]*_struct.Value{
+						"muted": {Kind: &_struct.Value_BoolValue{BoolValue: true}},
+					},
+				},
+				UserID: u2.ID,
+			}
+			go func() {
+				err := api.SendSignal(ctx, param)
+				Expect(err).To(BeNil())
+			}()
+			signal := <-api.Signals
+			Expect(signal.From).To(Equal(u1.ID))
+			Expect(signal.To).To(Equal(u2.ID))
+			Expect(signal.Type).To(Equal(param.Type))
+			Expect(signal.Payload).To(Equal(param.Payload))
+			Expect(signal.Data).To(MatchJSON(`{"muted":true}`))
+			close(done)
+		}, 0.3)
+
+		It("should publish signal to other room members", func(done Done) {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u2.ID)
+			param := &protos.SignalParam{
+				Type:   "hand-raise",
+				RoomID: r3.ID,
+			}
+			go func() {
+				err := api.SendSignal(ctx, param)
+				Expect(err).To(BeNil())
+			}()
+			signal := <-api.Signals
+			Expect(signal.From).To(Equal(u2.ID))
+			Expect(signal.RoomID).To(Equal(r3.ID))
+			Expect(signal.Recipients).To(ConsistOf(u3.ID, u4.ID, u5.ID, u6.ID))
+			close(done)
+		}, 0.3)
+
+		When("signal has no type or target", func() {
+			It("should return invalid argument error", func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				err := api.SendSignal(ctx, &protos.SignalParam{UserID: u2.ID})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				err = api.SendSignal(ctx, &protos.SignalParam{Type: "mute"})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				err = api.SendSignal(ctx, &protos.SignalParam{
+					Type: "mute", UserID: u2.ID, RoomID: r1.ID,
+				})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("payload too large", func() {
+			It("should return invalid argument error", func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				err := api.SendSignal(ctx, &protos.SignalParam{
+					Type:    "blob",
+					Payload: make([]byte, signaling.MaxSignalSize+1),
+					UserID:  u2.ID,
+				})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				Expect(err.Error()).To(ContainSubstring(signaling.SignalTooLargeError))
+			})
+		})
+
+		When("target user not share any room", func() {
+			It("should return permission denied error", func(done Done) {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				err := api.SendSignal(ctx, &protos.SignalParam{Type: "mute", UserID: u5.ID})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Consistently(api.Signals).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
+		})
+
+		When("user not a member of target room", func() {
+			It("should return permission denied error", func(done Done) {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				err := api.SendSignal(ctx, &protos.SignalParam{Type: "mute", RoomID: r3.ID})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Consistently(api.Signals).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
+		})
+
+		When("token not allowed on target room", func() {
+			It("should return permission denied error", func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				ctx = context.WithValue(ctx, auth.ScopeContextKey, &auth.Scope{RoomIDs: []string{r2.ID}})
+				err := api.SendSignal(ctx, &protos.SignalParam{Type: "mute", RoomID: r1.ID})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+		})
+	})
+
+	Describe("SubscribeSignal", func() {
+		When("other user send signal to user", func() {
+			It("should receive signal", func(done Done) {
+				in := make(chan *signaling.Signal)
+				out := make(chan *protos.Signal)
+				signal := &signaling.Signal{
+					From:    u2.ID,
+					To:      u1.ID,
+					Type:    "mute",
+					Payload: []byte("audio"),
+					Data:    []byte(`{"muted":true}`),
+					Time:    time.Now(),
+				}
+				go func() {
+					ctx := context.WithValue(
+						context.Background(), room.UserIDKey, u1.ID)
+					api.SubscribeSignal(ctx, in, out)
+				}()
+				go func() {
+					in <- signal
+				}()
+				p := <-out
+				Expect(p.SenderID).To(Equal(signal.From))
+				Expect(p.Type).To(Equal(signal.Type))
+				Expect(p.Payload).To(Equal(signal.Payload))
+				Expect(p.Data.Fields["muted"].GetBoolValue()).To(BeTrue())
+				Expect(p.Time.Seconds).To(Equal(signal.Time.Unix()))
+				close(done)
+			}, 0.3)
+		})
+
+		When("other member send signal to user's room", func() {
+			It("should receive signal with room id", func(done Done) {
+				in := make(chan *signaling.Signal)
+				out := make(chan *protos.Signal)
+				signal := &signaling.Signal{
+					From:       u3.ID,
+					RoomID:     r2.ID,
+					Recipients: []string{u1.ID},
+					Type:       "hand-raise",
+					Time:       time.Now(),
+				}
+				go func() {
+					ctx := context.WithValue(
+						context.Background(), room.UserIDKey, u1.ID)
+					api.SubscribeSignal(ctx, in, out)
+				}()
+				go func() {
+					in <- signal
+				}()
+				p := <-out
+				Expect(p.SenderID).To(Equal(signal.From))
+				Expect(p.RoomID).To(Equal(signal.RoomID))
+				close(done)
+			}, 0.3)
+		})
+
+		When("other user send signal to other user", func() {
+			It("should not receive signal", func(done Done) {
+				in := make(chan *signaling.Signal)
+				out := make(chan *protos.Signal)
+				signal := &signaling.Signal{
+					From: u2.ID,
+					To:   u3.ID,
+					Type: "mute",
+					Time: time.Now(),
+				}
+				go func() {
+					ctx := context.WithValue(
+						context.Background(), room.UserIDKey, u1.ID)
+					api.SubscribeSignal(ctx, in, out)
+				}()
+				go func() {
+					in <- signal
+				}()
+				Consistently(out).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
+		})
+	})
+
+	// receiveSnapshot read presence of co-members sent when subscription started
+	receiveSnapshot := func(protoStatusChanges chan *protos.OnlineStatus, count int) []*protos.OnlineStatus {
+		snapshot := []*protos.OnlineStatus{}
+		for i := 0; i < count; i++ {
+			snapshot = append(snapshot, <-protoStatusChanges)
+		}
+		return snapshot
+	}
+
 	Describe("SubscribeOnlineStatus", func() {
 		When("subscription active", func() {
 			It("should set user status to online", func(done Done) {
@@ -1176,7 +2358,7 @@ var _ = Describe("API", func() {
 				}()
 				go func() {
 					ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
-					api.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, protoStatusChanges)
+					api.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, nil, protoStatusChanges)
 				}()
 				go func() {
 					statusChanges <- statusChange
@@ -1187,7 +2369,7 @@ var _ = Describe("API", func() {
 				}()
 				<-protoStatusChanges
 				u, _ := api.GetUser(
-					context.Background(),
+					context.WithValue(context.Background(), room.UserIDKey, u2.ID),
 					&protos.GetUserParam{Id: u1.ID})
 				Expect(u.Online).To(BeTrue())
 				close(done)
@@ -1212,7 +2394,7 @@ var _ = Describe("API", func() {
 				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
 				ctx, cancel := context.WithCancel(ctx)
 				go func() {
-					api.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, protoStatusChanges)
+					api.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, nil, protoStatusChanges)
 				}()
 				go func() {
 					statusChanges <- statusChange
@@ -1225,7 +2407,7 @@ var _ = Describe("API", func() {
 				cancel()
 				time.Sleep(time.Millisecond * 100)
 				u, err := api.GetUser(
-					context.Background(),
+					context.WithValue(context.Background(), room.UserIDKey, u2.ID),
 					&protos.GetUserParam{Id: u1.ID})
 				Expect(err).To(BeNil())
 				Expect(u.Online).To(BeFalse())
@@ -1233,6 +2415,32 @@ var _ = Describe("API", func() {
 			}, 0.5)
 		})
 
+		When("context done while status change not received", func() {
+			It("should return and release session", func(done Done) {
+				statusChanges := make(chan *signaling.OnlineStatus)
+				protoStatusChanges := make(chan *protos.OnlineStatus)
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				ctx, cancel := context.WithCancel(ctx)
+				go func() {
+					for {
+						<-api.Onlines
+					}
+				}()
+				returned := make(chan error)
+				go func() {
+					returned <- api.SubscribeOnlineStatus(ctx, make(chan *protos.Heartbeat), statusChanges, nil, protoStatusChanges)
+				}()
+				receiveSnapshot(protoStatusChanges, 2)
+				statusChanges <- &signaling.OnlineStatus{ID: u2.ID, Online: false}
+				cancel()
+				Expect(<-returned).To(BeNil())
+				connected, err := api.HasSession(u1.ID, time.Now())
+				Expect(err).To(BeNil())
+				Expect(connected).To(BeFalse())
+				close(done)
+			}, 0.5)
+		})
+
 		When("heartbeat not send in 5 second", func() {
 			It("should set user status to offline", func(done Done) {
 				statusChanges := make(chan *signaling.OnlineStatus)
@@ -1248,7 +2456,7 @@ var _ = Describe("API", func() {
 				}()
 				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
 				go func() {
-					api.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, protoStatusChanges)
+					api.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, nil, protoStatusChanges)
 				}()
 				go func() {
 					statusChanges <- statusChange
@@ -1260,7 +2468,7 @@ var _ = Describe("API", func() {
 				<-protoStatusChanges
 				time.Sleep((time.Second * 5) + (time.Millisecond * 100))
 				u, err := api.GetUser(
-					context.Background(),
+					context.WithValue(context.Background(), room.UserIDKey, u2.ID),
 					&protos.GetUserParam{Id: u1.ID})
 				Expect(err).To(BeNil())
 				Expect(u.Online).To(BeFalse())
@@ -1268,6 +2476,24 @@ var _ = Describe("API", func() {
 			}, 7)
 		})
 
+		When("subscription started", func() {
+			It("should send current presence of users sharing room", func(done Done) {
+				protoStatusChanges := make(chan *protos.OnlineStatus)
+				ctx, cancel := context.WithCancel(context.WithValue(context.Background(), room.UserIDKey, u1.ID))
+				go api.SubscribeOnlineStatus(ctx, make(chan *protos.Heartbeat), nil, nil, protoStatusChanges)
+				<-api.Onlines
+				snapshot := receiveSnapshot(protoStatusChanges, 2)
+				Expect(snapshot[0].Id).To(Equal(u2.ID))
+				Expect(snapshot[0].Online).To(Equal(u2.Online))
+				Expect(snapshot[1].Id).To(Equal(u3.ID))
+				Expect(snapshot[1].Online).To(Equal(u3.Online))
+				Consistently(protoStatusChanges).ShouldNot(Receive())
+				cancel()
+				<-api.Onlines
+				close(done)
+			}, 0.3)
+		})
+
 		When("other user online status change", func() {
 			It("should receive status change event", func(done Done) {
 				statusChanges := make(chan *signaling.OnlineStatus)
@@ -1285,7 +2511,7 @@ var _ = Describe("API", func() {
 				}()
 				go func() {
 					ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
-					api.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, protoStatusChanges)
+					api.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, nil, protoStatusChanges)
 				}()
 				go func() {
 					statusChanges <- statusChange
@@ -1294,6 +2520,7 @@ var _ = Describe("API", func() {
 					<-api.Onlines
 					<-api.Onlines
 				}()
+				receiveSnapshot(protoStatusChanges, 2)
 				status := <-protoStatusChanges
 				Expect(status.Id).To(Equal(statusChange.ID))
 				Expect(status.Online).To(Equal(statusChange.Online))
@@ -1318,7 +2545,7 @@ var _ = Describe("API", func() {
 				}
 				go func() {
 					ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
-					api.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, protoStatusChanges)
+					api.SubscribeOnlineStatus(ctx, heartbeat, statusChanges, nil, protoStatusChanges)
 				}()
 				go func() {
 					statusChanges <- statusChange
@@ -1327,10 +2554,451 @@ var _ = Describe("API", func() {
 					<-api.Onlines
 					<-api.Onlines
 				}()
+				receiveSnapshot(protoStatusChanges, 2)
+				Consistently(protoStatusChanges).ShouldNot(Receive())
+				close(done)
+			}, 0.3)
+		})
+
+		When("user not share any room with subscriber", func() {
+			It("should not receive status change event", func(done Done) {
+				statusChanges := make(chan *signaling.OnlineStatus)
+				protoStatusChanges := make(chan *protos.OnlineStatus)
+				ctx, cancel := context.WithCancel(context.WithValue(context.Background(), room.UserIDKey, u1.ID))
+				go api.SubscribeOnlineStatus(ctx, make(chan *protos.Heartbeat), statusChanges, nil, protoStatusChanges)
+				<-api.Onlines
+				receiveSnapshot(protoStatusChanges, 2)
+				go func() {
+					statusChanges <- &signaling.OnlineStatus{ID: u4.ID, Online: true}
+				}()
 				Consistently(protoStatusChanges).ShouldNot(Receive())
+				cancel()
+				<-api.Onlines
+				close(done)
+			}, 0.3)
+		})
+
+		When("user join room of subscriber", func() {
+			It("should start receive it's status change event", func(done Done) {
+				statusChanges := make(chan *signaling.OnlineStatus)
+				roomEvents := make(chan *room.RoomEvent)
+				protoStatusChanges := make(chan *protos.OnlineStatus)
+				ctx, cancel := context.WithCancel(context.WithValue(context.Background(), room.UserIDKey, u1.ID))
+				go api.SubscribeOnlineStatus(ctx, make(chan *protos.Heartbeat), statusChanges, roomEvents, protoStatusChanges)
+				<-api.Onlines
+				receiveSnapshot(protoStatusChanges, 2)
+				db.Model(r1).Association("Members").Append(u4)
+				roomEvents <- &room.RoomEvent{
+					Event: room.UserJoinedRoom,
+					Payload: &room.RoomParticipantEventPayload{
+						UserID:         u4.ID,
+						RoomID:         r1.ID,
+						ParticipantIDs: []string{u1.ID, u2.ID, u4.ID},
+					},
+				}
+				statusChanges <- &signaling.OnlineStatus{ID: u4.ID, Online: true}
+				status := <-protoStatusChanges
+				Expect(status.Id).To(Equal(u4.ID))
+				cancel()
+				<-api.Onlines
 				close(done)
 			}, 0.3)
 		})
+
+		When("user left the only room shared with subscriber", func() {
+			It("should stop receive it's status change event", func(done Done) {
+				statusChanges := make(chan *signaling.OnlineStatus)
+				roomEvents := make(chan *room.RoomEvent)
+				protoStatusChanges := make(chan *protos.OnlineStatus)
+				ctx, cancel := context.WithCancel(context.WithValue(context.Background(), room.UserIDKey, u1.ID))
+				go api.SubscribeOnlineStatus(ctx, make(chan *protos.Heartbeat), statusChanges, roomEvents, protoStatusChanges)
+				<-api.Onlines
+				receiveSnapshot(protoStatusChanges, 2)
+				db.Model(r1).Association("Members").Delete(u2)
+				roomEvents <- &room.RoomEvent{
+					Event: room.UserLeftRoom,
+					Payload: &room.RoomParticipantEventPayload{
+						UserID:         u2.ID,
+						RoomID:         r1.ID,
+						ParticipantIDs: []string{u1.ID},
+					},
+				}
+				go func() {
+					statusChanges <- &signaling.OnlineStatus{ID: u2.ID, Online: true}
+				}()
+				Consistently(protoStatusChanges).ShouldNot(Receive())
+				cancel()
+				<-api.Onlines
+				close(done)
+			}, 0.3)
+		})
+	})
+
+	Describe("Presence sessions", func() {
+		subscribe := func() context.CancelFunc {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			ctx, cancel := context.WithCancel(ctx)
+			heartbeat := make(chan *protos.Heartbeat)
+			go api.SubscribeOnlineStatus(ctx, heartbeat, nil, nil, make(chan *protos.OnlineStatus))
+			status := <-api.Onlines
+			Expect(status.Online).To(BeTrue())
+			return cancel
+		}
+		isOnline := func() bool {
+			u, _ := api.GetUser(
+				context.WithValue(context.Background(), room.UserIDKey, u2.ID),
+				&protos.GetUserParam{Id: u1.ID})
+			return u.Online
+		}
+
+		When("user has other session", func() {
+			It("should keep user online until last session ended", func(done Done) {
+				first := subscribe()
+				second := subscribe()
+				first()
+				Consistently(api.Onlines, 0.1).ShouldNot(Receive())
+				Expect(isOnline()).To(BeTrue())
+				second()
+				status := <-api.Onlines
+				Expect(status.Online).To(BeFalse())
+				Expect(isOnline()).To(BeFalse())
+				close(done)
+			}, 1)
+		})
+
+		When("user reconnect within grace period", func() {
+			It("should keep user online", func(done Done) {
+				api.PresenceGrace = time.Millisecond * 100
+				first := subscribe()
+				first()
+				time.Sleep(time.Millisecond * 20)
+				second := subscribe()
+				Consistently(api.Onlines, 0.2).ShouldNot(Receive())
+				Expect(isOnline()).To(BeTrue())
+				second()
+				status := <-api.Onlines
+				Expect(status.Online).To(BeFalse())
+				close(done)
+			}, 1)
+		})
+
+		When("grace period passed", func() {
+			It("should set user offline", func(done Done) {
+				api.PresenceGrace = time.Millisecond * 50
+				cancel := subscribe()
+				cancel()
+				Expect(isOnline()).To(BeTrue())
+				status := <-api.Onlines
+				Expect(status.Online).To(BeFalse())
+				Expect(status.LastSeenAt).NotTo(BeNil())
+				Expect(isOnline()).To(BeFalse())
+				close(done)
+			}, 1)
+		})
+
+		It("should not set user with live session offline", func() {
+			release, err := api.KeepSubscribed(u1.ID, "", signaling.PresenceSessionKind)
+			Expect(err).To(BeNil())
+			defer release()
+			db.Model(u1).Update("online", true)
+			changed, err := api.SetOfflineWithoutSession(u1.ID)
+			Expect(err).To(BeNil())
+			Expect(changed).To(BeFalse())
+			Expect(isOnline()).To(BeTrue())
+		})
+	})
+
+	Describe("Instance sweep", func() {
+		var past time.Time
+		var future time.Time
+
+		JustBeforeEach(func() {
+			past = time.Now().Add(-time.Minute)
+			future = time.Now().Add(time.Minute)
+			db.Create(&signaling.InstanceModel{ID: "dead", ExpiresAt: past})
+			db.Create(&signaling.InstanceModel{ID: "alive", ExpiresAt: future})
+			db.Model(&room.UserModel{}).
+				Where("id IN (?)", []string{u1.ID, u2.ID}).
+				Update("online", true)
+		})
+
+		When("instance lease expired", func() {
+			It("should set it's users offline and publish status", func(done Done) {
+				db.Create(&signaling.SubscriptionModel{
+					ID: "s1", UserID: u1.ID, InstanceID: "dead",
+					Kind: signaling.PresenceSessionKind, ExpiresAt: future,
+				})
+				db.Create(&signaling.SubscriptionModel{
+					ID: "s2", UserID: u1.ID, InstanceID: "dead",
+					Kind: signaling.DeliveryKindSDP, ExpiresAt: future,
+				})
+				swept := make(chan []string)
+				go func() {
+					defer GinkgoRecover()
+					offline, err := api.SweepDeadInstances(time.Now())
+					Expect(err).To(BeNil())
+					swept <- offline
+				}()
+				status := <-api.Onlines
+				Expect(<-swept).To(Equal([]string{u1.ID}))
+				Expect(status.ID).To(Equal(u1.ID))
+				Expect(status.Online).To(BeFalse())
+				Expect(status.LastSeenAt).NotTo(BeNil())
+				var count int
+				db.Model(&signaling.SubscriptionModel{}).
+					Where("instance_id = ?", "dead").
+					Count(&count)
+				Expect(count).To(Equal(0))
+				db.Model(&signaling.InstanceModel{}).Count(&count)
+				Expect(count).To(Equal(1))
+				close(done)
+			}, 1)
+
+			It("should keep user with session on other instance online", func() {
+				db.Create(&signaling.SubscriptionModel{
+					ID: "s1", UserID: u1.ID, InstanceID: "dead",
+					Kind: signaling.PresenceSessionKind, ExpiresAt: future,
+				})
+				db.Create(&signaling.SubscriptionModel{
+					ID: "s2", UserID: u1.ID, InstanceID: "alive",
+					Kind: signaling.PresenceSessionKind, ExpiresAt: future,
+				})
+				offline, err := api.SweepDeadInstances(time.Now())
+				Expect(err).To(BeNil())
+				Expect(offline).To(BeEmpty())
+				u, _ := api.GetUser(
+					context.WithValue(context.Background(), room.UserIDKey, u2.ID),
+					&protos.GetUserParam{Id: u1.ID})
+				Expect(u.Online).To(BeTrue())
+			})
+		})
+
+		It("should not sweep users of live instance", func() {
+			db.Create(&signaling.SubscriptionModel{
+				ID: "s1", UserID: u2.ID, InstanceID: "alive",
+				Kind: signaling.PresenceSessionKind, ExpiresAt: future,
+			})
+			offline, err := api.SweepDeadInstances(time.Now())
+			Expect(err).To(BeNil())
+			Expect(offline).To(BeEmpty())
+			var count int
+			db.Model(&signaling.SubscriptionModel{}).
+				Where("instance_id = ?", "alive").
+				Count(&count)
+			Expect(count).To(Equal(1))
+			u, _ := api.GetUser(context.WithValue(context.Background(), room.UserIDKey, u1.ID), &protos.GetUserParam{Id: u2.ID})
+			Expect(u.Online).To(BeTrue())
+		})
+
+		It("should record instance owning subscription", func() {
+			release, err := api.KeepInstance(time.Minute)
+			Expect(err).To(BeNil())
+			defer release()
+			Expect(api.InstanceID).NotTo(BeEmpty())
+			releaseSession, err := api.KeepSubscribed(u1.ID, "", signaling.PresenceSessionKind)
+			Expect(err).To(BeNil())
+			defer releaseSession()
+			var count int
+			db.Model(&signaling.SubscriptionModel{}).
+				Where("instance_id = ?", api.InstanceID).
+				Count(&count)
+			Expect(count).To(Equal(1))
+		})
+
+		It("should expire lease of released instance so it's sessions swept", func(done Done) {
+			release, err := api.KeepInstance(time.Minute)
+			Expect(err).To(BeNil())
+			_, err = api.KeepSubscribed(u1.ID, "", signaling.PresenceSessionKind)
+			Expect(err).To(BeNil())
+			release()
+			var count int
+			db.Model(&signaling.InstanceModel{}).
+				Where("id = ? AND expires_at <= ?", api.InstanceID, time.Now()).
+				Count(&count)
+			Expect(count).To(Equal(1))
+			swept := make(chan []string)
+			go func() {
+				defer GinkgoRecover()
+				offline, err := api.SweepDeadInstances(time.Now())
+				Expect(err).To(BeNil())
+				swept <- offline
+			}()
+			status := <-api.Onlines
+			Expect(status.ID).To(Equal(u1.ID))
+			Expect(status.Online).To(BeFalse())
+			Expect(<-swept).To(Equal([]string{u1.ID}))
+			db.Model(&signaling.SubscriptionModel{}).
+				Where("instance_id = ?", api.InstanceID).
+				Count(&count)
+			Expect(count).To(Equal(0))
+			close(done)
+		}, 1)
+	})
+
+	Describe("Paused instance", func() {
+		It("should register instance & it's sessions swept by other instance again", func(done Done) {
+			api.Queue = &signaling.QueueConfig{
+				TTL:               time.Minute,
+				Size:              10,
+				SubscriptionLease: time.Millisecond * 100,
+			}
+			stop := make(chan struct{})
+			defer close(stop)
+			go func() {
+				for {
+					select {
+					case <-api.Onlines:
+					case <-stop:
+						return
+					}
+				}
+			}()
+			release, err := api.KeepInstance(time.Millisecond * 100)
+			Expect(err).To(BeNil())
+			defer release()
+			releaseSession, err := api.KeepPresenceSession(u1.ID, "")
+			Expect(err).To(BeNil())
+			defer releaseSession()
+			db.Model(u1).Update("online", true)
+
+			// other instance see lease expired while this instance paused
+			db.Model(&signaling.InstanceModel{}).
+				Where("id = ?", api.InstanceID).
+				Update("expires_at", time.Now().Add(-time.Second))
+			offline, err := api.SweepDeadInstances(time.Now())
+			Expect(err).To(BeNil())
+			Expect(offline).To(Equal([]string{u1.ID}))
+
+			alive := func() bool {
+				var count int
+				db.Model(&signaling.InstanceModel{}).
+					Where("id = ? AND expires_at > ?", api.InstanceID, time.Now()).
+					Count(&count)
+				return count == 1
+			}
+			Eventually(alive, 0.5, 0.02).Should(BeTrue())
+			hasSession := func() bool {
+				connected, err := api.HasSession(u1.ID, time.Now())
+				Expect(err).To(BeNil())
+				return connected
+			}
+			Eventually(hasSession, 0.5, 0.02).Should(BeTrue())
+			isOnline := func() bool {
+				u := &room.UserModel{}
+				db.Where("id = ?", u1.ID).First(u)
+				return u.Online
+			}
+			Eventually(isOnline, 0.5, 0.02).Should(BeTrue())
+			close(done)
+		}, 2)
+	})
+
+	Describe("SweepOfflineUsers", func() {
+		JustBeforeEach(func() {
+			db.Model(&room.UserModel{}).Update("online", false)
+			db.Model(&room.UserModel{}).
+				Where("id IN (?)", []string{u1.ID, u2.ID}).
+				Update("online", true)
+		})
+
+		It("should set online users without session offline and publish status", func(done Done) {
+			release, err := api.KeepSubscribed(u2.ID, "", signaling.PresenceSessionKind)
+			Expect(err).To(BeNil())
+			defer release()
+			swept := make(chan []string)
+			go func() {
+				defer GinkgoRecover()
+				offline, err := api.SweepOfflineUsers(time.Now())
+				Expect(err).To(BeNil())
+				swept <- offline
+			}()
+			status := <-api.Onlines
+			Expect(status.ID).To(Equal(u1.ID))
+			Expect(status.Online).To(BeFalse())
+			Expect(status.LastSeenAt).NotTo(BeNil())
+			Expect(<-swept).To(Equal([]string{u1.ID}))
+			u, _ := api.GetUser(
+				context.WithValue(context.Background(), room.UserIDKey, u1.ID),
+				&protos.GetUserParam{Id: u2.ID})
+			Expect(u.Online).To(BeTrue())
+			close(done)
+		}, 1)
+
+		It("should keep user online during grace period after session released", func(done Done) {
+			api.PresenceGrace = time.Millisecond * 100
+			for _, id := range []string{u1.ID, u2.ID} {
+				release, err := api.KeepPresenceSession(id, "")
+				Expect(err).To(BeNil())
+				release()
+			}
+			offline, err := api.SweepOfflineUsers(time.Now())
+			Expect(err).To(BeNil())
+			Expect(offline).To(BeEmpty())
+
+			time.Sleep(time.Millisecond * 150)
+			swept := make(chan []string)
+			go func() {
+				defer GinkgoRecover()
+				offline, err := api.SweepOfflineUsers(time.Now())
+				Expect(err).To(BeNil())
+				swept <- offline
+			}()
+			<-api.Onlines
+			<-api.Onlines
+			Expect(<-swept).To(ConsistOf(u1.ID, u2.ID))
+			close(done)
+		}, 1)
+	})
+
+	Describe("KeepSubscribed", func() {
+		BeforeEach(func() {
+			api.Queue = &signaling.QueueConfig{
+				TTL:               time.Minute,
+				Size:              10,
+				SubscriptionLease: time.Millisecond * 100,
+			}
+		})
+
+		isSubscribed := func() bool {
+			subscribed, err := api.IsSubscribed(u1.ID, "", signaling.DeliveryKindSDP, time.Now())
+			Expect(err).To(BeNil())
+			return subscribed
+		}
+
+		It("should register subscription swept while open again", func() {
+			release, err := api.KeepSubscribed(u1.ID, "", signaling.DeliveryKindSDP)
+			Expect(err).To(BeNil())
+			defer release()
+			db.Where("user_id = ?", u1.ID).Delete(&signaling.SubscriptionModel{})
+			Expect(isSubscribed()).To(BeFalse())
+			Eventually(isSubscribed, 0.5, 0.02).Should(BeTrue())
+		})
+
+		It("should not register released subscription again", func() {
+			release, err := api.KeepSubscribed(u1.ID, "", signaling.DeliveryKindSDP)
+			Expect(err).To(BeNil())
+			release()
+			Consistently(isSubscribed, 0.2, 0.02).Should(BeFalse())
+		})
+	})
+
+	Describe("PruneExpiredSubscriptions", func() {
+		It("should remove expired subscriptions only", func() {
+			db.Create(&signaling.SubscriptionModel{
+				ID: "s1", UserID: u1.ID, Kind: signaling.PresenceSessionKind,
+				ExpiresAt: time.Now().Add(-time.Minute),
+			})
+			db.Create(&signaling.SubscriptionModel{
+				ID: "s2", UserID: u2.ID, Kind: signaling.PresenceSessionKind,
+				ExpiresAt: time.Now().Add(time.Minute),
+			})
+			pruned, err := api.PruneExpiredSubscriptions(time.Now())
+			Expect(err).To(BeNil())
+			Expect(pruned).To(Equal(int64(1)))
+			connected, err := api.HasSession(u2.ID, time.Now())
+			Expect(err).To(BeNil())
+			Expect(connected).To(BeTrue())
+		})
 	})
 
 	Describe("SetUserOnlineStatus", func() {
@@ -1342,7 +3010,7 @@ var _ = Describe("API", func() {
 				err := api.SetUserOnlineStatus(u1.ID, true)
 				Expect(err).To(BeNil())
 				u, _ := api.GetUser(
-					context.Background(),
+					context.WithValue(context.Background(), room.UserIDKey, u2.ID),
 					&protos.GetUserParam{Id: u1.ID})
 				Expect(u.Online).To(BeTrue())
 				close(done)
@@ -1368,7 +3036,7 @@ var _ = Describe("API", func() {
 				err := api.SetUserOnlineStatus(u1.ID, false)
 				Expect(err).To(BeNil())
 				u, _ := api.GetUser(
-					context.Background(),
+					context.WithValue(context.Background(), room.UserIDKey, u2.ID),
 					&protos.GetUserParam{Id: u1.ID})
 				Expect(u.Online).To(BeFalse())
 				close(done)
@@ -1383,6 +3051,241 @@ var _ = Describe("API", func() {
 				Expect(status.Online).To(BeFalse())
 				close(done)
 			}, 0.3)
+
+			It("should record last seen time", func(done Done) {
+				go func() {
+					<-api.Onlines
+				}()
+				before := time.Now()
+				err := api.SetUserOnlineStatus(u1.ID, false)
+				Expect(err).To(BeNil())
+				u, _ := api.GetUser(
+					context.WithValue(context.Background(), room.UserIDKey, u2.ID),
+					&protos.GetUserParam{Id: u1.ID})
+				Expect(u.Presence).To(Equal(protos.PresenceState_Offline))
+				Expect(u.LastSeenAt).NotTo(BeNil())
+				lastSeen, _ := ptypes.Timestamp(u.LastSeenAt)
+				Expect(lastSeen).To(BeTemporally(">=", before.Truncate(time.Second)))
+				close(done)
+			}, 0.3)
+		})
+	})
+
+	Describe("SetPresence", func() {
+		var ctx context.Context
+
+		JustBeforeEach(func() {
+			ctx = context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			go func() {
+				<-api.Onlines
+			}()
+			api.SetUserOnlineStatus(u1.ID, true)
+		})
+
+		It("should publish presence & status text", func(done Done) {
+			go func() {
+				err := api.SetPresence(ctx, &protos.PresenceParam{
+					State:      protos.PresenceState_Busy,
+					StatusText: "in a meeting",
+				})
+				Expect(err).To(BeNil())
+			}()
+			status := <-api.Onlines
+			Expect(status.ID).To(Equal(u1.ID))
+			Expect(status.Online).To(BeTrue())
+			Expect(status.Presence).To(Equal(room.PresenceBusy))
+			Expect(status.StatusText).To(Equal("in a meeting"))
+			u, _ := api.GetUser(
+				context.WithValue(context.Background(), room.UserIDKey, u2.ID),
+				&protos.GetUserParam{Id: u1.ID})
+			Expect(u.Presence).To(Equal(protos.PresenceState_Busy))
+			Expect(u.StatusText).To(Equal("in a meeting"))
+			close(done)
+		}, 0.3)
+
+		When("user go invisible", func() {
+			It("should be seen as offline", func(done Done) {
+				go api.SetPresence(ctx, &protos.PresenceParam{State: protos.PresenceState_Invisible})
+				status := <-api.Onlines
+				Expect(status.Online).To(BeFalse())
+				Expect(status.Presence).To(Equal(room.PresenceOffline))
+				Expect(status.LastSeenAt).NotTo(BeNil())
+				u, _ := api.GetUser(
+					context.WithValue(context.Background(), room.UserIDKey, u2.ID),
+					&protos.GetUserParam{Id: u1.ID})
+				Expect(u.Online).To(BeFalse())
+				Expect(u.Presence).To(Equal(protos.PresenceState_Offline))
+				close(done)
+			}, 0.3)
+
+			It("should hide status text", func(done Done) {
+				go api.SetPresence(ctx, &protos.PresenceParam{
+					State:      protos.PresenceState_Invisible,
+					StatusText: "hiding",
+				})
+				status := <-api.Onlines
+				Expect(status.StatusText).To(BeEmpty())
+				u, _ := api.GetUser(
+					context.WithValue(context.Background(), room.UserIDKey, u2.ID),
+					&protos.GetUserParam{Id: u1.ID})
+				Expect(u.StatusText).To(BeEmpty())
+				close(done)
+			}, 0.3)
+
+			It("should keep last seen when it went invisible after it's session ended", func(done Done) {
+				wentInvisible := time.Now().Add(-time.Hour).Round(time.Second)
+				db.Model(u1).Updates(map[string]interface{}{
+					"online":       true,
+					"presence":     room.PresenceInvisible,
+					"last_seen_at": wentInvisible,
+				})
+				go func() {
+					changed, err := api.SetOfflineWithoutSession(u1.ID)
+					Expect(err).To(BeNil())
+					Expect(changed).To(BeTrue())
+				}()
+				status := <-api.Onlines
+				Expect(status.Online).To(BeFalse())
+				Expect(status.LastSeenAt.Equal(wentInvisible)).To(BeTrue())
+				close(done)
+			}, 0.3)
+		})
+
+		When("presence set to offline", func() {
+			It("should return invalid argument error", func() {
+				err := api.SetPresence(ctx, &protos.PresenceParam{State: protos.PresenceState_Offline})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("presence sent on heartbeat", func() {
+			It("should change user presence", func(done Done) {
+				heartbeat := make(chan *protos.Heartbeat)
+				ctx, cancel := context.WithCancel(ctx)
+				go api.SubscribeOnlineStatus(ctx, heartbeat, make(chan *signaling.OnlineStatus), nil, make(chan *protos.OnlineStatus))
+				<-api.Onlines
+				go func() {
+					heartbeat <- &protos.Heartbeat{
+						Beat:     true,
+						Presence: &protos.PresenceParam{State: protos.PresenceState_Away},
+					}
+				}()
+				status := <-api.Onlines
+				Expect(status.Presence).To(Equal(room.PresenceAway))
+				cancel()
+				<-api.Onlines
+				close(done)
+			}, 0.3)
+		})
+
+		It("should send presence to subscribers", func(done Done) {
+			statusChanges := make(chan *signaling.OnlineStatus)
+			protoStatusChanges := make(chan *protos.OnlineStatus)
+			ctx2, cancel := context.WithCancel(context.WithValue(context.Background(), room.UserIDKey, u2.ID))
+			go api.SubscribeOnlineStatus(ctx2, make(chan *protos.Heartbeat), statusChanges, nil, protoStatusChanges)
+			<-api.Onlines
+			receiveSnapshot(protoStatusChanges, 5)
+			lastSeen := time.Now()
+			go func() {
+				statusChanges <- &signaling.OnlineStatus{
+					ID:         u1.ID,
+					Online:     true,
+					Presence:   room.PresenceDoNotDisturb,
+					StatusText: "focus",
+					LastSeenAt: &lastSeen,
+				}
+			}()
+			protoStatus := <-protoStatusChanges
+			Expect(protoStatus.Presence).To(Equal(protos.PresenceState_DoNotDisturb))
+			Expect(protoStatus.StatusText).To(Equal("focus"))
+			Expect(protoStatus.LastSeenAt).NotTo(BeNil())
+			cancel()
+			<-api.Onlines
+			close(done)
+		}, 0.3)
+	})
+
+	Describe("GetPresence", func() {
+		var ctx context.Context
+
+		JustBeforeEach(func() {
+			ctx = context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+		})
+
+		It("should return presence of requested users sharing room with user", func() {
+			res, err := api.GetPresence(ctx, &protos.GetPresenceParam{
+				UserIDs: []string{u1.ID, u2.ID, u4.ID, "unknown"},
+			})
+			Expect(err).To(BeNil())
+			Expect(res.Statuses).To(HaveLen(2))
+			Expect(res.Statuses[0].Id).To(Equal(u1.ID))
+			Expect(res.Statuses[0].Online).To(Equal(u1.Online))
+			Expect(res.Statuses[1].Id).To(Equal(u2.ID))
+			Expect(res.Statuses[1].Online).To(Equal(u2.Online))
+		})
+
+		When("user go invisible", func() {
+			It("should return user as offline", func() {
+				db.Model(u2).Updates(map[string]interface{}{
+					"online":   true,
+					"presence": room.PresenceInvisible,
+				})
+				res, err := api.GetPresence(ctx, &protos.GetPresenceParam{UserIDs: []string{u2.ID}})
+				Expect(err).To(BeNil())
+				Expect(res.Statuses).To(HaveLen(1))
+				Expect(res.Statuses[0].Online).To(BeFalse())
+				Expect(res.Statuses[0].Presence).To(Equal(protos.PresenceState_Offline))
+			})
+		})
+
+		When("token restricted to some rooms", func() {
+			It("should only return presence of members of those rooms", func() {
+				ctx = context.WithValue(ctx, auth.ScopeContextKey, &auth.Scope{
+					RoomIDs: []string{r2.ID},
+				})
+				res, err := api.GetPresence(ctx, &protos.GetPresenceParam{
+					UserIDs: []string{u2.ID, u3.ID},
+				})
+				Expect(err).To(BeNil())
+				Expect(res.Statuses).To(HaveLen(1))
+				Expect(res.Statuses[0].Id).To(Equal(u3.ID))
+			})
+		})
+	})
+
+	Describe("GetRoomPresence", func() {
+		It("should return presence of every room members", func() {
+			ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+			res, err := api.GetRoomPresence(ctx, &protos.GetRoomParam{Id: r1.ID})
+			Expect(err).To(BeNil())
+			Expect(res.Statuses).To(HaveLen(2))
+			online := map[string]bool{}
+			for _, s := range res.Statuses {
+				online[s.Id] = s.Online
+			}
+			Expect(online).To(Equal(map[string]bool{
+				u1.ID: u1.Online,
+				u2.ID: u2.Online,
+			}))
+		})
+
+		When("user not member of room", func() {
+			It("should return room not found error", func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				_, err := api.GetRoomPresence(ctx, &protos.GetRoomParam{Id: r3.ID})
+				Expect(err).To(MatchError(room.RoomNotFoundError))
+			})
+		})
+
+		When("room outside token scope", func() {
+			It("should return room not found error", func() {
+				ctx := context.WithValue(context.Background(), room.UserIDKey, u1.ID)
+				ctx = context.WithValue(ctx, auth.ScopeContextKey, &auth.Scope{
+					RoomIDs: []string{r2.ID},
+				})
+				_, err := api.GetRoomPresence(ctx, &protos.GetRoomParam{Id: r1.ID})
+				Expect(err).To(MatchError(room.RoomNotFoundError))
+			})
 		})
 	})
 })
@@ -0,0 +1,120 @@
+package signaling
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// DefaultDevicePinTimeout is how long device stay pinned without messages exchanged
+const DefaultDevicePinTimeout = time.Minute * 30
+
+// pinKey return key of device pin receiving messages sent by user to peer on a call
+// & connection, so other calls & connections between same peers start on all devices
+func pinKey(from string, to string, callID string, connectionID string) string {
+	return hashKey(from, to, callID, connectionID)
+}
+
+// pinDevice will route next messages sent by user to peer on a call & connection
+// to a device of that peer
+func (a *API) pinDevice(from string, to string, callID string, connectionID string, deviceID string) error {
+	if len(deviceID) == 0 {
+		return nil
+	}
+	return a.DB.Save(&DevicePinModel{
+		ID:        pinKey(from, to, callID, connectionID),
+		DeviceID:  deviceID,
+		ExpiresAt: time.Now().Add(DefaultDevicePinTimeout),
+	}).Error
+}
+
+// routeDevice return device of peer should receive message of a kind from user,
+// empty means message sent to all devices of peer. device requested by user
+// take precedence, pinned device only used while it's still subscribed
+func (a *API) routeDevice(
+	from string,
+	to string,
+	callID string,
+	connectionID string,
+	requested string,
+	kind string,
+) (string, error) {
+	if len(requested) > 0 {
+		return requested, nil
+	}
+	now := time.Now()
+	pin := &DevicePinModel{}
+	err := a.DB.
+		Where("id = ? AND expires_at > ?", pinKey(from, to, callID, connectionID), now).
+		First(pin).
+		Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	live, err := a.IsSubscribed(to, pin.DeviceID, kind, now)
+	if err != nil {
+		return "", err
+	}
+	if !live {
+		return "", nil
+	}
+	// keep pin while peers still exchanging messages
+	err = a.DB.Model(pin).Update("expires_at", now.Add(DefaultDevicePinTimeout)).Error
+	if err != nil {
+		return "", err
+	}
+	return pin.DeviceID, nil
+}
+
+// unpinDevice will send next messages from user to peer on a call & connection
+// to all devices of that peer
+func (a *API) unpinDevice(from string, to string, callID string, connectionID string) error {
+	return a.DB.
+		Where("id = ?", pinKey(from, to, callID, connectionID)).
+		Delete(&DevicePinModel{}).
+		Error
+}
+
+// PruneExpiredPins will remove device pins of peers no longer exchanging messages,
+// return number of pins removed
+func (a *API) PruneExpiredPins(now time.Time) (int64, error) {
+	res := a.DB.Where("expires_at <= ?", now).Delete(&DevicePinModel{})
+	return res.RowsAffected, res.Error
+}
+
+// acceptDevice return true when message routed to a device should be received by device,
+// client without device identifier receive all messages sent to it's user
+func acceptDevice(deviceID string, toDevice string, exceptDevice string) bool {
+	if len(deviceID) == 0 {
+		return true
+	}
+	if len(toDevice) > 0 && toDevice != deviceID {
+		return false
+	}
+	return exceptDevice != deviceID
+}
+
+// answeredElsewhere will tell other devices of user to stop ringing
+// after one of them answered offer from peer
+func (a *API) answeredElsewhere(
+	userID string,
+	deviceID string,
+	peerID string,
+	callID string,
+	connectionID string,
+) {
+	if len(deviceID) == 0 {
+		return
+	}
+	a.Commands <- &SDPCommand{
+		Type:         SDPAnsweredElsewhere,
+		From:         peerID,
+		To:           userID,
+		CallID:       callID,
+		ExceptDevice: deviceID,
+		ConnectionID: connectionID,
+	}
+}
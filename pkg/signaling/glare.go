@@ -0,0 +1,138 @@
+package signaling
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+)
+
+// DefaultOfferTimeout is how long offer without answer counted on glare detection
+const DefaultOfferTimeout = time.Second * 30
+
+// IsPolite return true when user is polite peer of a pair on perfect negotiation,
+// polite peer roll back it's offer when both peers offer at the same time
+func IsPolite(userID string, peerID string) bool {
+	return userID > peerID
+}
+
+// offerKey return key of outstanding offer between pair of peers on a call & connection,
+// offers on different connections between same peers never collide, key has fixed
+// length whatever ids client send
+func offerKey(userID string, peerID string, callID string, connectionID string) string {
+	if userID > peerID {
+		userID, peerID = peerID, userID
+	}
+	return hashKey(userID, peerID, callID, connectionID)
+}
+
+// hashKey return fixed length key of parts, parts encoded unambiguously
+// so different parts never share a key
+func hashKey(parts ...string) string {
+	encoded, _ := json.Marshal(parts)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// trackOffer will record outstanding offer from user to peer, return true collided
+// when peer has outstanding offer to user, impolite user take over the offer
+func (a *API) trackOffer(from string, to string, callID string, connectionID string) (bool, error) {
+	id := offerKey(from, to, callID, connectionID)
+	now := time.Now()
+	track := map[string]interface{}{
+		"offerer_id": from,
+		"expires_at": now.Add(DefaultOfferTimeout),
+	}
+	var err error
+	// retry when offer changed by other instance at the same time
+	for attempt := 0; attempt < 3; attempt++ {
+		// renegotiation or previous offer already expired
+		res := a.DB.Model(&OutstandingOfferModel{}).
+			Where("id = ? AND (offerer_id = ? OR expires_at <= ?)", id, from, now).
+			Updates(track)
+		if res.Error != nil {
+			return false, res.Error
+		}
+		if res.RowsAffected > 0 {
+			return false, nil
+		}
+		offer := &OutstandingOfferModel{}
+		err = a.DB.Where("id = ?", id).First(offer).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return false, err
+		}
+		if err == gorm.ErrRecordNotFound {
+			// no offer between peers yet
+			err = a.DB.Create(&OutstandingOfferModel{
+				ID:        id,
+				OffererID: from,
+				ExpiresAt: now.Add(DefaultOfferTimeout),
+			}).Error
+			if err == nil {
+				return false, nil
+			}
+			continue
+		}
+		if offer.OffererID == from || !offer.ExpiresAt.After(now) {
+			continue
+		}
+		if IsPolite(from, to) {
+			return true, nil
+		}
+		res = a.DB.Model(&OutstandingOfferModel{}).
+			Where("id = ? AND offerer_id = ?", id, to).
+			Updates(track)
+		if res.Error != nil {
+			return false, res.Error
+		}
+		if res.RowsAffected > 0 {
+			return true, nil
+		}
+	}
+	return false, err
+}
+
+// clearOffer will remove outstanding offer of offerer after answered or rolled back
+func (a *API) clearOffer(offerer string, peer string, callID string, connectionID string) error {
+	return a.DB.
+		Where("id = ? AND offerer_id = ?", offerKey(offerer, peer, callID, connectionID), offerer).
+		Delete(&OutstandingOfferModel{}).
+		Error
+}
+
+// PruneExpiredOffers will remove offers no longer counted on glare detection,
+// return number of offers removed
+func (a *API) PruneExpiredOffers(now time.Time) (int64, error) {
+	res := a.DB.Where("expires_at <= ?", now).Delete(&OutstandingOfferModel{})
+	return res.RowsAffected, res.Error
+}
+
+// rollback will tell loser of offer collision to roll back it's pending offer,
+// sent to loser device when known or device pinned for winner messages
+func (a *API) rollback(
+	loser string,
+	loserDevice string,
+	winner string,
+	callID string,
+	connectionID string,
+) (*protos.Delivery, error) {
+	toDevice, err := a.routeDevice(winner, loser, callID, connectionID, loserDevice, DeliveryKindSDP)
+	if err != nil {
+		return nil, err
+	}
+	command := &SDPCommand{
+		Type:         SDPRollback,
+		From:         winner,
+		To:           loser,
+		CallID:       callID,
+		Collision:    true,
+		ToDevice:     toDevice,
+		ConnectionID: connectionID,
+	}
+	return a.deliver(command.From, command.To, command.ToDevice, command.ConnectionID, DeliveryKindSDP, command, func() {
+		a.Commands <- command
+	})
+}
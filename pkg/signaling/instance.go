@@ -0,0 +1,169 @@
+package signaling
+
+import (
+	"sync"
+	"time"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+)
+
+// DefaultInstanceLease is how long instance counted alive without renewing it's lease
+const DefaultInstanceLease = time.Second * 30
+
+// KeepInstance will register this instance until released, instance lease renewed,
+// sessions of dead instances & users left online swept and expired pending messages,
+// offers, device pins & subscriptions pruned while it's alive. released instance
+// lease expired so it's sessions swept by other instances
+func (a *API) KeepInstance(lease time.Duration) (func(), error) {
+	if lease <= 0 {
+		lease = DefaultInstanceLease
+	}
+	id, err := utils.GenerateTokenID()
+	if err != nil {
+		return nil, err
+	}
+	err = a.DB.Create(&InstanceModel{
+		ID:        id,
+		ExpiresAt: time.Now().Add(lease),
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+	a.InstanceID = id
+	// renewal & release never run together, so released instance lease not renewed
+	var mu sync.Mutex
+	released := false
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(lease / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				mu.Lock()
+				if !released {
+					a.renewInstance(id, now.Add(lease))
+				}
+				mu.Unlock()
+				_, err := a.SweepDeadInstances(now)
+				if err != nil {
+					a.Logger.Errorf("failed to sweep dead instances -> %v", err)
+				}
+				_, err = a.SweepOfflineUsers(now)
+				if err != nil {
+					a.Logger.Errorf("failed to sweep offline users -> %v", err)
+				}
+				_, err = a.PruneExpiredPending(now)
+				if err != nil {
+					a.Logger.Errorf("failed to prune expired pending messages -> %v", err)
+				}
+				_, err = a.PruneExpiredOffers(now)
+				if err != nil {
+					a.Logger.Errorf("failed to prune expired offers -> %v", err)
+				}
+				_, err = a.PruneExpiredPins(now)
+				if err != nil {
+					a.Logger.Errorf("failed to prune expired device pins -> %v", err)
+				}
+				_, err = a.PruneExpiredSubscriptions(now)
+				if err != nil {
+					a.Logger.Errorf("failed to prune expired subscriptions -> %v", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		released = true
+		close(done)
+		err := a.DB.Model(&InstanceModel{}).
+			Where("id = ?", id).
+			Update("expires_at", time.Now()).
+			Error
+		if err != nil {
+			a.Logger.Errorf("failed to release instance -> %v", err)
+		}
+	}, nil
+}
+
+// renewInstance will extend instance lease, instance swept by other instance while
+// this instance paused registered again, it's sessions registered again on their own renewal
+func (a *API) renewInstance(id string, expiresAt time.Time) {
+	res := a.DB.Model(&InstanceModel{}).
+		Where("id = ?", id).
+		Update("expires_at", expiresAt)
+	if res.Error != nil {
+		a.Logger.Errorf("failed to renew instance lease -> %v", res.Error)
+		return
+	}
+	if res.RowsAffected > 0 {
+		return
+	}
+	a.Logger.Warnf("instance %s swept while alive, registering it again", id)
+	err := a.DB.Create(&InstanceModel{
+		ID:        id,
+		ExpiresAt: expiresAt,
+	}).Error
+	if err != nil {
+		a.Logger.Errorf("failed to register swept instance -> %v", err)
+	}
+}
+
+// SweepDeadInstances will remove sessions of instances with expired lease and set
+// their users offline when they have no session left, return users set offline
+func (a *API) SweepDeadInstances(now time.Time) ([]string, error) {
+	dead := []*InstanceModel{}
+	err := a.DB.Where("expires_at <= ?", now).Find(&dead).Error
+	if err != nil {
+		return nil, err
+	}
+	offline := []string{}
+	for _, instance := range dead {
+		// only one instance sweep a dead instance
+		res := a.DB.
+			Where("id = ? AND expires_at <= ?", instance.ID, now).
+			Delete(&InstanceModel{})
+		if res.Error != nil {
+			return offline, res.Error
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+		sessions := []*SubscriptionModel{}
+		err := a.DB.
+			Where("instance_id = ? AND kind = ?", instance.ID, PresenceSessionKind).
+			Find(&sessions).
+			Error
+		if err != nil {
+			return offline, err
+		}
+		err = a.DB.
+			Where("instance_id = ?", instance.ID).
+			Delete(&SubscriptionModel{}).
+			Error
+		if err != nil {
+			return offline, err
+		}
+		a.Logger.Infof("swept %d sessions of dead instance %s", len(sessions), instance.ID)
+		userIDs := []string{}
+		for _, session := range sessions {
+			if !utils.ContainString(userIDs, session.UserID) {
+				userIDs = append(userIDs, session.UserID)
+			}
+		}
+		for _, userID := range userIDs {
+			changed, err := a.SetOfflineWithoutSession(userID)
+			if err != nil {
+				return offline, err
+			}
+			if changed {
+				offline = append(offline, userID)
+			}
+		}
+	}
+	return offline, nil
+}
@@ -0,0 +1,66 @@
+package signaling
+
+import "time"
+
+// Models defined in signaling package
+var Models = []interface{}{
+	&PendingMessageModel{},
+	&PendingReceiptModel{},
+	&SubscriptionModel{},
+	&OutstandingOfferModel{},
+	&DevicePinModel{},
+	&InstanceModel{},
+}
+
+// PendingMessageModel define SDP or ICE candidate kept until recipient subscribe
+type PendingMessageModel struct {
+	ID           string    `gorm:"primary_key;not null;size:100"`
+	UserID       string    `gorm:"column:user_id;index;size:100"`
+	SenderID     string    `gorm:"column:sender_id;size:100"`
+	DeviceID     string    `gorm:"column:device_id;size:100"`
+	ConnectionID string    `gorm:"column:connection_id;size:100"`
+	Kind         string    `gorm:"column:kind;size:20"`
+	Payload      string    `gorm:"column:payload;type:text"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	ExpiresAt    time.Time `gorm:"column:expires_at;index"`
+}
+
+// PendingReceiptModel define device already received pending message sent to all devices
+// of user, message kept until expired so every device receive it once
+type PendingReceiptModel struct {
+	MessageID string `gorm:"primary_key;not null;size:100"`
+	DeviceID  string `gorm:"primary_key;size:100"`
+}
+
+// SubscriptionModel define user subscription counted until it's lease expired
+type SubscriptionModel struct {
+	ID         string    `gorm:"primary_key;not null;size:100"`
+	UserID     string    `gorm:"column:user_id;index;size:100"`
+	DeviceID   string    `gorm:"column:device_id;size:100"`
+	InstanceID string    `gorm:"column:instance_id;index;size:100"`
+	Kind       string    `gorm:"column:kind;size:20"`
+	ExpiresAt  time.Time `gorm:"column:expires_at;index"`
+}
+
+// OutstandingOfferModel define offer waiting answer between pair of peers,
+// one row per pair so concurrent offers collide
+type OutstandingOfferModel struct {
+	ID        string    `gorm:"primary_key;not null;size:300"`
+	OffererID string    `gorm:"column:offerer_id;size:100"`
+	ExpiresAt time.Time `gorm:"column:expires_at;index"`
+}
+
+// DevicePinModel define device of user receiving messages from a peer,
+// pinned after device answered offer from that peer
+type DevicePinModel struct {
+	ID        string    `gorm:"primary_key;not null;size:300"`
+	DeviceID  string    `gorm:"column:device_id;size:100"`
+	ExpiresAt time.Time `gorm:"column:expires_at;index"`
+}
+
+// InstanceModel define signaling instance alive until it's lease expired,
+// sessions of expired instance swept by other instances
+type InstanceModel struct {
+	ID        string    `gorm:"primary_key;not null;size:100"`
+	ExpiresAt time.Time `gorm:"column:expires_at;index"`
+}
@@ -0,0 +1,221 @@
+package signaling
+
+import (
+	"context"
+	"time"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/auth"
+	"go.sirus.dev/p2p-comm/signalling/pkg/room"
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+)
+
+// PresenceSessionKind is kind of subscription counted as user session on presence
+const PresenceSessionKind = "presence"
+
+// DefaultPresenceGracePeriod is how long user stay online after it's last session ended,
+// long enough to reconnect without seen offline
+const DefaultPresenceGracePeriod = time.Second * 10
+
+// KeepPresenceSession will count user session on any instance until released,
+// released session still counted during grace period so user reconnecting stay online
+func (a *API) KeepPresenceSession(userID string, deviceID string) (func(), error) {
+	return a.keepSubscribed(userID, deviceID, PresenceSessionKind, a.PresenceGrace)
+}
+
+// LeavePresence will set user offline after grace period when user has no other session,
+// session opened during grace period keep user online
+func (a *API) LeavePresence(userID string) {
+	leave := func() {
+		_, err := a.SetOfflineWithoutSession(userID)
+		if err != nil {
+			a.Logger.Errorf("failed to set user offline -> %v", err)
+		}
+	}
+	if a.PresenceGrace <= 0 {
+		leave()
+		return
+	}
+	time.AfterFunc(a.PresenceGrace, leave)
+}
+
+// SetOfflineWithoutSession will set online user offline when it has no live session
+// on any instance, return true when user status changed
+func (a *API) SetOfflineWithoutSession(userID string) (bool, error) {
+	now := time.Now()
+	sessions := a.DB.Model(&SubscriptionModel{}).
+		Select("1").
+		Where("user_id = ? AND kind = ? AND expires_at > ?", userID, PresenceSessionKind, now).
+		SubQuery()
+	res := a.DB.Model(&room.UserModel{}).
+		Where("id = ? AND online = ?", userID, true).
+		Where("NOT EXISTS ?", sessions).
+		Updates(map[string]interface{}{
+			"online":       false,
+			"last_seen_at": lastSeenUnlessInvisible(now),
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+	a.Logger.Debugf("set user %s offline after last session ended", userID)
+	return true, a.publishOnlineStatus(userID)
+}
+
+// SweepOfflineUsers will set online users without live session on any instance offline,
+// catch users left online when instance stopped during their grace period, return users set offline
+func (a *API) SweepOfflineUsers(now time.Time) ([]string, error) {
+	sessions := a.DB.Model(&SubscriptionModel{}).
+		Select("1").
+		Where("subscription_models.user_id = user_models.id").
+		Where("kind = ? AND expires_at > ?", PresenceSessionKind, now).
+		SubQuery()
+	userIDs := []string{}
+	err := a.DB.Model(&room.UserModel{}).
+		Where("online = ?", true).
+		Where("NOT EXISTS ?", sessions).
+		Pluck("id", &userIDs).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	offline := []string{}
+	for _, userID := range userIDs {
+		changed, err := a.SetOfflineWithoutSession(userID)
+		if err != nil {
+			return offline, err
+		}
+		if changed {
+			offline = append(offline, userID)
+		}
+	}
+	return offline, nil
+}
+
+// GetCoMemberIDs return users sharing at least one room allowed by token scope with user,
+// only their presence visible to user
+func (a *API) GetCoMemberIDs(me *room.UserModel, scope *auth.Scope) (map[string]bool, error) {
+	myRooms := []*room.RoomModel{}
+	err := a.DB.
+		Model(me).
+		Related(&myRooms, "Rooms").
+		Error
+	if err != nil {
+		return nil, err
+	}
+	roomIDs := []string{}
+	for _, r := range myRooms {
+		roomIDs = append(roomIDs, r.ID)
+	}
+	members := map[string]bool{}
+	roomIDs = scope.FilterRooms(roomIDs)
+	if len(roomIDs) == 0 {
+		return members, nil
+	}
+	rooms := []*room.RoomModel{}
+	err = a.DB.
+		Preload("Members").
+		Where("id IN (?)", roomIDs).
+		Find(&rooms).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rooms {
+		for _, member := range r.Members {
+			if member.ID != me.ID {
+				members[member.ID] = true
+			}
+		}
+	}
+	return members, nil
+}
+
+// GetPresence return online status of users sharing room with user,
+// other users left out of result
+func (a *API) GetPresence(ctx context.Context, param *protos.GetPresenceParam) (*protos.OnlineStatuses, error) {
+	user, err := a.GetUserContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	members, err := a.GetCoMemberIDs(user, auth.ScopeFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	members[user.ID] = true
+	visible := map[string]bool{}
+	for _, id := range param.UserIDs {
+		if members[id] {
+			visible[id] = true
+		}
+	}
+	statuses, err := a.getOnlineStatuses(visible)
+	if err != nil {
+		return nil, err
+	}
+	return onlineStatusesToProto(statuses), nil
+}
+
+// GetRoomPresence return online status of every members of room user participates in
+func (a *API) GetRoomPresence(ctx context.Context, param *protos.GetRoomParam) (*protos.OnlineStatuses, error) {
+	r, err := a.getMyRoom(ctx, param.Id)
+	if err != nil {
+		return nil, err
+	}
+	statuses := []*OnlineStatus{}
+	for _, member := range r.Members {
+		statuses = append(statuses, userOnlineStatus(member))
+	}
+	return onlineStatusesToProto(statuses), nil
+}
+
+// getOnlineStatuses return current online status of users
+func (a *API) getOnlineStatuses(userIDs map[string]bool) ([]*OnlineStatus, error) {
+	statuses := []*OnlineStatus{}
+	if len(userIDs) == 0 {
+		return statuses, nil
+	}
+	ids := []string{}
+	for id := range userIDs {
+		ids = append(ids, id)
+	}
+	users := []*room.UserModel{}
+	err := a.DB.
+		Where("id IN (?)", ids).
+		Order("id").
+		Find(&users).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		statuses = append(statuses, userOnlineStatus(user))
+	}
+	return statuses, nil
+}
+
+// onlineStatusesToProto convert list of online status to protobuf
+func onlineStatusesToProto(statuses []*OnlineStatus) *protos.OnlineStatuses {
+	protoStatuses := &protos.OnlineStatuses{
+		Statuses: []*protos.OnlineStatus{},
+	}
+	for _, status := range statuses {
+		protoStatuses.Statuses = append(protoStatuses.Statuses, onlineStatusToProto(status))
+	}
+	return protoStatuses
+}
+
+// membershipChanged return true when room event may change users sharing room with user
+func membershipChanged(userID string, members map[string]bool, event *room.RoomEvent) bool {
+	switch payload := event.Payload.(type) {
+	case *room.RoomParticipantEventPayload:
+		return payload.UserID == userID || utils.ContainString(payload.ParticipantIDs, userID)
+	case *room.RoomInstanceEventPayload:
+		return event.Event != room.RoomProfileUpdated && utils.ContainString(payload.MemberIDs, userID)
+	case *room.UserInstanceEventPayload:
+		return event.Event == room.UserRemoved && members[payload.ID]
+	}
+	return false
+}
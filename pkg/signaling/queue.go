@@ -0,0 +1,339 @@
+package signaling
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.sirus.dev/p2p-comm/signalling/pkg/utils"
+	"go.sirus.dev/p2p-comm/signalling/protos"
+)
+
+const (
+	// DeliveryKindSDP is kind of SDP command subscription & pending message
+	DeliveryKindSDP = "sdp"
+	// DeliveryKindICE is kind of ICE candidate subscription & pending message
+	DeliveryKindICE = "ice"
+)
+
+// DefaultSubscriptionLease is how long subscription of crashed instance still counted
+const DefaultSubscriptionLease = time.Minute
+
+// QueueConfig define pending queue of SDP & ICE candidates sent to user not subscribed,
+// zero TTL or size disable the queue
+type QueueConfig struct {
+	TTL               time.Duration `json:"ttl" mapstructure:"ttl"`
+	Size              int           `json:"size" mapstructure:"size"`
+	SubscriptionLease time.Duration `json:"subscription_lease" mapstructure:"subscription_lease"`
+}
+
+// Enabled return true when undelivered messages should be queued
+func (q *QueueConfig) Enabled() bool {
+	return q != nil && q.TTL > 0 && q.Size > 0
+}
+
+// Lease return lease of user subscription
+func (q *QueueConfig) Lease() time.Duration {
+	if q == nil || q.SubscriptionLease <= 0 {
+		return DefaultSubscriptionLease
+	}
+	return q.SubscriptionLease
+}
+
+// KeepSubscribed will register user device subscription of a kind until released,
+// subscription lease renewed while it's open
+func (a *API) KeepSubscribed(userID string, deviceID string, kind string) (func(), error) {
+	return a.keepSubscribed(userID, deviceID, kind, 0)
+}
+
+// keepSubscribed will register subscription until released,
+// released subscription still counted for linger duration
+func (a *API) keepSubscribed(
+	userID string,
+	deviceID string,
+	kind string,
+	linger time.Duration,
+) (func(), error) {
+	id, err := utils.GenerateTokenID()
+	if err != nil {
+		return nil, err
+	}
+	lease := a.Queue.Lease()
+	subscription := &SubscriptionModel{
+		ID:         id,
+		UserID:     userID,
+		DeviceID:   deviceID,
+		InstanceID: a.InstanceID,
+		Kind:       kind,
+		ExpiresAt:  time.Now().Add(lease),
+	}
+	err = a.DB.Create(subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	// renewal & release never run together, so released subscription not registered again
+	var mu sync.Mutex
+	released := false
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(lease / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				mu.Lock()
+				if !released {
+					a.renewSubscription(subscription, lease)
+				}
+				mu.Unlock()
+			}
+		}
+	}()
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		released = true
+		close(done)
+		var err error
+		if linger > 0 {
+			err = a.DB.Model(&SubscriptionModel{}).
+				Where("id = ?", id).
+				Update("expires_at", time.Now().Add(linger)).
+				Error
+		} else {
+			err = a.DB.Where("id = ?", id).Delete(&SubscriptionModel{}).Error
+		}
+		if err != nil {
+			a.Logger.Errorf("failed to release subscription -> %v", err)
+		}
+	}, nil
+}
+
+// renewSubscription will extend subscription lease, subscription swept by other
+// instance while this instance paused registered again since it's still open
+func (a *API) renewSubscription(subscription *SubscriptionModel, lease time.Duration) {
+	subscription.ExpiresAt = time.Now().Add(lease)
+	res := a.DB.Model(&SubscriptionModel{}).
+		Where("id = ?", subscription.ID).
+		Update("expires_at", subscription.ExpiresAt)
+	if res.Error != nil {
+		a.Logger.Errorf("failed to renew subscription -> %v", res.Error)
+		return
+	}
+	if res.RowsAffected > 0 {
+		return
+	}
+	a.Logger.Warnf("subscription %s of user %s swept while open, registering it again", subscription.ID, subscription.UserID)
+	subscription.InstanceID = a.InstanceID
+	err := a.DB.Create(subscription).Error
+	if err != nil {
+		a.Logger.Errorf("failed to register swept subscription -> %v", err)
+		return
+	}
+	// user set offline when it's session swept
+	if subscription.Kind == PresenceSessionKind {
+		err = a.SetUserOnlineStatus(subscription.UserID, true)
+		if err != nil {
+			a.Logger.Errorf("failed to set user online -> %v", err)
+		}
+	}
+}
+
+// PruneExpiredSubscriptions will remove subscriptions no longer counted, return number removed
+func (a *API) PruneExpiredSubscriptions(now time.Time) (int64, error) {
+	res := a.DB.Where("expires_at <= ?", now).Delete(&SubscriptionModel{})
+	return res.RowsAffected, res.Error
+}
+
+// IsSubscribed return true when user subscribed to a kind of message on any instance,
+// only subscription of the device counted when device given
+func (a *API) IsSubscribed(userID string, deviceID string, kind string, now time.Time) (bool, error) {
+	count := 0
+	query := a.DB.Model(&SubscriptionModel{}).
+		Where("user_id = ? AND kind = ? AND expires_at > ?", userID, kind, now)
+	if len(deviceID) > 0 {
+		query = query.Where("device_id = ?", deviceID)
+	}
+	err := query.Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// HasSession return true when user has any open subscription on any instance
+func (a *API) HasSession(userID string, now time.Time) (bool, error) {
+	count := 0
+	err := a.DB.Model(&SubscriptionModel{}).
+		Where("user_id = ? AND expires_at > ?", userID, now).
+		Count(&count).
+		Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// deliver will publish message when target subscribed,
+// otherwise queue it until target subscribe
+func (a *API) deliver(
+	from string,
+	to string,
+	toDevice string,
+	connectionID string,
+	kind string,
+	message interface{},
+	publish func(),
+) (*protos.Delivery, error) {
+	now := time.Now()
+	live, err := a.IsSubscribed(to, toDevice, kind, now)
+	if err != nil {
+		return nil, err
+	}
+	if live {
+		publish()
+		return &protos.Delivery{Status: protos.DeliveryStatus_Live}, nil
+	}
+	if !a.Queue.Enabled() {
+		// no queue, keep publishing for subscriber not registered yet
+		publish()
+		return &protos.Delivery{Status: protos.DeliveryStatus_Unconfirmed}, nil
+	}
+
+	// drop expired messages and check queue size of sender,
+	// so one sender can't fill target queue for others
+	_, err = a.deletePending("user_id = ? AND expires_at <= ?", to, now)
+	if err != nil {
+		return nil, err
+	}
+	count := 0
+	err = a.DB.Model(&PendingMessageModel{}).
+		Where("user_id = ? AND sender_id = ?", to, from).
+		Count(&count).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if count >= a.Queue.Size {
+		return &protos.Delivery{Status: protos.DeliveryStatus_Expired}, nil
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	id, err := utils.GenerateTokenID()
+	if err != nil {
+		return nil, err
+	}
+	err = a.DB.Create(&PendingMessageModel{
+		ID:           id,
+		UserID:       to,
+		SenderID:     from,
+		DeviceID:     toDevice,
+		ConnectionID: connectionID,
+		Kind:         kind,
+		Payload:      string(payload),
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(a.Queue.TTL),
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// target may subscribed before message queued, take it back and send it live
+	// unless a device already drained it
+	live, err = a.IsSubscribed(to, toDevice, kind, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if live {
+		receipts := a.DB.Model(&PendingReceiptModel{}).Select("message_id").SubQuery()
+		res := a.DB.
+			Where("id = ? AND id NOT IN (?)", id, receipts).
+			Delete(&PendingMessageModel{})
+		if res.Error == nil && res.RowsAffected == 1 {
+			publish()
+			return &protos.Delivery{Status: protos.DeliveryStatus_Live}, nil
+		}
+	}
+	return &protos.Delivery{Status: protos.DeliveryStatus_Queued}, nil
+}
+
+// DrainPending will take unexpired pending messages of a kind sent to user device
+// or any device of user, message sent to a device taken by one subscriber only,
+// message sent to all devices kept until expired and received once by each device
+func (a *API) DrainPending(userID string, deviceID string, kind string) ([]*PendingMessageModel, error) {
+	pending := []*PendingMessageModel{}
+	err := a.DB.
+		Where("user_id = ? AND kind = ? AND expires_at > ?", userID, kind, time.Now()).
+		Where("device_id = ? OR device_id = ?", "", deviceID).
+		Order("created_at").
+		Find(&pending).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	taken := []*PendingMessageModel{}
+	for _, message := range pending {
+		ok, err := a.takePending(message, deviceID)
+		if err != nil {
+			return taken, err
+		}
+		if ok {
+			taken = append(taken, message)
+		}
+	}
+	return taken, nil
+}
+
+// takePending will take pending message for device, return false
+// when message already taken by other subscriber or by this device
+func (a *API) takePending(message *PendingMessageModel, deviceID string) (bool, error) {
+	if len(message.DeviceID) > 0 {
+		res := a.DB.Where("id = ?", message.ID).Delete(&PendingMessageModel{})
+		return res.RowsAffected == 1, res.Error
+	}
+	err := a.DB.Create(&PendingReceiptModel{
+		MessageID: message.ID,
+		DeviceID:  deviceID,
+	}).Error
+	if err == nil {
+		return true, nil
+	}
+	count := 0
+	countErr := a.DB.Model(&PendingReceiptModel{}).
+		Where("message_id = ? AND device_id = ?", message.ID, deviceID).
+		Count(&count).
+		Error
+	if countErr == nil && count > 0 {
+		return false, nil
+	}
+	return false, err
+}
+
+// PruneExpiredPending will remove expired pending messages of every recipient with their
+// receipts, so queue of recipient never subscribing again won't grow, return number removed
+func (a *API) PruneExpiredPending(now time.Time) (int64, error) {
+	return a.deletePending("expires_at <= ?", now)
+}
+
+// deletePending will remove pending messages matching condition with their receipts,
+// return number of messages removed
+func (a *API) deletePending(query string, args ...interface{}) (int64, error) {
+	messages := a.DB.Model(&PendingMessageModel{}).
+		Select("id").
+		Where(query, args...).
+		SubQuery()
+	err := a.DB.
+		Where("message_id IN (?)", messages).
+		Delete(&PendingReceiptModel{}).
+		Error
+	if err != nil {
+		return 0, err
+	}
+	res := a.DB.Where(query, args...).Delete(&PendingMessageModel{})
+	return res.RowsAffected, res.Error
+}
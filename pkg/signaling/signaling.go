@@ -2,6 +2,8 @@ package signaling
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"go.sirus.dev/p2p-comm/signalling/pkg/room"
 	"go.sirus.dev/p2p-comm/signalling/protos"
@@ -12,39 +14,82 @@ const (
 	SDPOferrCommand = "chat.sdp.offer"
 	// SDPAnswerCommand emitted when peer send sdp answer command to another peer oferring
 	SDPAnswerCommand = "chat.sdp.answer"
+	// SDPPranswerCommand emitted when peer send provisional sdp answer to another peer
+	SDPPranswerCommand = "chat.sdp.pranswer"
+	// SDPRollbackCommand emitted when peer rollback it's pending sdp offer
+	SDPRollbackCommand = "chat.sdp.rollback"
 	// ICECandidateOffer emitted during candidate negotiations between peers
 	ICECandidateOffer = "chat.ice-candidate"
 	// OnlineStatusChangeEvent emitted when user are offline / online
 	OnlineStatusChangeEvent = "chat.user.online-change"
+	// PeerSignal emitted when peer send app-level signal to other peers
+	PeerSignal = "chat.signal"
 )
 
+// types of session description
 const (
 	SDPOffer    = "offer"
 	SDPAnswer   = "answer"
 	SDPPranswer = "pranswer"
 	SDPRollback = "rollback"
+	// SDPAnsweredElsewhere sent to other devices of user after one of them answered
+	SDPAnsweredElsewhere = "answered-elsewhere"
 )
 
-// SDPCommand related to session description command emitted by peers
+// SDPCommand related to session description command emitted by peers,
+// empty target device send it to all devices of target user
 type SDPCommand struct {
-	From        string `json:"from"`
-	To          string `json:"to"`
-	Type        string `json:"type"`
-	Description string `json:"description"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	Type         string `json:"type"`
+	Description  string `json:"description"`
+	CallID       string `json:"call_id,omitempty"`
+	Collision    bool   `json:"collision,omitempty"`
+	FromDevice   string `json:"from_device,omitempty"`
+	ToDevice     string `json:"to_device,omitempty"`
+	ExceptDevice string `json:"except_device,omitempty"`
+	ConnectionID string `json:"connection_id,omitempty"`
 }
 
-// ICEOffer contain ICE candidate offer from user to another user
+// ICEOffer contain ICE candidate offer from user to another user,
+// candidate empty on end of candidates & ICE restart marker
 type ICEOffer struct {
-	From      string `json:"from"`
-	To        string `json:"to"`
-	IsRemote  bool   `json:"isRemote"`
-	Candidate string `json:"candidate"`
+	From             string  `json:"from"`
+	To               string  `json:"to"`
+	IsRemote         bool    `json:"isRemote"`
+	Candidate        string  `json:"candidate"`
+	SDPMid           string  `json:"sdpMid,omitempty"`
+	SDPMLineIndex    *uint32 `json:"sdpMLineIndex,omitempty"`
+	UsernameFragment string  `json:"usernameFragment,omitempty"`
+	EndOfCandidates  bool    `json:"endOfCandidates,omitempty"`
+	ICERestart       bool    `json:"iceRestart,omitempty"`
+	FromDevice       string  `json:"fromDevice,omitempty"`
+	ToDevice         string  `json:"toDevice,omitempty"`
+	ConnectionID     string  `json:"connectionID,omitempty"`
+	CallID           string  `json:"callID,omitempty"`
 }
 
-// OnlineStatus emitted when user with `id` has online state change
+// Signal contain app-level signal from user to another user or room members,
+// recipients resolved when signal sent to a room
+type Signal struct {
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	RoomID     string          `json:"roomID"`
+	Recipients []string        `json:"recipients"`
+	Type       string          `json:"type"`
+	Payload    []byte          `json:"payload"`
+	Data       json.RawMessage `json:"data"`
+	Time       time.Time       `json:"time"`
+}
+
+// OnlineStatus emitted when user with `id` has online state or presence change,
+// presence is the one seen by other users
 type OnlineStatus struct {
-	ID     string `json:"id"`
-	Online bool   `json:"online"`
+	ID         string     `json:"id"`
+	Online     bool       `json:"online"`
+	Presence   string     `json:"presence"`
+	StatusText string     `json:"status_text"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
 }
 
 // ISignaling act as intermediary to give signal from peer to other peer
@@ -58,13 +103,17 @@ type ISignaling interface {
 	SetICEOffers(offers chan *ICEOffer)
 	GetOnlineStatus() chan *OnlineStatus
 	SetOnlineStatus(statusChanges chan *OnlineStatus)
+	GetSignals() chan *Signal
+	SetSignals(signals chan *Signal)
 	MyProfile(ctx context.Context) (*protos.Profile, error)
+	MyICEServers(ctx context.Context) (*protos.ICEServers, error)
 	UpdateProfile(ctx context.Context, param *protos.UpdateProfileParam) (*protos.Profile, error)
 	MyRooms(ctx context.Context) (*protos.Rooms, error)
 	MyRoomInfo(ctx context.Context, param *protos.GetRoomParam) (*protos.Room, error)
 	GetUser(ctx context.Context, param *protos.GetUserParam) (*protos.User, error)
-	OfferSDP(ctx context.Context, param *protos.SDPParam) error
-	AnswerSDP(ctx context.Context, param *protos.SDPParam) error
+	OfferSDP(ctx context.Context, param *protos.SDPParam) (*protos.Delivery, error)
+	AnswerSDP(ctx context.Context, param *protos.SDPParam) (*protos.Delivery, error)
+	SendSDP(ctx context.Context, param *protos.SDPParam) (*protos.Delivery, error)
 	SubscribeSDPCommand(
 		ctx context.Context,
 		commands <-chan *SDPCommand,
@@ -75,7 +124,7 @@ type ISignaling interface {
 		commands <-chan *room.RoomEvent,
 		protoEvents chan<- *protos.RoomEvent,
 	) error
-	SendICECandidate(ctx context.Context, param *protos.ICEParam) error
+	SendICECandidate(ctx context.Context, param *protos.ICEParam) (*protos.Delivery, error)
 	SubscribeICECandidate(
 		ctx context.Context,
 		offers <-chan *ICEOffer,
@@ -85,6 +134,16 @@ type ISignaling interface {
 		ctx context.Context,
 		heartbeat <-chan *protos.Heartbeat,
 		statusChanges <-chan *OnlineStatus,
+		roomEvents <-chan *room.RoomEvent,
 		protoStatusChanges chan<- *protos.OnlineStatus,
 	) error
+	SetPresence(ctx context.Context, param *protos.PresenceParam) error
+	GetPresence(ctx context.Context, param *protos.GetPresenceParam) (*protos.OnlineStatuses, error)
+	GetRoomPresence(ctx context.Context, param *protos.GetRoomParam) (*protos.OnlineStatuses, error)
+	SendSignal(ctx context.Context, param *protos.SignalParam) error
+	SubscribeSignal(
+		ctx context.Context,
+		signals <-chan *Signal,
+		protoSignals chan<- *protos.Signal,
+	) error
 }
@@ -0,0 +1,27 @@
+package signaling
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// DefaultTURNCredentialTTL is lifetime of TURN credential when not configured
+const DefaultTURNCredentialTTL = time.Hour * 24
+
+// GenerateTURNCredential will generate time-limited TURN credential of a user
+// following TURN REST API (coturn use-auth-secret), username is
+// `<expiration unix time>:<user id>` and credential is base64 HMAC-SHA1 of username
+func GenerateTURNCredential(
+	secret string,
+	userID string,
+	expiresAt time.Time,
+) (string, string) {
+	username := fmt.Sprintf("%d:%s", expiresAt.Unix(), userID)
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write([]byte(username))
+	credential := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return username, credential
+}
@@ -1,14 +1,80 @@
 package utils
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
 	"fmt"
 
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
+const (
+	// TokenExpiredError returned when token has passed it's expiration time
+	TokenExpiredError = "token expired"
+	// UnknownKeyError returned when token signed by key not in key set
+	UnknownKeyError = "unknown signing key"
+)
+
+// KeyIDHeader is token header contain id of key used to sign it
+const KeyIDHeader = "kid"
+
 // Claims is alias of jwt.MapClaims
 type Claims = jwt.MapClaims
 
+// SigningKey is asymmetric key used to sign & verify token,
+// key without private key can only be used to verify token
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+// ParseSigningKey will parse PEM encoded RSA (RS256) or ECDSA (ES256) key,
+// public key derived from private key when only private key given
+func ParseSigningKey(
+	id string,
+	algorithm string,
+	privatePEM []byte,
+	publicPEM []byte,
+) (*SigningKey, error) {
+	key := &SigningKey{ID: id, Algorithm: algorithm}
+	var err error
+	switch algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		if len(privatePEM) > 0 {
+			var private *rsa.PrivateKey
+			private, err = jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+			if err == nil {
+				key.PrivateKey = private
+				key.PublicKey = &private.PublicKey
+			}
+		} else {
+			key.PublicKey, err = jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+		}
+	case jwt.SigningMethodES256.Alg():
+		if len(privatePEM) > 0 {
+			var private *ecdsa.PrivateKey
+			private, err = jwt.ParseECPrivateKeyFromPEM(privatePEM)
+			if err == nil {
+				key.PrivateKey = private
+				key.PublicKey = &private.PublicKey
+			}
+		} else {
+			key.PublicKey, err = jwt.ParseECPublicKeyFromPEM(publicPEM)
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported signing algorithm: %s", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 // GenerateToken will generate JWT token
 func GenerateToken(secret string, payload Claims) (*string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
@@ -16,6 +82,27 @@ func GenerateToken(secret string, payload Claims) (*string, error) {
 	return &ts, err
 }
 
+// GenerateTokenWithKey will generate JWT token signed by asymmetric key
+func GenerateTokenWithKey(key *SigningKey, payload Claims) (*string, error) {
+	if key.PrivateKey == nil {
+		return nil, fmt.Errorf("signing key %s has no private key", key.ID)
+	}
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), payload)
+	token.Header[KeyIDHeader] = key.ID
+	ts, err := token.SignedString(key.PrivateKey)
+	return &ts, err
+}
+
+// GenerateTokenID will generate random unique identifier of a token
+func GenerateTokenID() (string, error) {
+	id := make([]byte, 16)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
 // ValidateToken will validate wether a token valid
 func ValidateToken(secret string, tokenString string) (Claims, error) {
 	// validate token
@@ -26,7 +113,42 @@ func ValidateToken(secret string, tokenString string) (Claims, error) {
 		}
 		return []byte(secret), nil
 	})
+	return getTokenClaims(token, err)
+}
+
+// ValidateTokenWithKeys will validate wether a token signed by one of asymmetric keys
+func ValidateTokenWithKeys(keys map[string]*SigningKey, tokenString string) (Claims, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header[KeyIDHeader].(string)
+		key, ok := keys[kid]
+		if !ok {
+			return nil, fmt.Errorf(UnknownKeyError)
+		}
+		// prevent algorithm substitution
+		if t.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("Unexpected signing method: %v", t.Header["alg"])
+		}
+		return key.PublicKey, nil
+	})
+	return getTokenClaims(token, err)
+}
+
+// GetTokenKeyID return id of key used to sign token without validating it
+func GetTokenKeyID(tokenString string) (string, error) {
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, Claims{})
 	if err != nil {
+		return "", err
+	}
+	kid, _ := token.Header[KeyIDHeader].(string)
+	return kid, nil
+}
+
+// getTokenClaims return claims of parsed token
+func getTokenClaims(token *jwt.Token, err error) (Claims, error) {
+	if err != nil {
+		if verr, ok := err.(*jwt.ValidationError); ok && verr.Errors&jwt.ValidationErrorExpired != 0 {
+			return nil, fmt.Errorf(TokenExpiredError)
+		}
 		return nil, err
 	}
 	payload, ok := token.Claims.(Claims)
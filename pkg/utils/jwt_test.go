@@ -1,6 +1,14 @@
 package utils_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -50,4 +58,86 @@ var _ = Describe("Token", func() {
 			Expect(err).NotTo(BeNil())
 		})
 	})
+
+	Context("with expired token", func() {
+		It("should be rejected as expired", func() {
+			secret := "jansdandn1dandand0238r"
+			claim := map[string]interface{}{
+				"claim_1": "content_1",
+				"exp":     time.Now().Add(-time.Minute).Unix(),
+			}
+			token, err := utils.GenerateToken(secret, claim)
+			Expect(err).To(BeNil())
+			_, err = utils.ValidateToken(secret, *token)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal(utils.TokenExpiredError))
+		})
+	})
+
+	Context("with asymmetric key", func() {
+		It("should verify RS256 signed token", func() {
+			private, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).To(BeNil())
+			privatePEM := pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(private),
+			})
+			key, err := utils.ParseSigningKey("rsa-1", "RS256", privatePEM, nil)
+			Expect(err).To(BeNil())
+			token, err := utils.GenerateTokenWithKey(key, utils.Claims{"claim_1": "content_1"})
+			Expect(err).To(BeNil())
+			kid, err := utils.GetTokenKeyID(*token)
+			Expect(err).To(BeNil())
+			Expect(kid).To(Equal("rsa-1"))
+			claims, err := utils.ValidateTokenWithKeys(map[string]*utils.SigningKey{"rsa-1": key}, *token)
+			Expect(err).To(BeNil())
+			Expect(claims["claim_1"]).To(Equal("content_1"))
+		})
+
+		It("should verify ES256 signed token using public key only", func() {
+			private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).To(BeNil())
+			privateDER, err := x509.MarshalECPrivateKey(private)
+			Expect(err).To(BeNil())
+			publicDER, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+			Expect(err).To(BeNil())
+			signer, err := utils.ParseSigningKey("ec-1", "ES256",
+				pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateDER}), nil)
+			Expect(err).To(BeNil())
+			verifier, err := utils.ParseSigningKey("ec-1", "ES256",
+				nil, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+			Expect(err).To(BeNil())
+			token, err := utils.GenerateTokenWithKey(signer, utils.Claims{"claim_1": "content_1"})
+			Expect(err).To(BeNil())
+			claims, err := utils.ValidateTokenWithKeys(map[string]*utils.SigningKey{"ec-1": verifier}, *token)
+			Expect(err).To(BeNil())
+			Expect(claims["claim_1"]).To(Equal("content_1"))
+			_, err = utils.GenerateTokenWithKey(verifier, utils.Claims{})
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should reject token signed by unknown key", func() {
+			private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).To(BeNil())
+			key := &utils.SigningKey{ID: "ec-1", Algorithm: "ES256",
+				PrivateKey: private, PublicKey: &private.PublicKey}
+			token, err := utils.GenerateTokenWithKey(key, utils.Claims{})
+			Expect(err).To(BeNil())
+			_, err = utils.ValidateTokenWithKeys(map[string]*utils.SigningKey{}, *token)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should reject HMAC token using asymmetric key id", func() {
+			private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).To(BeNil())
+			key := &utils.SigningKey{ID: "ec-1", Algorithm: "ES256",
+				PrivateKey: private, PublicKey: &private.PublicKey}
+			forged := &utils.SigningKey{ID: "ec-1", Algorithm: "HS256",
+				PrivateKey: []byte("secret")}
+			token, err := utils.GenerateTokenWithKey(forged, utils.Claims{})
+			Expect(err).To(BeNil())
+			_, err = utils.ValidateTokenWithKeys(map[string]*utils.SigningKey{"ec-1": key}, *token)
+			Expect(err).NotTo(BeNil())
+		})
+	})
 })
@@ -8,7 +8,9 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	_struct "github.com/golang/protobuf/ptypes/struct"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -26,6 +28,44 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// presence seen by other users, invisible user seen as offline
+type PresenceState int32
+
+const (
+	PresenceState_Offline      PresenceState = 0
+	PresenceState_Online       PresenceState = 1
+	PresenceState_Away         PresenceState = 2
+	PresenceState_Busy         PresenceState = 3
+	PresenceState_DoNotDisturb PresenceState = 4
+	PresenceState_Invisible    PresenceState = 5
+)
+
+var PresenceState_name = map[int32]string{
+	0: "Offline",
+	1: "Online",
+	2: "Away",
+	3: "Busy",
+	4: "DoNotDisturb",
+	5: "Invisible",
+}
+
+var PresenceState_value = map[string]int32{
+	"Offline":      0,
+	"Online":       1,
+	"Away":         2,
+	"Busy":         3,
+	"DoNotDisturb": 4,
+	"Invisible":    5,
+}
+
+func (x PresenceState) String() string {
+	return proto.EnumName(PresenceState_name, int32(x))
+}
+
+func (PresenceState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{0}
+}
+
 type ICECredentialType int32
 
 const (
@@ -51,7 +91,113 @@ func (x ICECredentialType) String() string {
 }
 
 func (ICECredentialType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{0}
+	return fileDescriptor_39f66308029891ad, []int{1}
+}
+
+type CallStates int32
+
+const (
+	CallStates_Ringing   CallStates = 0
+	CallStates_Active    CallStates = 1
+	CallStates_Rejected  CallStates = 2
+	CallStates_Cancelled CallStates = 3
+	CallStates_Missed    CallStates = 4
+	CallStates_Ended     CallStates = 5
+)
+
+var CallStates_name = map[int32]string{
+	0: "Ringing",
+	1: "Active",
+	2: "Rejected",
+	3: "Cancelled",
+	4: "Missed",
+	5: "Ended",
+}
+
+var CallStates_value = map[string]int32{
+	"Ringing":   0,
+	"Active":    1,
+	"Rejected":  2,
+	"Cancelled": 3,
+	"Missed":    4,
+	"Ended":     5,
+}
+
+func (x CallStates) String() string {
+	return proto.EnumName(CallStates_name, int32(x))
+}
+
+func (CallStates) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{2}
+}
+
+type RoomCallEvents int32
+
+const (
+	RoomCallEvents_ParticipantJoined RoomCallEvents = 0
+	RoomCallEvents_ParticipantLeft   RoomCallEvents = 1
+)
+
+var RoomCallEvents_name = map[int32]string{
+	0: "ParticipantJoined",
+	1: "ParticipantLeft",
+}
+
+var RoomCallEvents_value = map[string]int32{
+	"ParticipantJoined": 0,
+	"ParticipantLeft":   1,
+}
+
+func (x RoomCallEvents) String() string {
+	return proto.EnumName(RoomCallEvents_name, int32(x))
+}
+
+func (RoomCallEvents) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{3}
+}
+
+type DeliveryStatus int32
+
+const (
+	// delivery not tracked, such as signals & failed messages
+	DeliveryStatus_Unspecified DeliveryStatus = 0
+	// target subscribed and message sent to it
+	DeliveryStatus_Live DeliveryStatus = 1
+	// target not subscribed, message kept until target subscribe or expired
+	DeliveryStatus_Queued DeliveryStatus = 2
+	// target not subscribed and message can't be queued
+	DeliveryStatus_Expired DeliveryStatus = 3
+	// offer collided with target offer and dropped, sender must roll back
+	DeliveryStatus_Collided DeliveryStatus = 4
+	// queue disabled and target not subscribed, message published in case
+	// target subscribe before it's subscription registered
+	DeliveryStatus_Unconfirmed DeliveryStatus = 5
+)
+
+var DeliveryStatus_name = map[int32]string{
+	0: "Unspecified",
+	1: "Live",
+	2: "Queued",
+	3: "Expired",
+	4: "Collided",
+	5: "Unconfirmed",
+}
+
+var DeliveryStatus_value = map[string]int32{
+	"Unspecified": 0,
+	"Live":        1,
+	"Queued":      2,
+	"Expired":     3,
+	"Collided":    4,
+	"Unconfirmed": 5,
+}
+
+func (x DeliveryStatus) String() string {
+	return proto.EnumName(DeliveryStatus_name, int32(x))
+}
+
+func (DeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{4}
 }
 
 type SDPTypes int32
@@ -61,6 +207,9 @@ const (
 	SDPTypes_Answer   SDPTypes = 1
 	SDPTypes_Pranswer SDPTypes = 2
 	SDPTypes_Rollback SDPTypes = 3
+	// sent by server to other devices of user after one of them answered offer
+	// from sender, those devices should stop ringing
+	SDPTypes_AnsweredElsewhere SDPTypes = 4
 )
 
 var SDPTypes_name = map[int32]string{
@@ -68,13 +217,15 @@ var SDPTypes_name = map[int32]string{
 	1: "Answer",
 	2: "Pranswer",
 	3: "Rollback",
+	4: "AnsweredElsewhere",
 }
 
 var SDPTypes_value = map[string]int32{
-	"Offer":    0,
-	"Answer":   1,
-	"Pranswer": 2,
-	"Rollback": 3,
+	"Offer":             0,
+	"Answer":            1,
+	"Pranswer":          2,
+	"Rollback":          3,
+	"AnsweredElsewhere": 4,
 }
 
 func (x SDPTypes) String() string {
@@ -82,7 +233,7 @@ func (x SDPTypes) String() string {
 }
 
 func (SDPTypes) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{1}
+	return fileDescriptor_39f66308029891ad, []int{5}
 }
 
 type RoomEvents int32
@@ -125,7 +276,7 @@ func (x RoomEvents) String() string {
 }
 
 func (RoomEvents) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{2}
+	return fileDescriptor_39f66308029891ad, []int{6}
 }
 
 type NewUserParam struct {
@@ -223,13 +374,17 @@ func (m *GetUserParam) GetId() string {
 }
 
 type User struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Photo                string   `protobuf:"bytes,3,opt,name=photo,proto3" json:"photo,omitempty"`
-	Online               bool     `protobuf:"varint,4,opt,name=online,proto3" json:"online,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id         string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Photo      string        `protobuf:"bytes,3,opt,name=photo,proto3" json:"photo,omitempty"`
+	Online     bool          `protobuf:"varint,4,opt,name=online,proto3" json:"online,omitempty"`
+	Presence   PresenceState `protobuf:"varint,5,opt,name=presence,proto3,enum=protos.PresenceState" json:"presence,omitempty"`
+	StatusText string        `protobuf:"bytes,6,opt,name=statusText,proto3" json:"statusText,omitempty"`
+	// last time user seen online, null when user never seen online
+	LastSeenAt           *timestamp.Timestamp `protobuf:"bytes,7,opt,name=lastSeenAt,proto3" json:"lastSeenAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *User) Reset()         { *m = User{} }
@@ -285,12 +440,36 @@ func (m *User) GetOnline() bool {
 	return false
 }
 
+func (m *User) GetPresence() PresenceState {
+	if m != nil {
+		return m.Presence
+	}
+	return PresenceState_Offline
+}
+
+func (m *User) GetStatusText() string {
+	if m != nil {
+		return m.StatusText
+	}
+	return ""
+}
+
+func (m *User) GetLastSeenAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return nil
+}
+
 type OnlineStatus struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Online               bool     `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Online               bool                 `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	Presence             PresenceState        `protobuf:"varint,3,opt,name=presence,proto3,enum=protos.PresenceState" json:"presence,omitempty"`
+	StatusText           string               `protobuf:"bytes,4,opt,name=statusText,proto3" json:"statusText,omitempty"`
+	LastSeenAt           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastSeenAt,proto3" json:"lastSeenAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *OnlineStatus) Reset()         { *m = OnlineStatus{} }
@@ -332,11 +511,34 @@ func (m *OnlineStatus) GetOnline() bool {
 	return false
 }
 
+func (m *OnlineStatus) GetPresence() PresenceState {
+	if m != nil {
+		return m.Presence
+	}
+	return PresenceState_Offline
+}
+
+func (m *OnlineStatus) GetStatusText() string {
+	if m != nil {
+		return m.StatusText
+	}
+	return ""
+}
+
+func (m *OnlineStatus) GetLastSeenAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return nil
+}
+
 type Heartbeat struct {
-	Beat                 bool     `protobuf:"varint,2,opt,name=beat,proto3" json:"beat,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Beat bool `protobuf:"varint,2,opt,name=beat,proto3" json:"beat,omitempty"`
+	// change presence along with heartbeat, null keep current presence
+	Presence             *PresenceParam `protobuf:"bytes,3,opt,name=presence,proto3" json:"presence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *Heartbeat) Reset()         { *m = Heartbeat{} }
@@ -371,6 +573,141 @@ func (m *Heartbeat) GetBeat() bool {
 	return false
 }
 
+func (m *Heartbeat) GetPresence() *PresenceParam {
+	if m != nil {
+		return m.Presence
+	}
+	return nil
+}
+
+type PresenceParam struct {
+	// any state except offline, presence shown when user connected
+	State PresenceState `protobuf:"varint,1,opt,name=state,proto3,enum=protos.PresenceState" json:"state,omitempty"`
+	// custom status text, empty to clear it
+	StatusText           string   `protobuf:"bytes,2,opt,name=statusText,proto3" json:"statusText,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PresenceParam) Reset()         { *m = PresenceParam{} }
+func (m *PresenceParam) String() string { return proto.CompactTextString(m) }
+func (*PresenceParam) ProtoMessage()    {}
+func (*PresenceParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{5}
+}
+
+func (m *PresenceParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PresenceParam.Unmarshal(m, b)
+}
+func (m *PresenceParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PresenceParam.Marshal(b, m, deterministic)
+}
+func (m *PresenceParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresenceParam.Merge(m, src)
+}
+func (m *PresenceParam) XXX_Size() int {
+	return xxx_messageInfo_PresenceParam.Size(m)
+}
+func (m *PresenceParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresenceParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresenceParam proto.InternalMessageInfo
+
+func (m *PresenceParam) GetState() PresenceState {
+	if m != nil {
+		return m.State
+	}
+	return PresenceState_Offline
+}
+
+func (m *PresenceParam) GetStatusText() string {
+	if m != nil {
+		return m.StatusText
+	}
+	return ""
+}
+
+// users not sharing room with caller left out of result
+type GetPresenceParam struct {
+	UserIDs              []string `protobuf:"bytes,1,rep,name=userIDs,proto3" json:"userIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPresenceParam) Reset()         { *m = GetPresenceParam{} }
+func (m *GetPresenceParam) String() string { return proto.CompactTextString(m) }
+func (*GetPresenceParam) ProtoMessage()    {}
+func (*GetPresenceParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{6}
+}
+
+func (m *GetPresenceParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPresenceParam.Unmarshal(m, b)
+}
+func (m *GetPresenceParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPresenceParam.Marshal(b, m, deterministic)
+}
+func (m *GetPresenceParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPresenceParam.Merge(m, src)
+}
+func (m *GetPresenceParam) XXX_Size() int {
+	return xxx_messageInfo_GetPresenceParam.Size(m)
+}
+func (m *GetPresenceParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPresenceParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPresenceParam proto.InternalMessageInfo
+
+func (m *GetPresenceParam) GetUserIDs() []string {
+	if m != nil {
+		return m.UserIDs
+	}
+	return nil
+}
+
+type OnlineStatuses struct {
+	Statuses             []*OnlineStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *OnlineStatuses) Reset()         { *m = OnlineStatuses{} }
+func (m *OnlineStatuses) String() string { return proto.CompactTextString(m) }
+func (*OnlineStatuses) ProtoMessage()    {}
+func (*OnlineStatuses) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{7}
+}
+
+func (m *OnlineStatuses) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OnlineStatuses.Unmarshal(m, b)
+}
+func (m *OnlineStatuses) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OnlineStatuses.Marshal(b, m, deterministic)
+}
+func (m *OnlineStatuses) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OnlineStatuses.Merge(m, src)
+}
+func (m *OnlineStatuses) XXX_Size() int {
+	return xxx_messageInfo_OnlineStatuses.Size(m)
+}
+func (m *OnlineStatuses) XXX_DiscardUnknown() {
+	xxx_messageInfo_OnlineStatuses.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OnlineStatuses proto.InternalMessageInfo
+
+func (m *OnlineStatuses) GetStatuses() []*OnlineStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
 type Users struct {
 	Users                []*User  `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
 	Count                uint64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
@@ -383,7 +720,7 @@ func (m *Users) Reset()         { *m = Users{} }
 func (m *Users) String() string { return proto.CompactTextString(m) }
 func (*Users) ProtoMessage()    {}
 func (*Users) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{5}
+	return fileDescriptor_39f66308029891ad, []int{8}
 }
 
 func (m *Users) XXX_Unmarshal(b []byte) error {
@@ -431,7 +768,7 @@ func (m *UpdateUserProfileParam) Reset()         { *m = UpdateUserProfileParam{}
 func (m *UpdateUserProfileParam) String() string { return proto.CompactTextString(m) }
 func (*UpdateUserProfileParam) ProtoMessage()    {}
 func (*UpdateUserProfileParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{6}
+	return fileDescriptor_39f66308029891ad, []int{9}
 }
 
 func (m *UpdateUserProfileParam) XXX_Unmarshal(b []byte) error {
@@ -485,7 +822,7 @@ func (m *UpdateProfileParam) Reset()         { *m = UpdateProfileParam{} }
 func (m *UpdateProfileParam) String() string { return proto.CompactTextString(m) }
 func (*UpdateProfileParam) ProtoMessage()    {}
 func (*UpdateProfileParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{7}
+	return fileDescriptor_39f66308029891ad, []int{10}
 }
 
 func (m *UpdateProfileParam) XXX_Unmarshal(b []byte) error {
@@ -534,7 +871,7 @@ func (m *Profile) Reset()         { *m = Profile{} }
 func (m *Profile) String() string { return proto.CompactTextString(m) }
 func (*Profile) ProtoMessage()    {}
 func (*Profile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{8}
+	return fileDescriptor_39f66308029891ad, []int{11}
 }
 
 func (m *Profile) XXX_Unmarshal(b []byte) error {
@@ -584,22 +921,24 @@ func (m *Profile) GetServers() []*ICEServer {
 }
 
 type ICEServer struct {
-	Url                  string            `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	Username             string            `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	CredentialType       ICECredentialType `protobuf:"varint,3,opt,name=credentialType,proto3,enum=protos.ICECredentialType" json:"credentialType,omitempty"`
-	Password             string            `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
-	AccessToken          string            `protobuf:"bytes,5,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
-	MacKey               string            `protobuf:"bytes,6,opt,name=macKey,proto3" json:"macKey,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Url                  string               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Username             string               `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	CredentialType       ICECredentialType    `protobuf:"varint,3,opt,name=credentialType,proto3,enum=protos.ICECredentialType" json:"credentialType,omitempty"`
+	Password             string               `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	AccessToken          string               `protobuf:"bytes,5,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	MacKey               string               `protobuf:"bytes,6,opt,name=macKey,proto3" json:"macKey,omitempty"`
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	Realm                string               `protobuf:"bytes,8,opt,name=realm,proto3" json:"realm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *ICEServer) Reset()         { *m = ICEServer{} }
 func (m *ICEServer) String() string { return proto.CompactTextString(m) }
 func (*ICEServer) ProtoMessage()    {}
 func (*ICEServer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{9}
+	return fileDescriptor_39f66308029891ad, []int{12}
 }
 
 func (m *ICEServer) XXX_Unmarshal(b []byte) error {
@@ -662,112 +1001,285 @@ func (m *ICEServer) GetMacKey() string {
 	return ""
 }
 
-type UserAccessToken struct {
-	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ICEServer) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
 }
 
-func (m *UserAccessToken) Reset()         { *m = UserAccessToken{} }
-func (m *UserAccessToken) String() string { return proto.CompactTextString(m) }
-func (*UserAccessToken) ProtoMessage()    {}
-func (*UserAccessToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{10}
+func (m *ICEServer) GetRealm() string {
+	if m != nil {
+		return m.Realm
+	}
+	return ""
 }
 
-func (m *UserAccessToken) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UserAccessToken.Unmarshal(m, b)
+type ICEServers struct {
+	Servers              []*ICEServer `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
-func (m *UserAccessToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UserAccessToken.Marshal(b, m, deterministic)
+
+func (m *ICEServers) Reset()         { *m = ICEServers{} }
+func (m *ICEServers) String() string { return proto.CompactTextString(m) }
+func (*ICEServers) ProtoMessage()    {}
+func (*ICEServers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{13}
 }
-func (m *UserAccessToken) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserAccessToken.Merge(m, src)
+
+func (m *ICEServers) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ICEServers.Unmarshal(m, b)
 }
-func (m *UserAccessToken) XXX_Size() int {
-	return xxx_messageInfo_UserAccessToken.Size(m)
+func (m *ICEServers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ICEServers.Marshal(b, m, deterministic)
 }
-func (m *UserAccessToken) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserAccessToken.DiscardUnknown(m)
+func (m *ICEServers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ICEServers.Merge(m, src)
+}
+func (m *ICEServers) XXX_Size() int {
+	return xxx_messageInfo_ICEServers.Size(m)
+}
+func (m *ICEServers) XXX_DiscardUnknown() {
+	xxx_messageInfo_ICEServers.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UserAccessToken proto.InternalMessageInfo
+var xxx_messageInfo_ICEServers proto.InternalMessageInfo
 
-func (m *UserAccessToken) GetToken() string {
+func (m *ICEServers) GetServers() []*ICEServer {
 	if m != nil {
-		return m.Token
+		return m.Servers
 	}
-	return ""
+	return nil
 }
 
-type NewRoomParam struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Photo                string   `protobuf:"bytes,3,opt,name=photo,proto3" json:"photo,omitempty"`
-	Description          string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	UserIDs              []string `protobuf:"bytes,5,rep,name=userIDs,proto3" json:"userIDs,omitempty"`
+type UserAccessTokenParam struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// restrict token to these rooms, empty allow all user's rooms
+	RoomIDs []string `protobuf:"bytes,2,rep,name=roomIDs,proto3" json:"roomIDs,omitempty"`
+	// restrict token to these capabilities (signal, presence, profile), empty allow all
+	Capabilities         []string `protobuf:"bytes,3,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NewRoomParam) Reset()         { *m = NewRoomParam{} }
-func (m *NewRoomParam) String() string { return proto.CompactTextString(m) }
-func (*NewRoomParam) ProtoMessage()    {}
-func (*NewRoomParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{11}
+func (m *UserAccessTokenParam) Reset()         { *m = UserAccessTokenParam{} }
+func (m *UserAccessTokenParam) String() string { return proto.CompactTextString(m) }
+func (*UserAccessTokenParam) ProtoMessage()    {}
+func (*UserAccessTokenParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{14}
 }
 
-func (m *NewRoomParam) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NewRoomParam.Unmarshal(m, b)
+func (m *UserAccessTokenParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserAccessTokenParam.Unmarshal(m, b)
 }
-func (m *NewRoomParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NewRoomParam.Marshal(b, m, deterministic)
+func (m *UserAccessTokenParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserAccessTokenParam.Marshal(b, m, deterministic)
 }
-func (m *NewRoomParam) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewRoomParam.Merge(m, src)
+func (m *UserAccessTokenParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserAccessTokenParam.Merge(m, src)
 }
-func (m *NewRoomParam) XXX_Size() int {
-	return xxx_messageInfo_NewRoomParam.Size(m)
+func (m *UserAccessTokenParam) XXX_Size() int {
+	return xxx_messageInfo_UserAccessTokenParam.Size(m)
 }
-func (m *NewRoomParam) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewRoomParam.DiscardUnknown(m)
+func (m *UserAccessTokenParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserAccessTokenParam.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NewRoomParam proto.InternalMessageInfo
+var xxx_messageInfo_UserAccessTokenParam proto.InternalMessageInfo
 
-func (m *NewRoomParam) GetId() string {
+func (m *UserAccessTokenParam) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *NewRoomParam) GetName() string {
+func (m *UserAccessTokenParam) GetRoomIDs() []string {
 	if m != nil {
-		return m.Name
+		return m.RoomIDs
 	}
-	return ""
+	return nil
 }
 
-func (m *NewRoomParam) GetPhoto() string {
+func (m *UserAccessTokenParam) GetCapabilities() []string {
 	if m != nil {
-		return m.Photo
+		return m.Capabilities
 	}
-	return ""
+	return nil
 }
 
-func (m *NewRoomParam) GetDescription() string {
-	if m != nil {
-		return m.Description
-	}
-	return ""
+type UserAccessToken struct {
+	Token                 string               `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt             *timestamp.Timestamp `protobuf:"bytes,2,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	RefreshToken          string               `protobuf:"bytes,3,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	RefreshTokenExpiresAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=refreshTokenExpiresAt,proto3" json:"refreshTokenExpiresAt,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}             `json:"-"`
+	XXX_unrecognized      []byte               `json:"-"`
+	XXX_sizecache         int32                `json:"-"`
 }
 
-func (m *NewRoomParam) GetUserIDs() []string {
-	if m != nil {
-		return m.UserIDs
+func (m *UserAccessToken) Reset()         { *m = UserAccessToken{} }
+func (m *UserAccessToken) String() string { return proto.CompactTextString(m) }
+func (*UserAccessToken) ProtoMessage()    {}
+func (*UserAccessToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{15}
+}
+
+func (m *UserAccessToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserAccessToken.Unmarshal(m, b)
+}
+func (m *UserAccessToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserAccessToken.Marshal(b, m, deterministic)
+}
+func (m *UserAccessToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserAccessToken.Merge(m, src)
+}
+func (m *UserAccessToken) XXX_Size() int {
+	return xxx_messageInfo_UserAccessToken.Size(m)
+}
+func (m *UserAccessToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserAccessToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserAccessToken proto.InternalMessageInfo
+
+func (m *UserAccessToken) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *UserAccessToken) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *UserAccessToken) GetRefreshToken() string {
+	if m != nil {
+		return m.RefreshToken
+	}
+	return ""
+}
+
+func (m *UserAccessToken) GetRefreshTokenExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RefreshTokenExpiresAt
+	}
+	return nil
+}
+
+type RefreshTokenParam struct {
+	RefreshToken         string   `protobuf:"bytes,1,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefreshTokenParam) Reset()         { *m = RefreshTokenParam{} }
+func (m *RefreshTokenParam) String() string { return proto.CompactTextString(m) }
+func (*RefreshTokenParam) ProtoMessage()    {}
+func (*RefreshTokenParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{16}
+}
+
+func (m *RefreshTokenParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefreshTokenParam.Unmarshal(m, b)
+}
+func (m *RefreshTokenParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefreshTokenParam.Marshal(b, m, deterministic)
+}
+func (m *RefreshTokenParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefreshTokenParam.Merge(m, src)
+}
+func (m *RefreshTokenParam) XXX_Size() int {
+	return xxx_messageInfo_RefreshTokenParam.Size(m)
+}
+func (m *RefreshTokenParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefreshTokenParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefreshTokenParam proto.InternalMessageInfo
+
+func (m *RefreshTokenParam) GetRefreshToken() string {
+	if m != nil {
+		return m.RefreshToken
+	}
+	return ""
+}
+
+type NewRoomParam struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Photo                string   `protobuf:"bytes,3,opt,name=photo,proto3" json:"photo,omitempty"`
+	Description          string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	UserIDs              []string `protobuf:"bytes,5,rep,name=userIDs,proto3" json:"userIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewRoomParam) Reset()         { *m = NewRoomParam{} }
+func (m *NewRoomParam) String() string { return proto.CompactTextString(m) }
+func (*NewRoomParam) ProtoMessage()    {}
+func (*NewRoomParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{17}
+}
+
+func (m *NewRoomParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewRoomParam.Unmarshal(m, b)
+}
+func (m *NewRoomParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewRoomParam.Marshal(b, m, deterministic)
+}
+func (m *NewRoomParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewRoomParam.Merge(m, src)
+}
+func (m *NewRoomParam) XXX_Size() int {
+	return xxx_messageInfo_NewRoomParam.Size(m)
+}
+func (m *NewRoomParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewRoomParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewRoomParam proto.InternalMessageInfo
+
+func (m *NewRoomParam) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *NewRoomParam) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NewRoomParam) GetPhoto() string {
+	if m != nil {
+		return m.Photo
+	}
+	return ""
+}
+
+func (m *NewRoomParam) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *NewRoomParam) GetUserIDs() []string {
+	if m != nil {
+		return m.UserIDs
 	}
 	return nil
 }
@@ -787,7 +1299,7 @@ func (m *Room) Reset()         { *m = Room{} }
 func (m *Room) String() string { return proto.CompactTextString(m) }
 func (*Room) ProtoMessage()    {}
 func (*Room) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{12}
+	return fileDescriptor_39f66308029891ad, []int{18}
 }
 
 func (m *Room) XXX_Unmarshal(b []byte) error {
@@ -857,7 +1369,7 @@ func (m *UpdateRoomProfileParam) Reset()         { *m = UpdateRoomProfileParam{}
 func (m *UpdateRoomProfileParam) String() string { return proto.CompactTextString(m) }
 func (*UpdateRoomProfileParam) ProtoMessage()    {}
 func (*UpdateRoomProfileParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{13}
+	return fileDescriptor_39f66308029891ad, []int{19}
 }
 
 func (m *UpdateRoomProfileParam) XXX_Unmarshal(b []byte) error {
@@ -918,7 +1430,7 @@ func (m *Rooms) Reset()         { *m = Rooms{} }
 func (m *Rooms) String() string { return proto.CompactTextString(m) }
 func (*Rooms) ProtoMessage()    {}
 func (*Rooms) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{14}
+	return fileDescriptor_39f66308029891ad, []int{20}
 }
 
 func (m *Rooms) XXX_Unmarshal(b []byte) error {
@@ -965,7 +1477,7 @@ func (m *UserRoomParam) Reset()         { *m = UserRoomParam{} }
 func (m *UserRoomParam) String() string { return proto.CompactTextString(m) }
 func (*UserRoomParam) ProtoMessage()    {}
 func (*UserRoomParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{15}
+	return fileDescriptor_39f66308029891ad, []int{21}
 }
 
 func (m *UserRoomParam) XXX_Unmarshal(b []byte) error {
@@ -1011,7 +1523,7 @@ func (m *GetRoomParam) Reset()         { *m = GetRoomParam{} }
 func (m *GetRoomParam) String() string { return proto.CompactTextString(m) }
 func (*GetRoomParam) ProtoMessage()    {}
 func (*GetRoomParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{16}
+	return fileDescriptor_39f66308029891ad, []int{22}
 }
 
 func (m *GetRoomParam) XXX_Unmarshal(b []byte) error {
@@ -1052,7 +1564,7 @@ func (m *PaginationParam) Reset()         { *m = PaginationParam{} }
 func (m *PaginationParam) String() string { return proto.CompactTextString(m) }
 func (*PaginationParam) ProtoMessage()    {}
 func (*PaginationParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{17}
+	return fileDescriptor_39f66308029891ad, []int{23}
 }
 
 func (m *PaginationParam) XXX_Unmarshal(b []byte) error {
@@ -1095,8 +1607,19 @@ func (m *PaginationParam) GetKeyword() string {
 }
 
 type SDPParam struct {
-	Description          string   `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
-	UserID               string   `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	Description string `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	UserID      string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// only used by SendSessionDescription, offer & answer RPCs ignore it
+	Type SDPTypes `protobuf:"varint,3,opt,name=type,proto3,enum=protos.SDPTypes" json:"type,omitempty"`
+	// call this session description belong to, empty for call-less session
+	CallID string `protobuf:"bytes,4,opt,name=callID,proto3" json:"callID,omitempty"`
+	// target device, empty to send to device pinned on the negotiation
+	// or all devices of target user when not pinned yet
+	DeviceID string `protobuf:"bytes,5,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
+	// peer connection this session description belong to, let peers run
+	// several independent connections, empty for default connection,
+	// at most 64 letters, digits, dot, colon, dash or underscore
+	ConnectionID         string   `protobuf:"bytes,6,opt,name=connectionID,proto3" json:"connectionID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1106,7 +1629,7 @@ func (m *SDPParam) Reset()         { *m = SDPParam{} }
 func (m *SDPParam) String() string { return proto.CompactTextString(m) }
 func (*SDPParam) ProtoMessage()    {}
 func (*SDPParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{18}
+	return fileDescriptor_39f66308029891ad, []int{24}
 }
 
 func (m *SDPParam) XXX_Unmarshal(b []byte) error {
@@ -1141,10 +1664,47 @@ func (m *SDPParam) GetUserID() string {
 	return ""
 }
 
+func (m *SDPParam) GetType() SDPTypes {
+	if m != nil {
+		return m.Type
+	}
+	return SDPTypes_Offer
+}
+
+func (m *SDPParam) GetCallID() string {
+	if m != nil {
+		return m.CallID
+	}
+	return ""
+}
+
+func (m *SDPParam) GetDeviceID() string {
+	if m != nil {
+		return m.DeviceID
+	}
+	return ""
+}
+
+func (m *SDPParam) GetConnectionID() string {
+	if m != nil {
+		return m.ConnectionID
+	}
+	return ""
+}
+
 type SDP struct {
-	Type                 SDPTypes `protobuf:"varint,1,opt,name=type,proto3,enum=protos.SDPTypes" json:"type,omitempty"`
-	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	SenderID             string   `protobuf:"bytes,3,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	Type        SDPTypes `protobuf:"varint,1,opt,name=type,proto3,enum=protos.SDPTypes" json:"type,omitempty"`
+	Description string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	SenderID    string   `protobuf:"bytes,3,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	CallID      string   `protobuf:"bytes,4,opt,name=callID,proto3" json:"callID,omitempty"`
+	// receiver role on perfect negotiation, polite peer roll back it's offer on collision
+	Polite bool `protobuf:"varint,5,opt,name=polite,proto3" json:"polite,omitempty"`
+	// sent by server with rollback type when receiver offer collided with sender offer,
+	// receiver must roll back it's pending local offer
+	Collision bool `protobuf:"varint,6,opt,name=collision,proto3" json:"collision,omitempty"`
+	// device of sender, empty when sender didn't identify it's device
+	SenderDeviceID       string   `protobuf:"bytes,7,opt,name=senderDeviceID,proto3" json:"senderDeviceID,omitempty"`
+	ConnectionID         string   `protobuf:"bytes,8,opt,name=connectionID,proto3" json:"connectionID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1154,7 +1714,7 @@ func (m *SDP) Reset()         { *m = SDP{} }
 func (m *SDP) String() string { return proto.CompactTextString(m) }
 func (*SDP) ProtoMessage()    {}
 func (*SDP) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{19}
+	return fileDescriptor_39f66308029891ad, []int{25}
 }
 
 func (m *SDP) XXX_Unmarshal(b []byte) error {
@@ -1196,751 +1756,2022 @@ func (m *SDP) GetSenderID() string {
 	return ""
 }
 
-type RoomEvent struct {
-	Event RoomEvents           `protobuf:"varint,1,opt,name=event,proto3,enum=protos.RoomEvents" json:"event,omitempty"`
-	Time  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
-	// Types that are valid to be assigned to Payload:
-	//	*RoomEvent_RoomParticipant
-	//	*RoomEvent_RoomInstance
-	//	*RoomEvent_UserInstance
-	Payload              isRoomEvent_Payload `protobuf_oneof:"payload"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+func (m *SDP) GetCallID() string {
+	if m != nil {
+		return m.CallID
+	}
+	return ""
 }
 
-func (m *RoomEvent) Reset()         { *m = RoomEvent{} }
-func (m *RoomEvent) String() string { return proto.CompactTextString(m) }
-func (*RoomEvent) ProtoMessage()    {}
-func (*RoomEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{20}
+func (m *SDP) GetPolite() bool {
+	if m != nil {
+		return m.Polite
+	}
+	return false
 }
 
-func (m *RoomEvent) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RoomEvent.Unmarshal(m, b)
-}
-func (m *RoomEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RoomEvent.Marshal(b, m, deterministic)
-}
-func (m *RoomEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RoomEvent.Merge(m, src)
-}
-func (m *RoomEvent) XXX_Size() int {
-	return xxx_messageInfo_RoomEvent.Size(m)
-}
-func (m *RoomEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_RoomEvent.DiscardUnknown(m)
+func (m *SDP) GetCollision() bool {
+	if m != nil {
+		return m.Collision
+	}
+	return false
 }
 
-var xxx_messageInfo_RoomEvent proto.InternalMessageInfo
-
-func (m *RoomEvent) GetEvent() RoomEvents {
+func (m *SDP) GetSenderDeviceID() string {
 	if m != nil {
-		return m.Event
+		return m.SenderDeviceID
 	}
-	return RoomEvents_UserLeftRoom
+	return ""
 }
 
-func (m *RoomEvent) GetTime() *timestamp.Timestamp {
+func (m *SDP) GetConnectionID() string {
 	if m != nil {
-		return m.Time
+		return m.ConnectionID
 	}
-	return nil
+	return ""
 }
 
-type isRoomEvent_Payload interface {
-	isRoomEvent_Payload()
+type StartCallParam struct {
+	UserID               string   `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type RoomEvent_RoomParticipant struct {
-	RoomParticipant *RoomParticipantEventPayload `protobuf:"bytes,4,opt,name=roomParticipant,proto3,oneof"`
+func (m *StartCallParam) Reset()         { *m = StartCallParam{} }
+func (m *StartCallParam) String() string { return proto.CompactTextString(m) }
+func (*StartCallParam) ProtoMessage()    {}
+func (*StartCallParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{26}
 }
 
-type RoomEvent_RoomInstance struct {
-	RoomInstance *RoomInstanceEventPayload `protobuf:"bytes,5,opt,name=roomInstance,proto3,oneof"`
+func (m *StartCallParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartCallParam.Unmarshal(m, b)
 }
-
-type RoomEvent_UserInstance struct {
-	UserInstance *UserInstanceEventPayload `protobuf:"bytes,6,opt,name=userInstance,proto3,oneof"`
+func (m *StartCallParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartCallParam.Marshal(b, m, deterministic)
+}
+func (m *StartCallParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartCallParam.Merge(m, src)
+}
+func (m *StartCallParam) XXX_Size() int {
+	return xxx_messageInfo_StartCallParam.Size(m)
+}
+func (m *StartCallParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartCallParam.DiscardUnknown(m)
 }
 
-func (*RoomEvent_RoomParticipant) isRoomEvent_Payload() {}
-
-func (*RoomEvent_RoomInstance) isRoomEvent_Payload() {}
-
-func (*RoomEvent_UserInstance) isRoomEvent_Payload() {}
+var xxx_messageInfo_StartCallParam proto.InternalMessageInfo
 
-func (m *RoomEvent) GetPayload() isRoomEvent_Payload {
+func (m *StartCallParam) GetUserID() string {
 	if m != nil {
-		return m.Payload
+		return m.UserID
 	}
-	return nil
+	return ""
 }
 
-func (m *RoomEvent) GetRoomParticipant() *RoomParticipantEventPayload {
-	if x, ok := m.GetPayload().(*RoomEvent_RoomParticipant); ok {
-		return x.RoomParticipant
-	}
-	return nil
+type CallParam struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RoomEvent) GetRoomInstance() *RoomInstanceEventPayload {
-	if x, ok := m.GetPayload().(*RoomEvent_RoomInstance); ok {
-		return x.RoomInstance
-	}
-	return nil
+func (m *CallParam) Reset()         { *m = CallParam{} }
+func (m *CallParam) String() string { return proto.CompactTextString(m) }
+func (*CallParam) ProtoMessage()    {}
+func (*CallParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{27}
 }
 
-func (m *RoomEvent) GetUserInstance() *UserInstanceEventPayload {
-	if x, ok := m.GetPayload().(*RoomEvent_UserInstance); ok {
-		return x.UserInstance
-	}
-	return nil
+func (m *CallParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallParam.Unmarshal(m, b)
+}
+func (m *CallParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallParam.Marshal(b, m, deterministic)
+}
+func (m *CallParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallParam.Merge(m, src)
+}
+func (m *CallParam) XXX_Size() int {
+	return xxx_messageInfo_CallParam.Size(m)
+}
+func (m *CallParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallParam.DiscardUnknown(m)
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*RoomEvent) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*RoomEvent_RoomParticipant)(nil),
-		(*RoomEvent_RoomInstance)(nil),
-		(*RoomEvent_UserInstance)(nil),
+var xxx_messageInfo_CallParam proto.InternalMessageInfo
+
+func (m *CallParam) GetId() string {
+	if m != nil {
+		return m.Id
 	}
+	return ""
 }
 
-type RoomParticipantEventPayload struct {
-	ParticipantID        string   `protobuf:"bytes,1,opt,name=participantID,proto3" json:"participantID,omitempty"`
-	RoomID               string   `protobuf:"bytes,2,opt,name=roomID,proto3" json:"roomID,omitempty"`
+type Call struct {
+	Id        string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CallerID  string               `protobuf:"bytes,2,opt,name=callerID,proto3" json:"callerID,omitempty"`
+	CalleeID  string               `protobuf:"bytes,3,opt,name=calleeID,proto3" json:"calleeID,omitempty"`
+	State     CallStates           `protobuf:"varint,4,opt,name=state,proto3,enum=protos.CallStates" json:"state,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// call missed when not answered before this time
+	RingingUntil *timestamp.Timestamp `protobuf:"bytes,6,opt,name=ringingUntil,proto3" json:"ringingUntil,omitempty"`
+	AnsweredAt   *timestamp.Timestamp `protobuf:"bytes,7,opt,name=answeredAt,proto3" json:"answeredAt,omitempty"`
+	EndedAt      *timestamp.Timestamp `protobuf:"bytes,8,opt,name=endedAt,proto3" json:"endedAt,omitempty"`
+	// participant who rejected, cancelled or hung up the call
+	EndedBy              string   `protobuf:"bytes,9,opt,name=endedBy,proto3" json:"endedBy,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RoomParticipantEventPayload) Reset()         { *m = RoomParticipantEventPayload{} }
-func (m *RoomParticipantEventPayload) String() string { return proto.CompactTextString(m) }
-func (*RoomParticipantEventPayload) ProtoMessage()    {}
-func (*RoomParticipantEventPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{21}
+func (m *Call) Reset()         { *m = Call{} }
+func (m *Call) String() string { return proto.CompactTextString(m) }
+func (*Call) ProtoMessage()    {}
+func (*Call) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{28}
 }
 
-func (m *RoomParticipantEventPayload) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RoomParticipantEventPayload.Unmarshal(m, b)
+func (m *Call) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Call.Unmarshal(m, b)
 }
-func (m *RoomParticipantEventPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RoomParticipantEventPayload.Marshal(b, m, deterministic)
+func (m *Call) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Call.Marshal(b, m, deterministic)
 }
-func (m *RoomParticipantEventPayload) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RoomParticipantEventPayload.Merge(m, src)
+func (m *Call) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Call.Merge(m, src)
 }
-func (m *RoomParticipantEventPayload) XXX_Size() int {
-	return xxx_messageInfo_RoomParticipantEventPayload.Size(m)
+func (m *Call) XXX_Size() int {
+	return xxx_messageInfo_Call.Size(m)
 }
-func (m *RoomParticipantEventPayload) XXX_DiscardUnknown() {
-	xxx_messageInfo_RoomParticipantEventPayload.DiscardUnknown(m)
+func (m *Call) XXX_DiscardUnknown() {
+	xxx_messageInfo_Call.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RoomParticipantEventPayload proto.InternalMessageInfo
+var xxx_messageInfo_Call proto.InternalMessageInfo
 
-func (m *RoomParticipantEventPayload) GetParticipantID() string {
+func (m *Call) GetId() string {
 	if m != nil {
-		return m.ParticipantID
+		return m.Id
 	}
 	return ""
 }
 
-func (m *RoomParticipantEventPayload) GetRoomID() string {
+func (m *Call) GetCallerID() string {
 	if m != nil {
-		return m.RoomID
+		return m.CallerID
 	}
 	return ""
 }
 
-type RoomInstanceEventPayload struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Photo                string   `protobuf:"bytes,3,opt,name=photo,proto3" json:"photo,omitempty"`
-	Description          string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Call) GetCalleeID() string {
+	if m != nil {
+		return m.CalleeID
+	}
+	return ""
 }
 
-func (m *RoomInstanceEventPayload) Reset()         { *m = RoomInstanceEventPayload{} }
-func (m *RoomInstanceEventPayload) String() string { return proto.CompactTextString(m) }
-func (*RoomInstanceEventPayload) ProtoMessage()    {}
-func (*RoomInstanceEventPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{22}
+func (m *Call) GetState() CallStates {
+	if m != nil {
+		return m.State
+	}
+	return CallStates_Ringing
 }
 
-func (m *RoomInstanceEventPayload) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RoomInstanceEventPayload.Unmarshal(m, b)
-}
-func (m *RoomInstanceEventPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RoomInstanceEventPayload.Marshal(b, m, deterministic)
-}
-func (m *RoomInstanceEventPayload) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RoomInstanceEventPayload.Merge(m, src)
+func (m *Call) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
 }
-func (m *RoomInstanceEventPayload) XXX_Size() int {
-	return xxx_messageInfo_RoomInstanceEventPayload.Size(m)
+
+func (m *Call) GetRingingUntil() *timestamp.Timestamp {
+	if m != nil {
+		return m.RingingUntil
+	}
+	return nil
 }
-func (m *RoomInstanceEventPayload) XXX_DiscardUnknown() {
-	xxx_messageInfo_RoomInstanceEventPayload.DiscardUnknown(m)
+
+func (m *Call) GetAnsweredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.AnsweredAt
+	}
+	return nil
 }
 
-var xxx_messageInfo_RoomInstanceEventPayload proto.InternalMessageInfo
+func (m *Call) GetEndedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndedAt
+	}
+	return nil
+}
 
-func (m *RoomInstanceEventPayload) GetId() string {
+func (m *Call) GetEndedBy() string {
 	if m != nil {
-		return m.Id
+		return m.EndedBy
 	}
 	return ""
 }
 
-func (m *RoomInstanceEventPayload) GetName() string {
+// emitted to caller & callee when call state changed
+type CallEvent struct {
+	Call                 *Call                `protobuf:"bytes,1,opt,name=call,proto3" json:"call,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CallEvent) Reset()         { *m = CallEvent{} }
+func (m *CallEvent) String() string { return proto.CompactTextString(m) }
+func (*CallEvent) ProtoMessage()    {}
+func (*CallEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{29}
+}
+
+func (m *CallEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallEvent.Unmarshal(m, b)
+}
+func (m *CallEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallEvent.Marshal(b, m, deterministic)
+}
+func (m *CallEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallEvent.Merge(m, src)
+}
+func (m *CallEvent) XXX_Size() int {
+	return xxx_messageInfo_CallEvent.Size(m)
+}
+func (m *CallEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallEvent proto.InternalMessageInfo
+
+func (m *CallEvent) GetCall() *Call {
 	if m != nil {
-		return m.Name
+		return m.Call
 	}
-	return ""
+	return nil
 }
 
-func (m *RoomInstanceEventPayload) GetPhoto() string {
+func (m *CallEvent) GetTime() *timestamp.Timestamp {
 	if m != nil {
-		return m.Photo
+		return m.Time
 	}
-	return ""
+	return nil
 }
 
-func (m *RoomInstanceEventPayload) GetDescription() string {
+type RoomCallParam struct {
+	RoomID               string   `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoomCallParam) Reset()         { *m = RoomCallParam{} }
+func (m *RoomCallParam) String() string { return proto.CompactTextString(m) }
+func (*RoomCallParam) ProtoMessage()    {}
+func (*RoomCallParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{30}
+}
+
+func (m *RoomCallParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoomCallParam.Unmarshal(m, b)
+}
+func (m *RoomCallParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoomCallParam.Marshal(b, m, deterministic)
+}
+func (m *RoomCallParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoomCallParam.Merge(m, src)
+}
+func (m *RoomCallParam) XXX_Size() int {
+	return xxx_messageInfo_RoomCallParam.Size(m)
+}
+func (m *RoomCallParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoomCallParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoomCallParam proto.InternalMessageInfo
+
+func (m *RoomCallParam) GetRoomID() string {
 	if m != nil {
-		return m.Description
+		return m.RoomID
 	}
 	return ""
 }
 
-type UserInstanceEventPayload struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Photo                string   `protobuf:"bytes,3,opt,name=photo,proto3" json:"photo,omitempty"`
+// mesh call of a room, peer with lower user id send offer to the other
+type RoomCall struct {
+	RoomID string `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	// other users currently in the call
+	ParticipantIDs []string `protobuf:"bytes,2,rep,name=participantIDs,proto3" json:"participantIDs,omitempty"`
+	// participants joiner must send offer to
+	OfferTo []string `protobuf:"bytes,3,rep,name=offerTo,proto3" json:"offerTo,omitempty"`
+	// participants that will send offer to joiner
+	AnswerTo             []string `protobuf:"bytes,4,rep,name=answerTo,proto3" json:"answerTo,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UserInstanceEventPayload) Reset()         { *m = UserInstanceEventPayload{} }
-func (m *UserInstanceEventPayload) String() string { return proto.CompactTextString(m) }
-func (*UserInstanceEventPayload) ProtoMessage()    {}
-func (*UserInstanceEventPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{23}
+func (m *RoomCall) Reset()         { *m = RoomCall{} }
+func (m *RoomCall) String() string { return proto.CompactTextString(m) }
+func (*RoomCall) ProtoMessage()    {}
+func (*RoomCall) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{31}
 }
 
-func (m *UserInstanceEventPayload) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UserInstanceEventPayload.Unmarshal(m, b)
+func (m *RoomCall) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoomCall.Unmarshal(m, b)
 }
-func (m *UserInstanceEventPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UserInstanceEventPayload.Marshal(b, m, deterministic)
+func (m *RoomCall) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoomCall.Marshal(b, m, deterministic)
 }
-func (m *UserInstanceEventPayload) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserInstanceEventPayload.Merge(m, src)
+func (m *RoomCall) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoomCall.Merge(m, src)
 }
-func (m *UserInstanceEventPayload) XXX_Size() int {
-	return xxx_messageInfo_UserInstanceEventPayload.Size(m)
+func (m *RoomCall) XXX_Size() int {
+	return xxx_messageInfo_RoomCall.Size(m)
 }
-func (m *UserInstanceEventPayload) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserInstanceEventPayload.DiscardUnknown(m)
+func (m *RoomCall) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoomCall.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UserInstanceEventPayload proto.InternalMessageInfo
+var xxx_messageInfo_RoomCall proto.InternalMessageInfo
 
-func (m *UserInstanceEventPayload) GetId() string {
+func (m *RoomCall) GetRoomID() string {
 	if m != nil {
-		return m.Id
+		return m.RoomID
 	}
 	return ""
 }
 
-func (m *UserInstanceEventPayload) GetName() string {
+func (m *RoomCall) GetParticipantIDs() []string {
 	if m != nil {
-		return m.Name
+		return m.ParticipantIDs
 	}
-	return ""
+	return nil
 }
 
-func (m *UserInstanceEventPayload) GetPhoto() string {
+func (m *RoomCall) GetOfferTo() []string {
 	if m != nil {
-		return m.Photo
+		return m.OfferTo
 	}
-	return ""
+	return nil
 }
 
-type ICEParam struct {
-	Candidate            string   `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
-	UserID               string   `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
-	IsRemote             bool     `protobuf:"varint,3,opt,name=isRemote,proto3" json:"isRemote,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *RoomCall) GetAnswerTo() []string {
+	if m != nil {
+		return m.AnswerTo
+	}
+	return nil
 }
 
-func (m *ICEParam) Reset()         { *m = ICEParam{} }
-func (m *ICEParam) String() string { return proto.CompactTextString(m) }
-func (*ICEParam) ProtoMessage()    {}
-func (*ICEParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{24}
+// emitted to other participants when user join or leave room call
+type RoomCallEvent struct {
+	Event  RoomCallEvents `protobuf:"varint,1,opt,name=event,proto3,enum=protos.RoomCallEvents" json:"event,omitempty"`
+	RoomID string         `protobuf:"bytes,2,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	UserID string         `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+	// true when receiver must send offer to joined user
+	ShouldOffer          bool                 `protobuf:"varint,4,opt,name=shouldOffer,proto3" json:"shouldOffer,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ICEParam) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ICEParam.Unmarshal(m, b)
+func (m *RoomCallEvent) Reset()         { *m = RoomCallEvent{} }
+func (m *RoomCallEvent) String() string { return proto.CompactTextString(m) }
+func (*RoomCallEvent) ProtoMessage()    {}
+func (*RoomCallEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{32}
 }
-func (m *ICEParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ICEParam.Marshal(b, m, deterministic)
+
+func (m *RoomCallEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoomCallEvent.Unmarshal(m, b)
 }
-func (m *ICEParam) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ICEParam.Merge(m, src)
+func (m *RoomCallEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoomCallEvent.Marshal(b, m, deterministic)
 }
-func (m *ICEParam) XXX_Size() int {
-	return xxx_messageInfo_ICEParam.Size(m)
+func (m *RoomCallEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoomCallEvent.Merge(m, src)
 }
-func (m *ICEParam) XXX_DiscardUnknown() {
-	xxx_messageInfo_ICEParam.DiscardUnknown(m)
+func (m *RoomCallEvent) XXX_Size() int {
+	return xxx_messageInfo_RoomCallEvent.Size(m)
+}
+func (m *RoomCallEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoomCallEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ICEParam proto.InternalMessageInfo
+var xxx_messageInfo_RoomCallEvent proto.InternalMessageInfo
 
-func (m *ICEParam) GetCandidate() string {
+func (m *RoomCallEvent) GetEvent() RoomCallEvents {
 	if m != nil {
-		return m.Candidate
+		return m.Event
+	}
+	return RoomCallEvents_ParticipantJoined
+}
+
+func (m *RoomCallEvent) GetRoomID() string {
+	if m != nil {
+		return m.RoomID
 	}
 	return ""
 }
 
-func (m *ICEParam) GetUserID() string {
+func (m *RoomCallEvent) GetUserID() string {
 	if m != nil {
 		return m.UserID
 	}
 	return ""
 }
 
-func (m *ICEParam) GetIsRemote() bool {
+func (m *RoomCallEvent) GetShouldOffer() bool {
 	if m != nil {
-		return m.IsRemote
+		return m.ShouldOffer
 	}
 	return false
 }
 
-type ICEOffer struct {
-	Candidate            string   `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
-	SenderID             string   `protobuf:"bytes,2,opt,name=senderID,proto3" json:"senderID,omitempty"`
-	IsRemote             bool     `protobuf:"varint,3,opt,name=isRemote,proto3" json:"isRemote,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *RoomCallEvent) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
 }
 
-func (m *ICEOffer) Reset()         { *m = ICEOffer{} }
-func (m *ICEOffer) String() string { return proto.CompactTextString(m) }
-func (*ICEOffer) ProtoMessage()    {}
-func (*ICEOffer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39f66308029891ad, []int{25}
+// app-level signal between peers e.g. hangup, mute state, track metadata
+// sent to a user or every other members of a room
+type SignalParam struct {
+	Type                 string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Payload              []byte          `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Data                 *_struct.Struct `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	UserID               string          `protobuf:"bytes,4,opt,name=userID,proto3" json:"userID,omitempty"`
+	RoomID               string          `protobuf:"bytes,5,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *ICEOffer) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ICEOffer.Unmarshal(m, b)
+func (m *SignalParam) Reset()         { *m = SignalParam{} }
+func (m *SignalParam) String() string { return proto.CompactTextString(m) }
+func (*SignalParam) ProtoMessage()    {}
+func (*SignalParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{33}
 }
-func (m *ICEOffer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ICEOffer.Marshal(b, m, deterministic)
+
+func (m *SignalParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignalParam.Unmarshal(m, b)
 }
-func (m *ICEOffer) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ICEOffer.Merge(m, src)
+func (m *SignalParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignalParam.Marshal(b, m, deterministic)
 }
-func (m *ICEOffer) XXX_Size() int {
-	return xxx_messageInfo_ICEOffer.Size(m)
+func (m *SignalParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignalParam.Merge(m, src)
 }
-func (m *ICEOffer) XXX_DiscardUnknown() {
-	xxx_messageInfo_ICEOffer.DiscardUnknown(m)
+func (m *SignalParam) XXX_Size() int {
+	return xxx_messageInfo_SignalParam.Size(m)
+}
+func (m *SignalParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignalParam.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ICEOffer proto.InternalMessageInfo
+var xxx_messageInfo_SignalParam proto.InternalMessageInfo
 
-func (m *ICEOffer) GetCandidate() string {
+func (m *SignalParam) GetType() string {
 	if m != nil {
-		return m.Candidate
+		return m.Type
 	}
 	return ""
 }
 
-func (m *ICEOffer) GetSenderID() string {
+func (m *SignalParam) GetPayload() []byte {
 	if m != nil {
-		return m.SenderID
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *SignalParam) GetData() *_struct.Struct {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *SignalParam) GetUserID() string {
+	if m != nil {
+		return m.UserID
 	}
 	return ""
 }
 
-func (m *ICEOffer) GetIsRemote() bool {
+func (m *SignalParam) GetRoomID() string {
 	if m != nil {
-		return m.IsRemote
+		return m.RoomID
 	}
-	return false
+	return ""
 }
 
-func init() {
-	proto.RegisterEnum("protos.ICECredentialType", ICECredentialType_name, ICECredentialType_value)
-	proto.RegisterEnum("protos.SDPTypes", SDPTypes_name, SDPTypes_value)
-	proto.RegisterEnum("protos.RoomEvents", RoomEvents_name, RoomEvents_value)
-	proto.RegisterType((*NewUserParam)(nil), "protos.NewUserParam")
-	proto.RegisterType((*GetUserParam)(nil), "protos.GetUserParam")
-	proto.RegisterType((*User)(nil), "protos.User")
-	proto.RegisterType((*OnlineStatus)(nil), "protos.OnlineStatus")
-	proto.RegisterType((*Heartbeat)(nil), "protos.Heartbeat")
-	proto.RegisterType((*Users)(nil), "protos.Users")
-	proto.RegisterType((*UpdateUserProfileParam)(nil), "protos.UpdateUserProfileParam")
-	proto.RegisterType((*UpdateProfileParam)(nil), "protos.UpdateProfileParam")
-	proto.RegisterType((*Profile)(nil), "protos.Profile")
-	proto.RegisterType((*ICEServer)(nil), "protos.ICEServer")
-	proto.RegisterType((*UserAccessToken)(nil), "protos.UserAccessToken")
-	proto.RegisterType((*NewRoomParam)(nil), "protos.NewRoomParam")
-	proto.RegisterType((*Room)(nil), "protos.Room")
-	proto.RegisterType((*UpdateRoomProfileParam)(nil), "protos.UpdateRoomProfileParam")
-	proto.RegisterType((*Rooms)(nil), "protos.Rooms")
-	proto.RegisterType((*UserRoomParam)(nil), "protos.UserRoomParam")
-	proto.RegisterType((*GetRoomParam)(nil), "protos.GetRoomParam")
-	proto.RegisterType((*PaginationParam)(nil), "protos.PaginationParam")
-	proto.RegisterType((*SDPParam)(nil), "protos.SDPParam")
-	proto.RegisterType((*SDP)(nil), "protos.SDP")
-	proto.RegisterType((*RoomEvent)(nil), "protos.RoomEvent")
-	proto.RegisterType((*RoomParticipantEventPayload)(nil), "protos.RoomParticipantEventPayload")
-	proto.RegisterType((*RoomInstanceEventPayload)(nil), "protos.RoomInstanceEventPayload")
-	proto.RegisterType((*UserInstanceEventPayload)(nil), "protos.UserInstanceEventPayload")
-	proto.RegisterType((*ICEParam)(nil), "protos.ICEParam")
-	proto.RegisterType((*ICEOffer)(nil), "protos.ICEOffer")
+type Signal struct {
+	Type     string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Payload  []byte          `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Data     *_struct.Struct `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	SenderID string          `protobuf:"bytes,4,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	// set when signal sent to a room
+	RoomID               string               `protobuf:"bytes,5,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func init() { proto.RegisterFile("signalling.proto", fileDescriptor_39f66308029891ad) }
-
-var fileDescriptor_39f66308029891ad = []byte{
-	// 1405 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdf, 0x6e, 0xdb, 0x36,
-	0x17, 0xb7, 0x6c, 0xcb, 0xb1, 0x8f, 0x9d, 0x44, 0xe1, 0xd7, 0xa6, 0xfe, 0xdc, 0xa1, 0x0d, 0xb8,
-	0x02, 0x0b, 0x3a, 0x20, 0x2d, 0xdc, 0xae, 0xc3, 0x2e, 0x96, 0xc1, 0xb3, 0x9d, 0x26, 0xeb, 0xda,
-	0x18, 0x72, 0x82, 0x6d, 0xd8, 0x2e, 0xc6, 0x58, 0xb4, 0x27, 0xc4, 0x12, 0x0d, 0x91, 0x49, 0xe1,
-	0xfb, 0x61, 0x7b, 0x98, 0x3d, 0xcd, 0xf6, 0x3c, 0xbb, 0x18, 0x48, 0x4a, 0x32, 0x65, 0x47, 0x4d,
-	0xb3, 0xac, 0x57, 0xe6, 0xe1, 0xf9, 0xcb, 0xdf, 0xe1, 0xe1, 0x39, 0x32, 0x38, 0xdc, 0x9f, 0x84,
-	0x64, 0x3a, 0xf5, 0xc3, 0xc9, 0xde, 0x2c, 0x62, 0x82, 0xa1, 0x8a, 0xfa, 0xe1, 0xad, 0xfb, 0x13,
-	0xc6, 0x26, 0x53, 0xfa, 0x44, 0x91, 0x67, 0x17, 0xe3, 0x27, 0x34, 0x98, 0x89, 0xb9, 0x16, 0x6a,
-	0x3d, 0x5c, 0x66, 0x0a, 0x3f, 0xa0, 0x5c, 0x90, 0x60, 0xa6, 0x05, 0xf0, 0x21, 0x34, 0xde, 0xd0,
-	0xb7, 0xa7, 0x9c, 0x46, 0x03, 0x12, 0x91, 0x00, 0x6d, 0x40, 0xd1, 0xf7, 0x9a, 0xd6, 0x8e, 0xb5,
-	0x5b, 0x73, 0x8b, 0xbe, 0x87, 0x10, 0x94, 0x43, 0x12, 0xd0, 0x66, 0x51, 0xed, 0xa8, 0x35, 0xba,
-	0x03, 0xf6, 0xec, 0x17, 0x26, 0x58, 0xb3, 0xa4, 0x36, 0x35, 0x81, 0x1f, 0x40, 0xe3, 0x25, 0x15,
-	0xb9, 0x96, 0xf0, 0xf7, 0x50, 0x96, 0xcc, 0x7f, 0xef, 0x01, 0x6d, 0x43, 0x85, 0x85, 0x53, 0x3f,
-	0xa4, 0xcd, 0xf2, 0x8e, 0xb5, 0x5b, 0x75, 0x63, 0x0a, 0xbf, 0x80, 0xc6, 0xb1, 0x5a, 0x0d, 0x05,
-	0x11, 0x17, 0x7c, 0xc5, 0xc3, 0x42, 0xaf, 0x98, 0xd1, 0x7b, 0x08, 0xb5, 0x43, 0x4a, 0x22, 0x71,
-	0x46, 0x89, 0x90, 0x61, 0xc8, 0xdf, 0x58, 0x44, 0xad, 0x71, 0x07, 0x6c, 0x19, 0x32, 0x47, 0x18,
-	0xec, 0x0b, 0xb9, 0x68, 0x5a, 0x3b, 0xa5, 0xdd, 0x7a, 0xbb, 0xa1, 0xc1, 0xe3, 0x7b, 0x92, 0xeb,
-	0x6a, 0x96, 0x8c, 0x79, 0xc4, 0x2e, 0x42, 0x6d, 0xa1, 0xec, 0x6a, 0x02, 0xbb, 0xb0, 0x7d, 0x3a,
-	0xf3, 0x88, 0xa0, 0x0a, 0x98, 0x88, 0x8d, 0xfd, 0x29, 0xbd, 0x2d, 0xd2, 0xfb, 0x80, 0xb4, 0xcd,
-	0x8c, 0xbd, 0xf7, 0xd7, 0x9f, 0xc1, 0x5a, 0xac, 0x79, 0x8b, 0x64, 0x7c, 0x0a, 0x6b, 0x9c, 0x46,
-	0x97, 0x12, 0x94, 0xb2, 0x02, 0x65, 0x2b, 0x01, 0xe5, 0xa8, 0xdb, 0x1f, 0x2a, 0x8e, 0x9b, 0x48,
-	0xe0, 0xbf, 0x2c, 0xa8, 0xa5, 0xdb, 0xc8, 0x81, 0xd2, 0x45, 0x34, 0x8d, 0xbd, 0xca, 0x25, 0x6a,
-	0x41, 0x55, 0x82, 0x68, 0xb8, 0x4e, 0x69, 0xd4, 0x81, 0x8d, 0x51, 0x44, 0x3d, 0x1a, 0x0a, 0x9f,
-	0x4c, 0x4f, 0xe6, 0x33, 0xaa, 0xe2, 0xd8, 0x68, 0xff, 0xdf, 0xf0, 0xd7, 0xcd, 0x08, 0xb8, 0x4b,
-	0x0a, 0xd2, 0xfc, 0x8c, 0x70, 0xfe, 0x96, 0x45, 0x9e, 0xba, 0x3a, 0x35, 0x37, 0xa5, 0xd1, 0x0e,
-	0xd4, 0xc9, 0x68, 0x44, 0x39, 0x3f, 0x61, 0xe7, 0x34, 0x6c, 0xda, 0x8a, 0x6d, 0x6e, 0xc9, 0xeb,
-	0x13, 0x90, 0xd1, 0x2b, 0x3a, 0x6f, 0x56, 0x14, 0x33, 0xa6, 0xf0, 0x27, 0xb0, 0x29, 0x93, 0xda,
-	0x31, 0x44, 0xef, 0x80, 0x2d, 0x94, 0x19, 0x7d, 0x36, 0x4d, 0xe0, 0x5f, 0x2d, 0x55, 0x64, 0x2e,
-	0x63, 0xc1, 0x2d, 0x53, 0x2f, 0xa3, 0xf5, 0x28, 0x1f, 0x45, 0xfe, 0x4c, 0xf8, 0x2c, 0x8c, 0x0f,
-	0x63, 0x6e, 0xa1, 0x26, 0xac, 0x49, 0xe8, 0x8e, 0x7a, 0xbc, 0x69, 0xef, 0x94, 0x76, 0x6b, 0x6e,
-	0x42, 0xe2, 0xdf, 0x2c, 0x28, 0xcb, 0x18, 0x3e, 0xa8, 0xfb, 0xb4, 0x52, 0xec, 0xdc, 0x4a, 0xc1,
-	0x22, 0xa9, 0x09, 0x85, 0xc8, 0x7f, 0x52, 0x13, 0xd7, 0x47, 0x26, 0x8b, 0x59, 0xfa, 0x53, 0xc5,
-	0x1c, 0xc9, 0xc5, 0x72, 0x31, 0x4b, 0xae, 0xab, 0x59, 0x39, 0xc5, 0xfc, 0x15, 0xac, 0xab, 0x73,
-	0xa4, 0x89, 0xdc, 0x86, 0x8a, 0x46, 0x37, 0x8e, 0x39, 0xa6, 0xe4, 0xbe, 0xb4, 0x73, 0xd4, 0x8b,
-	0x23, 0x8f, 0xa9, 0xf8, 0x8d, 0xcc, 0xbd, 0x08, 0xf8, 0x07, 0xd8, 0x1c, 0x90, 0x89, 0x1f, 0x12,
-	0x19, 0x71, 0xea, 0x82, 0x8d, 0xc7, 0x9c, 0x0a, 0x25, 0x66, 0xbb, 0x31, 0x25, 0x23, 0x9c, 0xfa,
-	0x81, 0xaf, 0x23, 0xb4, 0x5d, 0x4d, 0xc8, 0xec, 0x9f, 0xd3, 0xb9, 0xba, 0xe8, 0x1a, 0x9e, 0x84,
-	0xc4, 0x3d, 0xa8, 0x0e, 0x7b, 0x03, 0x6d, 0x73, 0x09, 0x2c, 0x6b, 0x35, 0x8d, 0x8b, 0x83, 0x15,
-	0xcd, 0x83, 0x61, 0x1f, 0x4a, 0xc3, 0xde, 0x00, 0x3d, 0x82, 0xb2, 0x90, 0x95, 0x68, 0xa9, 0x4a,
-	0x74, 0x12, 0x04, 0x87, 0xbd, 0x81, 0xac, 0x37, 0xee, 0x2a, 0xee, 0xb2, 0x9b, 0xe2, 0xaa, 0x9b,
-	0x16, 0x54, 0x39, 0x0d, 0x3d, 0xe5, 0x48, 0xc7, 0x9b, 0xd2, 0xf8, 0xcf, 0x22, 0xd4, 0x24, 0x52,
-	0xfd, 0x4b, 0x1a, 0x0a, 0xb4, 0x0b, 0x36, 0x95, 0x8b, 0xd8, 0x25, 0x32, 0x93, 0xa6, 0x24, 0xb8,
-	0xab, 0x05, 0xd0, 0x1e, 0x94, 0x65, 0x93, 0x53, 0xf6, 0xea, 0xed, 0xd6, 0x9e, 0xee, 0x80, 0x7b,
-	0x49, 0x07, 0xdc, 0x3b, 0x49, 0x3a, 0xa0, 0xab, 0xe4, 0xd0, 0x31, 0x6c, 0x46, 0x3a, 0x21, 0xc2,
-	0x1f, 0xf9, 0x33, 0x12, 0x0a, 0x75, 0x7b, 0xea, 0xed, 0x8f, 0x4d, 0x1f, 0x06, 0x5b, 0xb9, 0x1b,
-	0x90, 0xf9, 0x94, 0x11, 0xef, 0xb0, 0xe0, 0x2e, 0x6b, 0xa3, 0x03, 0x68, 0xa8, 0x74, 0x87, 0x5c,
-	0x90, 0x70, 0x44, 0xd5, 0x93, 0x52, 0x6f, 0xef, 0x98, 0xd6, 0x12, 0xde, 0x92, 0xa9, 0x8c, 0x9e,
-	0xb4, 0xa3, 0x50, 0x4f, 0xec, 0x54, 0xb2, 0x76, 0x4e, 0x0d, 0xde, 0xb2, 0x1d, 0x53, 0xef, 0xeb,
-	0x1a, 0xac, 0xcd, 0x34, 0x0b, 0xff, 0x08, 0xf7, 0xdf, 0x71, 0x18, 0xf4, 0x08, 0xd6, 0x67, 0x0b,
-	0x56, 0x7a, 0xab, 0xb3, 0x9b, 0xb9, 0x97, 0xfb, 0x12, 0x9a, 0x79, 0x67, 0xfb, 0xa0, 0x85, 0x7d,
-	0x02, 0xcd, 0x3c, 0x2c, 0x6e, 0xd1, 0x64, 0x7f, 0x82, 0xea, 0x51, 0xb7, 0xaf, 0xeb, 0xe5, 0x23,
-	0xa8, 0x8d, 0x48, 0xe8, 0xf9, 0xf2, 0xcd, 0x8a, 0x8d, 0x2d, 0x36, 0xf2, 0x6a, 0x45, 0x5e, 0x6e,
-	0x9f, 0xbb, 0x34, 0x60, 0x42, 0x5f, 0xc6, 0xaa, 0x9b, 0xd2, 0xf8, 0x67, 0x65, 0xfd, 0x78, 0x3c,
-	0xa6, 0xd1, 0x35, 0xd6, 0xcd, 0x12, 0x29, 0x66, 0x4b, 0xe4, 0x5d, 0x1e, 0x1e, 0xbf, 0x80, 0xad,
-	0x95, 0xc6, 0x88, 0xaa, 0x50, 0x7e, 0x73, 0xfc, 0xa6, 0xef, 0x14, 0x50, 0x03, 0xaa, 0x83, 0xce,
-	0x70, 0xf8, 0xdd, 0xb1, 0xdb, 0x73, 0x2c, 0x54, 0x03, 0xfb, 0xb8, 0x73, 0x7a, 0x72, 0xe8, 0x14,
-	0x1f, 0x7f, 0xa9, 0xde, 0x09, 0x55, 0xc6, 0x6a, 0x5b, 0x86, 0xe8, 0x14, 0x10, 0x40, 0xa5, 0x13,
-	0xf2, 0xb7, 0x34, 0x72, 0x2c, 0xa5, 0x1b, 0x11, 0x4d, 0x15, 0x25, 0xe5, 0xb2, 0xe9, 0xf4, 0x8c,
-	0x8c, 0xce, 0x9d, 0xd2, 0xe3, 0x3f, 0x2c, 0x80, 0x45, 0x4d, 0x22, 0x07, 0x1a, 0x32, 0x37, 0xdf,
-	0xd2, 0xb1, 0x7a, 0xf5, 0x9c, 0x02, 0x42, 0xb0, 0x21, 0x77, 0xbe, 0x61, 0x7e, 0x48, 0x3d, 0xb5,
-	0x67, 0xa1, 0x4d, 0xa8, 0xcb, 0x55, 0x37, 0xa2, 0x44, 0x50, 0xcf, 0x29, 0xa2, 0x6d, 0x40, 0x46,
-	0x6f, 0xd0, 0xcd, 0xc2, 0x73, 0x4a, 0x68, 0x0b, 0xd6, 0xe5, 0x7e, 0x8f, 0x72, 0x11, 0xb1, 0x39,
-	0xf5, 0x9c, 0x72, 0x62, 0xcf, 0xa5, 0x13, 0x9f, 0x0b, 0x1a, 0x51, 0xcf, 0xb1, 0xa5, 0xba, 0x31,
-	0x6e, 0x25, 0xea, 0x15, 0xe9, 0x47, 0xcb, 0x06, 0xec, 0x92, 0x7a, 0xce, 0x5a, 0xfb, 0x6f, 0x1b,
-	0xee, 0x4a, 0x83, 0xaf, 0x49, 0x48, 0x26, 0x34, 0xa0, 0xa1, 0x90, 0x23, 0x8a, 0x3f, 0xa2, 0xe8,
-	0x39, 0x34, 0x12, 0x93, 0x6a, 0x6a, 0xbd, 0x93, 0x94, 0x9d, 0x39, 0x2d, 0xb7, 0x32, 0xed, 0x0d,
-	0x17, 0xd0, 0x13, 0x58, 0x8b, 0x67, 0xe0, 0x85, 0x82, 0x39, 0x14, 0xaf, 0x28, 0x3c, 0x87, 0x6a,
-	0xcc, 0xe7, 0xe8, 0x5e, 0xc2, 0x5b, 0x6a, 0x01, 0xad, 0x75, 0x53, 0x89, 0xe3, 0x02, 0xea, 0x03,
-	0x8a, 0xb5, 0x32, 0xc3, 0xc7, 0x95, 0x1e, 0xef, 0x99, 0xca, 0x86, 0x38, 0x2e, 0xa0, 0x2e, 0x6c,
-	0xad, 0xcc, 0xa6, 0xe8, 0x41, 0x2a, 0x7f, 0xe5, 0xd8, 0xba, 0x72, 0x82, 0x36, 0x80, 0xc6, 0xf3,
-	0x06, 0xa7, 0x6e, 0x03, 0xe8, 0x5c, 0xab, 0x71, 0xc4, 0x84, 0x36, 0x6d, 0x8d, 0xad, 0x4c, 0x5b,
-	0x4e, 0xa1, 0xcd, 0x2a, 0x98, 0xbd, 0x74, 0x45, 0x41, 0x43, 0xab, 0x5b, 0xfe, 0xf5, 0xd0, 0x2a,
-	0x39, 0x13, 0x13, 0xe3, 0xfe, 0x2d, 0x63, 0xb2, 0x3c, 0xb6, 0xac, 0xb8, 0x7e, 0x01, 0xeb, 0x1d,
-	0xcf, 0x93, 0x87, 0x3d, 0x61, 0x2a, 0xe2, 0xbb, 0x99, 0x31, 0x28, 0x37, 0xe4, 0x2f, 0xc0, 0x79,
-	0xe5, 0x8f, 0xce, 0xa5, 0xd0, 0x41, 0xc4, 0x82, 0x9b, 0xa8, 0x3e, 0x83, 0x7a, 0x5c, 0x15, 0xef,
-	0x0f, 0x51, 0xfb, 0xf7, 0x0a, 0x38, 0x43, 0xf5, 0x5d, 0xe9, 0x87, 0x93, 0xe4, 0xe6, 0x7f, 0x0e,
-	0xf0, 0x92, 0x8a, 0xe4, 0xe8, 0xdb, 0x2b, 0xfd, 0xb3, 0x2f, 0x3f, 0x2f, 0x5b, 0x9b, 0x29, 0xa2,
-	0x5a, 0x10, 0x17, 0xd0, 0x3e, 0xac, 0x67, 0x3e, 0x4b, 0x50, 0x2b, 0x0b, 0x5b, 0x06, 0xb2, 0x2b,
-	0xf4, 0x3f, 0x53, 0x8e, 0x5f, 0xcf, 0x75, 0xca, 0xf2, 0x1c, 0xaf, 0x64, 0xec, 0xc6, 0x17, 0xe3,
-	0xc6, 0x45, 0xda, 0x87, 0x7b, 0xea, 0x19, 0x1c, 0x52, 0xce, 0x7d, 0x16, 0xf6, 0x8c, 0x01, 0xc6,
-	0x1c, 0x7d, 0xb4, 0x72, 0x4e, 0xdc, 0xb8, 0x80, 0x0e, 0xa0, 0xa9, 0x9f, 0xd0, 0x5b, 0xda, 0xd9,
-	0x87, 0xff, 0x0d, 0x2f, 0xce, 0xa4, 0xee, 0x19, 0x1d, 0xf6, 0x06, 0x5d, 0x16, 0x04, 0x24, 0xf4,
-	0x72, 0x01, 0xab, 0x1b, 0xa6, 0x71, 0xe1, 0xa9, 0x85, 0xba, 0x80, 0x52, 0xfd, 0xc5, 0x80, 0x95,
-	0xa7, 0xbe, 0xb5, 0x32, 0x69, 0x29, 0x23, 0xfb, 0xe0, 0x0c, 0x69, 0xe8, 0xc9, 0x16, 0x93, 0xb6,
-	0x2a, 0xc7, 0xf8, 0x22, 0xbb, 0xee, 0x10, 0x7d, 0xb8, 0x9b, 0x06, 0x91, 0x31, 0x92, 0x17, 0x87,
-	0x69, 0x5c, 0x65, 0x43, 0x85, 0x71, 0x60, 0x98, 0xc9, 0xfc, 0x07, 0x90, 0x86, 0x9d, 0x7e, 0xe1,
-	0xb7, 0xd2, 0x64, 0x9b, 0x82, 0xb8, 0xb0, 0x6b, 0x3d, 0xb5, 0xce, 0xf4, 0x9f, 0x29, 0xcf, 0xfe,
-	0x09, 0x00, 0x00, 0xff, 0xff, 0x66, 0x06, 0xc3, 0xb1, 0x67, 0x11, 0x00, 0x00,
+func (m *Signal) Reset()         { *m = Signal{} }
+func (m *Signal) String() string { return proto.CompactTextString(m) }
+func (*Signal) ProtoMessage()    {}
+func (*Signal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{34}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *Signal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Signal.Unmarshal(m, b)
+}
+func (m *Signal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Signal.Marshal(b, m, deterministic)
+}
+func (m *Signal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Signal.Merge(m, src)
+}
+func (m *Signal) XXX_Size() int {
+	return xxx_messageInfo_Signal.Size(m)
+}
+func (m *Signal) XXX_DiscardUnknown() {
+	xxx_messageInfo_Signal.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_Signal proto.InternalMessageInfo
 
-// RoomManagementServiceClient is the client API for RoomManagementService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type RoomManagementServiceClient interface {
-	RegisterUser(ctx context.Context, in *NewUserParam, opts ...grpc.CallOption) (*User, error)
-	GetUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error)
-	GetUsers(ctx context.Context, in *PaginationParam, opts ...grpc.CallOption) (*Users, error)
-	GetUserAccessToken(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*UserAccessToken, error)
-	UpdateUserProfile(ctx context.Context, in *UpdateUserProfileParam, opts ...grpc.CallOption) (*User, error)
-	RemoveUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error)
-	CreateRoom(ctx context.Context, in *NewRoomParam, opts ...grpc.CallOption) (*Room, error)
-	GetRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error)
-	GetRooms(ctx context.Context, in *PaginationParam, opts ...grpc.CallOption) (*Rooms, error)
-	UpdateRoomProfile(ctx context.Context, in *UpdateRoomProfileParam, opts ...grpc.CallOption) (*Room, error)
-	AddUserToRoom(ctx context.Context, in *UserRoomParam, opts ...grpc.CallOption) (*Room, error)
-	KickUserFromRoom(ctx context.Context, in *UserRoomParam, opts ...grpc.CallOption) (*Room, error)
-	DestroyRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error)
+func (m *Signal) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
 }
 
-type roomManagementServiceClient struct {
-	cc *grpc.ClientConn
+func (m *Signal) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
 }
 
-func NewRoomManagementServiceClient(cc *grpc.ClientConn) RoomManagementServiceClient {
-	return &roomManagementServiceClient{cc}
+func (m *Signal) GetData() *_struct.Struct {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-func (c *roomManagementServiceClient) RegisterUser(ctx context.Context, in *NewUserParam, opts ...grpc.CallOption) (*User, error) {
-	out := new(User)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/RegisterUser", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Signal) GetSenderID() string {
+	if m != nil {
+		return m.SenderID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *roomManagementServiceClient) GetUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error) {
-	out := new(User)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/GetUser", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Signal) GetRoomID() string {
+	if m != nil {
+		return m.RoomID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *roomManagementServiceClient) GetUsers(ctx context.Context, in *PaginationParam, opts ...grpc.CallOption) (*Users, error) {
-	out := new(Users)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/GetUsers", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Signal) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
 	}
-	return out, nil
+	return nil
 }
 
-func (c *roomManagementServiceClient) GetUserAccessToken(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*UserAccessToken, error) {
-	out := new(UserAccessToken)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/GetUserAccessToken", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// message sent by client on Connect session
+type ClientMessage struct {
+	// echoed back on ack to correlate result
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//	*ClientMessage_Heartbeat
+	//	*ClientMessage_Sdp
+	//	*ClientMessage_Ice
+	//	*ClientMessage_Signal
+	Payload              isClientMessage_Payload `protobuf_oneof:"payload"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
-func (c *roomManagementServiceClient) UpdateUserProfile(ctx context.Context, in *UpdateUserProfileParam, opts ...grpc.CallOption) (*User, error) {
-	out := new(User)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/UpdateUserProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ClientMessage) Reset()         { *m = ClientMessage{} }
+func (m *ClientMessage) String() string { return proto.CompactTextString(m) }
+func (*ClientMessage) ProtoMessage()    {}
+func (*ClientMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{35}
 }
 
-func (c *roomManagementServiceClient) RemoveUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error) {
-	out := new(User)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/RemoveUser", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ClientMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientMessage.Unmarshal(m, b)
+}
+func (m *ClientMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientMessage.Marshal(b, m, deterministic)
+}
+func (m *ClientMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientMessage.Merge(m, src)
+}
+func (m *ClientMessage) XXX_Size() int {
+	return xxx_messageInfo_ClientMessage.Size(m)
+}
+func (m *ClientMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientMessage.DiscardUnknown(m)
 }
 
-func (c *roomManagementServiceClient) CreateRoom(ctx context.Context, in *NewRoomParam, opts ...grpc.CallOption) (*Room, error) {
-	out := new(Room)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/CreateRoom", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ClientMessage proto.InternalMessageInfo
+
+func (m *ClientMessage) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return ""
 }
 
-func (c *roomManagementServiceClient) GetRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error) {
-	out := new(Room)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/GetRoom", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type isClientMessage_Payload interface {
+	isClientMessage_Payload()
 }
 
-func (c *roomManagementServiceClient) GetRooms(ctx context.Context, in *PaginationParam, opts ...grpc.CallOption) (*Rooms, error) {
-	out := new(Rooms)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/GetRooms", in, out, opts...)
-	if err != nil {
-		return nil, err
+type ClientMessage_Heartbeat struct {
+	Heartbeat *Heartbeat `protobuf:"bytes,2,opt,name=heartbeat,proto3,oneof"`
+}
+
+type ClientMessage_Sdp struct {
+	Sdp *SDPParam `protobuf:"bytes,3,opt,name=sdp,proto3,oneof"`
+}
+
+type ClientMessage_Ice struct {
+	Ice *ICEParam `protobuf:"bytes,4,opt,name=ice,proto3,oneof"`
+}
+
+type ClientMessage_Signal struct {
+	Signal *SignalParam `protobuf:"bytes,5,opt,name=signal,proto3,oneof"`
+}
+
+func (*ClientMessage_Heartbeat) isClientMessage_Payload() {}
+
+func (*ClientMessage_Sdp) isClientMessage_Payload() {}
+
+func (*ClientMessage_Ice) isClientMessage_Payload() {}
+
+func (*ClientMessage_Signal) isClientMessage_Payload() {}
+
+func (m *ClientMessage) GetPayload() isClientMessage_Payload {
+	if m != nil {
+		return m.Payload
 	}
-	return out, nil
+	return nil
 }
 
-func (c *roomManagementServiceClient) UpdateRoomProfile(ctx context.Context, in *UpdateRoomProfileParam, opts ...grpc.CallOption) (*Room, error) {
-	out := new(Room)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/UpdateRoomProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ClientMessage) GetHeartbeat() *Heartbeat {
+	if x, ok := m.GetPayload().(*ClientMessage_Heartbeat); ok {
+		return x.Heartbeat
 	}
-	return out, nil
+	return nil
 }
 
-func (c *roomManagementServiceClient) AddUserToRoom(ctx context.Context, in *UserRoomParam, opts ...grpc.CallOption) (*Room, error) {
-	out := new(Room)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/AddUserToRoom", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ClientMessage) GetSdp() *SDPParam {
+	if x, ok := m.GetPayload().(*ClientMessage_Sdp); ok {
+		return x.Sdp
 	}
-	return out, nil
+	return nil
 }
 
-func (c *roomManagementServiceClient) KickUserFromRoom(ctx context.Context, in *UserRoomParam, opts ...grpc.CallOption) (*Room, error) {
-	out := new(Room)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/KickUserFromRoom", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ClientMessage) GetIce() *ICEParam {
+	if x, ok := m.GetPayload().(*ClientMessage_Ice); ok {
+		return x.Ice
 	}
-	return out, nil
+	return nil
 }
 
-func (c *roomManagementServiceClient) DestroyRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error) {
-	out := new(Room)
-	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/DestroyRoom", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ClientMessage) GetSignal() *SignalParam {
+	if x, ok := m.GetPayload().(*ClientMessage_Signal); ok {
+		return x.Signal
 	}
-	return out, nil
+	return nil
 }
 
-// RoomManagementServiceServer is the server API for RoomManagementService service.
-type RoomManagementServiceServer interface {
-	RegisterUser(context.Context, *NewUserParam) (*User, error)
-	GetUser(context.Context, *GetUserParam) (*User, error)
-	GetUsers(context.Context, *PaginationParam) (*Users, error)
-	GetUserAccessToken(context.Context, *GetUserParam) (*UserAccessToken, error)
-	UpdateUserProfile(context.Context, *UpdateUserProfileParam) (*User, error)
-	RemoveUser(context.Context, *GetUserParam) (*User, error)
-	CreateRoom(context.Context, *NewRoomParam) (*Room, error)
-	GetRoom(context.Context, *GetRoomParam) (*Room, error)
-	GetRooms(context.Context, *PaginationParam) (*Rooms, error)
-	UpdateRoomProfile(context.Context, *UpdateRoomProfileParam) (*Room, error)
-	AddUserToRoom(context.Context, *UserRoomParam) (*Room, error)
-	KickUserFromRoom(context.Context, *UserRoomParam) (*Room, error)
-	DestroyRoom(context.Context, *GetRoomParam) (*Room, error)
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ClientMessage) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ClientMessage_Heartbeat)(nil),
+		(*ClientMessage_Sdp)(nil),
+		(*ClientMessage_Ice)(nil),
+		(*ClientMessage_Signal)(nil),
+	}
 }
 
-// UnimplementedRoomManagementServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedRoomManagementServiceServer struct {
+// message sent by server on Connect session
+type ServerMessage struct {
+	// Types that are valid to be assigned to Payload:
+	//	*ServerMessage_Ack
+	//	*ServerMessage_Sdp
+	//	*ServerMessage_Ice
+	//	*ServerMessage_RoomEvent
+	//	*ServerMessage_OnlineStatus
+	//	*ServerMessage_Signal
+	//	*ServerMessage_CallEvent
+	//	*ServerMessage_RoomCallEvent
+	Payload              isServerMessage_Payload `protobuf_oneof:"payload"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
-func (*UnimplementedRoomManagementServiceServer) RegisterUser(ctx context.Context, req *NewUserParam) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
+func (m *ServerMessage) Reset()         { *m = ServerMessage{} }
+func (m *ServerMessage) String() string { return proto.CompactTextString(m) }
+func (*ServerMessage) ProtoMessage()    {}
+func (*ServerMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{36}
 }
-func (*UnimplementedRoomManagementServiceServer) GetUser(ctx context.Context, req *GetUserParam) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+
+func (m *ServerMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerMessage.Unmarshal(m, b)
 }
-func (*UnimplementedRoomManagementServiceServer) GetUsers(ctx context.Context, req *PaginationParam) (*Users, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUsers not implemented")
+func (m *ServerMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerMessage.Marshal(b, m, deterministic)
 }
-func (*UnimplementedRoomManagementServiceServer) GetUserAccessToken(ctx context.Context, req *GetUserParam) (*UserAccessToken, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserAccessToken not implemented")
+func (m *ServerMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerMessage.Merge(m, src)
 }
-func (*UnimplementedRoomManagementServiceServer) UpdateUserProfile(ctx context.Context, req *UpdateUserProfileParam) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserProfile not implemented")
+func (m *ServerMessage) XXX_Size() int {
+	return xxx_messageInfo_ServerMessage.Size(m)
 }
-func (*UnimplementedRoomManagementServiceServer) RemoveUser(ctx context.Context, req *GetUserParam) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveUser not implemented")
+func (m *ServerMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerMessage.DiscardUnknown(m)
 }
-func (*UnimplementedRoomManagementServiceServer) CreateRoom(ctx context.Context, req *NewRoomParam) (*Room, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+
+var xxx_messageInfo_ServerMessage proto.InternalMessageInfo
+
+type isServerMessage_Payload interface {
+	isServerMessage_Payload()
 }
-func (*UnimplementedRoomManagementServiceServer) GetRoom(ctx context.Context, req *GetRoomParam) (*Room, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRoom not implemented")
+
+type ServerMessage_Ack struct {
+	Ack *Ack `protobuf:"bytes,1,opt,name=ack,proto3,oneof"`
 }
-func (*UnimplementedRoomManagementServiceServer) GetRooms(ctx context.Context, req *PaginationParam) (*Rooms, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRooms not implemented")
+
+type ServerMessage_Sdp struct {
+	Sdp *SDP `protobuf:"bytes,2,opt,name=sdp,proto3,oneof"`
 }
-func (*UnimplementedRoomManagementServiceServer) UpdateRoomProfile(ctx context.Context, req *UpdateRoomProfileParam) (*Room, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoomProfile not implemented")
+
+type ServerMessage_Ice struct {
+	Ice *ICEOffer `protobuf:"bytes,3,opt,name=ice,proto3,oneof"`
 }
-func (*UnimplementedRoomManagementServiceServer) AddUserToRoom(ctx context.Context, req *UserRoomParam) (*Room, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddUserToRoom not implemented")
+
+type ServerMessage_RoomEvent struct {
+	RoomEvent *RoomEvent `protobuf:"bytes,4,opt,name=roomEvent,proto3,oneof"`
 }
-func (*UnimplementedRoomManagementServiceServer) KickUserFromRoom(ctx context.Context, req *UserRoomParam) (*Room, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method KickUserFromRoom not implemented")
+
+type ServerMessage_OnlineStatus struct {
+	OnlineStatus *OnlineStatus `protobuf:"bytes,5,opt,name=onlineStatus,proto3,oneof"`
 }
-func (*UnimplementedRoomManagementServiceServer) DestroyRoom(ctx context.Context, req *GetRoomParam) (*Room, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DestroyRoom not implemented")
+
+type ServerMessage_Signal struct {
+	Signal *Signal `protobuf:"bytes,6,opt,name=signal,proto3,oneof"`
 }
 
-func RegisterRoomManagementServiceServer(s *grpc.Server, srv RoomManagementServiceServer) {
-	s.RegisterService(&_RoomManagementService_serviceDesc, srv)
+type ServerMessage_CallEvent struct {
+	CallEvent *CallEvent `protobuf:"bytes,7,opt,name=callEvent,proto3,oneof"`
 }
 
-func _RoomManagementService_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewUserParam)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).RegisterUser(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/RegisterUser",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).RegisterUser(ctx, req.(*NewUserParam))
-	}
-	return interceptor(ctx, in, info, handler)
+type ServerMessage_RoomCallEvent struct {
+	RoomCallEvent *RoomCallEvent `protobuf:"bytes,8,opt,name=roomCallEvent,proto3,oneof"`
+}
+
+func (*ServerMessage_Ack) isServerMessage_Payload() {}
+
+func (*ServerMessage_Sdp) isServerMessage_Payload() {}
+
+func (*ServerMessage_Ice) isServerMessage_Payload() {}
+
+func (*ServerMessage_RoomEvent) isServerMessage_Payload() {}
+
+func (*ServerMessage_OnlineStatus) isServerMessage_Payload() {}
+
+func (*ServerMessage_Signal) isServerMessage_Payload() {}
+
+func (*ServerMessage_CallEvent) isServerMessage_Payload() {}
+
+func (*ServerMessage_RoomCallEvent) isServerMessage_Payload() {}
+
+func (m *ServerMessage) GetPayload() isServerMessage_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *ServerMessage) GetAck() *Ack {
+	if x, ok := m.GetPayload().(*ServerMessage_Ack); ok {
+		return x.Ack
+	}
+	return nil
+}
+
+func (m *ServerMessage) GetSdp() *SDP {
+	if x, ok := m.GetPayload().(*ServerMessage_Sdp); ok {
+		return x.Sdp
+	}
+	return nil
+}
+
+func (m *ServerMessage) GetIce() *ICEOffer {
+	if x, ok := m.GetPayload().(*ServerMessage_Ice); ok {
+		return x.Ice
+	}
+	return nil
+}
+
+func (m *ServerMessage) GetRoomEvent() *RoomEvent {
+	if x, ok := m.GetPayload().(*ServerMessage_RoomEvent); ok {
+		return x.RoomEvent
+	}
+	return nil
+}
+
+func (m *ServerMessage) GetOnlineStatus() *OnlineStatus {
+	if x, ok := m.GetPayload().(*ServerMessage_OnlineStatus); ok {
+		return x.OnlineStatus
+	}
+	return nil
+}
+
+func (m *ServerMessage) GetSignal() *Signal {
+	if x, ok := m.GetPayload().(*ServerMessage_Signal); ok {
+		return x.Signal
+	}
+	return nil
+}
+
+func (m *ServerMessage) GetCallEvent() *CallEvent {
+	if x, ok := m.GetPayload().(*ServerMessage_CallEvent); ok {
+		return x.CallEvent
+	}
+	return nil
+}
+
+func (m *ServerMessage) GetRoomCallEvent() *RoomCallEvent {
+	if x, ok := m.GetPayload().(*ServerMessage_RoomCallEvent); ok {
+		return x.RoomCallEvent
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ServerMessage) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ServerMessage_Ack)(nil),
+		(*ServerMessage_Sdp)(nil),
+		(*ServerMessage_Ice)(nil),
+		(*ServerMessage_RoomEvent)(nil),
+		(*ServerMessage_OnlineStatus)(nil),
+		(*ServerMessage_Signal)(nil),
+		(*ServerMessage_CallEvent)(nil),
+		(*ServerMessage_RoomCallEvent)(nil),
+	}
+}
+
+// result of client message, code follow gRPC status codes
+type Ack struct {
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code    int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// seconds to wait before retry when rate limited
+	RetryAfter int64 `protobuf:"varint,4,opt,name=retryAfter,proto3" json:"retryAfter,omitempty"`
+	// delivery of SDP & ICE candidate
+	Delivery             DeliveryStatus `protobuf:"varint,5,opt,name=delivery,proto3,enum=protos.DeliveryStatus" json:"delivery,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Ack) Reset()         { *m = Ack{} }
+func (m *Ack) String() string { return proto.CompactTextString(m) }
+func (*Ack) ProtoMessage()    {}
+func (*Ack) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{37}
+}
+
+func (m *Ack) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ack.Unmarshal(m, b)
+}
+func (m *Ack) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ack.Marshal(b, m, deterministic)
+}
+func (m *Ack) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ack.Merge(m, src)
+}
+func (m *Ack) XXX_Size() int {
+	return xxx_messageInfo_Ack.Size(m)
+}
+func (m *Ack) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ack.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ack proto.InternalMessageInfo
+
+func (m *Ack) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Ack) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *Ack) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Ack) GetRetryAfter() int64 {
+	if m != nil {
+		return m.RetryAfter
+	}
+	return 0
+}
+
+func (m *Ack) GetDelivery() DeliveryStatus {
+	if m != nil {
+		return m.Delivery
+	}
+	return DeliveryStatus_Unspecified
+}
+
+// delivery of SDP & ICE candidate to target peer
+type Delivery struct {
+	Status               DeliveryStatus `protobuf:"varint,1,opt,name=status,proto3,enum=protos.DeliveryStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Delivery) Reset()         { *m = Delivery{} }
+func (m *Delivery) String() string { return proto.CompactTextString(m) }
+func (*Delivery) ProtoMessage()    {}
+func (*Delivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{38}
+}
+
+func (m *Delivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Delivery.Unmarshal(m, b)
+}
+func (m *Delivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Delivery.Marshal(b, m, deterministic)
+}
+func (m *Delivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Delivery.Merge(m, src)
+}
+func (m *Delivery) XXX_Size() int {
+	return xxx_messageInfo_Delivery.Size(m)
+}
+func (m *Delivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_Delivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Delivery proto.InternalMessageInfo
+
+func (m *Delivery) GetStatus() DeliveryStatus {
+	if m != nil {
+		return m.Status
+	}
+	return DeliveryStatus_Unspecified
+}
+
+type RoomEvent struct {
+	Event RoomEvents           `protobuf:"varint,1,opt,name=event,proto3,enum=protos.RoomEvents" json:"event,omitempty"`
+	Time  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//	*RoomEvent_RoomParticipant
+	//	*RoomEvent_RoomInstance
+	//	*RoomEvent_UserInstance
+	Payload              isRoomEvent_Payload `protobuf_oneof:"payload"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *RoomEvent) Reset()         { *m = RoomEvent{} }
+func (m *RoomEvent) String() string { return proto.CompactTextString(m) }
+func (*RoomEvent) ProtoMessage()    {}
+func (*RoomEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{39}
+}
+
+func (m *RoomEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoomEvent.Unmarshal(m, b)
+}
+func (m *RoomEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoomEvent.Marshal(b, m, deterministic)
+}
+func (m *RoomEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoomEvent.Merge(m, src)
+}
+func (m *RoomEvent) XXX_Size() int {
+	return xxx_messageInfo_RoomEvent.Size(m)
+}
+func (m *RoomEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoomEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoomEvent proto.InternalMessageInfo
+
+func (m *RoomEvent) GetEvent() RoomEvents {
+	if m != nil {
+		return m.Event
+	}
+	return RoomEvents_UserLeftRoom
+}
+
+func (m *RoomEvent) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+type isRoomEvent_Payload interface {
+	isRoomEvent_Payload()
+}
+
+type RoomEvent_RoomParticipant struct {
+	RoomParticipant *RoomParticipantEventPayload `protobuf:"bytes,4,opt,name=roomParticipant,proto3,oneof"`
+}
+
+type RoomEvent_RoomInstance struct {
+	RoomInstance *RoomInstanceEventPayload `protobuf:"bytes,5,opt,name=roomInstance,proto3,oneof"`
+}
+
+type RoomEvent_UserInstance struct {
+	UserInstance *UserInstanceEventPayload `protobuf:"bytes,6,opt,name=userInstance,proto3,oneof"`
+}
+
+func (*RoomEvent_RoomParticipant) isRoomEvent_Payload() {}
+
+func (*RoomEvent_RoomInstance) isRoomEvent_Payload() {}
+
+func (*RoomEvent_UserInstance) isRoomEvent_Payload() {}
+
+func (m *RoomEvent) GetPayload() isRoomEvent_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *RoomEvent) GetRoomParticipant() *RoomParticipantEventPayload {
+	if x, ok := m.GetPayload().(*RoomEvent_RoomParticipant); ok {
+		return x.RoomParticipant
+	}
+	return nil
+}
+
+func (m *RoomEvent) GetRoomInstance() *RoomInstanceEventPayload {
+	if x, ok := m.GetPayload().(*RoomEvent_RoomInstance); ok {
+		return x.RoomInstance
+	}
+	return nil
+}
+
+func (m *RoomEvent) GetUserInstance() *UserInstanceEventPayload {
+	if x, ok := m.GetPayload().(*RoomEvent_UserInstance); ok {
+		return x.UserInstance
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*RoomEvent) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*RoomEvent_RoomParticipant)(nil),
+		(*RoomEvent_RoomInstance)(nil),
+		(*RoomEvent_UserInstance)(nil),
+	}
+}
+
+type RoomParticipantEventPayload struct {
+	ParticipantID        string   `protobuf:"bytes,1,opt,name=participantID,proto3" json:"participantID,omitempty"`
+	RoomID               string   `protobuf:"bytes,2,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoomParticipantEventPayload) Reset()         { *m = RoomParticipantEventPayload{} }
+func (m *RoomParticipantEventPayload) String() string { return proto.CompactTextString(m) }
+func (*RoomParticipantEventPayload) ProtoMessage()    {}
+func (*RoomParticipantEventPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{40}
+}
+
+func (m *RoomParticipantEventPayload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoomParticipantEventPayload.Unmarshal(m, b)
+}
+func (m *RoomParticipantEventPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoomParticipantEventPayload.Marshal(b, m, deterministic)
+}
+func (m *RoomParticipantEventPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoomParticipantEventPayload.Merge(m, src)
+}
+func (m *RoomParticipantEventPayload) XXX_Size() int {
+	return xxx_messageInfo_RoomParticipantEventPayload.Size(m)
+}
+func (m *RoomParticipantEventPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoomParticipantEventPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoomParticipantEventPayload proto.InternalMessageInfo
+
+func (m *RoomParticipantEventPayload) GetParticipantID() string {
+	if m != nil {
+		return m.ParticipantID
+	}
+	return ""
+}
+
+func (m *RoomParticipantEventPayload) GetRoomID() string {
+	if m != nil {
+		return m.RoomID
+	}
+	return ""
+}
+
+type RoomInstanceEventPayload struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Photo                string   `protobuf:"bytes,3,opt,name=photo,proto3" json:"photo,omitempty"`
+	Description          string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoomInstanceEventPayload) Reset()         { *m = RoomInstanceEventPayload{} }
+func (m *RoomInstanceEventPayload) String() string { return proto.CompactTextString(m) }
+func (*RoomInstanceEventPayload) ProtoMessage()    {}
+func (*RoomInstanceEventPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{41}
+}
+
+func (m *RoomInstanceEventPayload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoomInstanceEventPayload.Unmarshal(m, b)
+}
+func (m *RoomInstanceEventPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoomInstanceEventPayload.Marshal(b, m, deterministic)
+}
+func (m *RoomInstanceEventPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoomInstanceEventPayload.Merge(m, src)
+}
+func (m *RoomInstanceEventPayload) XXX_Size() int {
+	return xxx_messageInfo_RoomInstanceEventPayload.Size(m)
+}
+func (m *RoomInstanceEventPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoomInstanceEventPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoomInstanceEventPayload proto.InternalMessageInfo
+
+func (m *RoomInstanceEventPayload) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RoomInstanceEventPayload) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RoomInstanceEventPayload) GetPhoto() string {
+	if m != nil {
+		return m.Photo
+	}
+	return ""
+}
+
+func (m *RoomInstanceEventPayload) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type UserInstanceEventPayload struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Photo                string   `protobuf:"bytes,3,opt,name=photo,proto3" json:"photo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserInstanceEventPayload) Reset()         { *m = UserInstanceEventPayload{} }
+func (m *UserInstanceEventPayload) String() string { return proto.CompactTextString(m) }
+func (*UserInstanceEventPayload) ProtoMessage()    {}
+func (*UserInstanceEventPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{42}
+}
+
+func (m *UserInstanceEventPayload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserInstanceEventPayload.Unmarshal(m, b)
+}
+func (m *UserInstanceEventPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserInstanceEventPayload.Marshal(b, m, deterministic)
+}
+func (m *UserInstanceEventPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserInstanceEventPayload.Merge(m, src)
+}
+func (m *UserInstanceEventPayload) XXX_Size() int {
+	return xxx_messageInfo_UserInstanceEventPayload.Size(m)
+}
+func (m *UserInstanceEventPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserInstanceEventPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserInstanceEventPayload proto.InternalMessageInfo
+
+func (m *UserInstanceEventPayload) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UserInstanceEventPayload) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UserInstanceEventPayload) GetPhoto() string {
+	if m != nil {
+		return m.Photo
+	}
+	return ""
+}
+
+type ICEParam struct {
+	Candidate string `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	UserID    string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	IsRemote  bool   `protobuf:"varint,3,opt,name=isRemote,proto3" json:"isRemote,omitempty"`
+	SdpMid    string `protobuf:"bytes,4,opt,name=sdpMid,proto3" json:"sdpMid,omitempty"`
+	// null when candidate not bound to media line index
+	SdpMLineIndex    *wrappers.UInt32Value `protobuf:"bytes,5,opt,name=sdpMLineIndex,proto3" json:"sdpMLineIndex,omitempty"`
+	UsernameFragment string                `protobuf:"bytes,6,opt,name=usernameFragment,proto3" json:"usernameFragment,omitempty"`
+	// sender gathered all candidates, candidate left empty
+	EndOfCandidates bool `protobuf:"varint,7,opt,name=endOfCandidates,proto3" json:"endOfCandidates,omitempty"`
+	// sender restarted ICE with new credentials, candidates of previous
+	// ICE session should be discarded, candidate left empty
+	IceRestart bool `protobuf:"varint,8,opt,name=iceRestart,proto3" json:"iceRestart,omitempty"`
+	// target device, empty to send to device pinned on the negotiation
+	DeviceID string `protobuf:"bytes,9,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
+	// peer connection this candidate belong to, empty for default connection,
+	// at most 64 letters, digits, dot, colon, dash or underscore
+	ConnectionID string `protobuf:"bytes,10,opt,name=connectionID,proto3" json:"connectionID,omitempty"`
+	// call this candidate belong to, route candidate to device pinned on the call
+	CallID               string   `protobuf:"bytes,11,opt,name=callID,proto3" json:"callID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ICEParam) Reset()         { *m = ICEParam{} }
+func (m *ICEParam) String() string { return proto.CompactTextString(m) }
+func (*ICEParam) ProtoMessage()    {}
+func (*ICEParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{43}
+}
+
+func (m *ICEParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ICEParam.Unmarshal(m, b)
+}
+func (m *ICEParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ICEParam.Marshal(b, m, deterministic)
+}
+func (m *ICEParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ICEParam.Merge(m, src)
+}
+func (m *ICEParam) XXX_Size() int {
+	return xxx_messageInfo_ICEParam.Size(m)
+}
+func (m *ICEParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_ICEParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ICEParam proto.InternalMessageInfo
+
+func (m *ICEParam) GetCandidate() string {
+	if m != nil {
+		return m.Candidate
+	}
+	return ""
+}
+
+func (m *ICEParam) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *ICEParam) GetIsRemote() bool {
+	if m != nil {
+		return m.IsRemote
+	}
+	return false
+}
+
+func (m *ICEParam) GetSdpMid() string {
+	if m != nil {
+		return m.SdpMid
+	}
+	return ""
+}
+
+func (m *ICEParam) GetSdpMLineIndex() *wrappers.UInt32Value {
+	if m != nil {
+		return m.SdpMLineIndex
+	}
+	return nil
+}
+
+func (m *ICEParam) GetUsernameFragment() string {
+	if m != nil {
+		return m.UsernameFragment
+	}
+	return ""
+}
+
+func (m *ICEParam) GetEndOfCandidates() bool {
+	if m != nil {
+		return m.EndOfCandidates
+	}
+	return false
+}
+
+func (m *ICEParam) GetIceRestart() bool {
+	if m != nil {
+		return m.IceRestart
+	}
+	return false
+}
+
+func (m *ICEParam) GetDeviceID() string {
+	if m != nil {
+		return m.DeviceID
+	}
+	return ""
+}
+
+func (m *ICEParam) GetConnectionID() string {
+	if m != nil {
+		return m.ConnectionID
+	}
+	return ""
+}
+
+func (m *ICEParam) GetCallID() string {
+	if m != nil {
+		return m.CallID
+	}
+	return ""
+}
+
+type ICEOffer struct {
+	Candidate            string                `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	SenderID             string                `protobuf:"bytes,2,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	IsRemote             bool                  `protobuf:"varint,3,opt,name=isRemote,proto3" json:"isRemote,omitempty"`
+	SdpMid               string                `protobuf:"bytes,4,opt,name=sdpMid,proto3" json:"sdpMid,omitempty"`
+	SdpMLineIndex        *wrappers.UInt32Value `protobuf:"bytes,5,opt,name=sdpMLineIndex,proto3" json:"sdpMLineIndex,omitempty"`
+	UsernameFragment     string                `protobuf:"bytes,6,opt,name=usernameFragment,proto3" json:"usernameFragment,omitempty"`
+	EndOfCandidates      bool                  `protobuf:"varint,7,opt,name=endOfCandidates,proto3" json:"endOfCandidates,omitempty"`
+	IceRestart           bool                  `protobuf:"varint,8,opt,name=iceRestart,proto3" json:"iceRestart,omitempty"`
+	SenderDeviceID       string                `protobuf:"bytes,9,opt,name=senderDeviceID,proto3" json:"senderDeviceID,omitempty"`
+	ConnectionID         string                `protobuf:"bytes,10,opt,name=connectionID,proto3" json:"connectionID,omitempty"`
+	CallID               string                `protobuf:"bytes,11,opt,name=callID,proto3" json:"callID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ICEOffer) Reset()         { *m = ICEOffer{} }
+func (m *ICEOffer) String() string { return proto.CompactTextString(m) }
+func (*ICEOffer) ProtoMessage()    {}
+func (*ICEOffer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39f66308029891ad, []int{44}
+}
+
+func (m *ICEOffer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ICEOffer.Unmarshal(m, b)
+}
+func (m *ICEOffer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ICEOffer.Marshal(b, m, deterministic)
+}
+func (m *ICEOffer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ICEOffer.Merge(m, src)
+}
+func (m *ICEOffer) XXX_Size() int {
+	return xxx_messageInfo_ICEOffer.Size(m)
+}
+func (m *ICEOffer) XXX_DiscardUnknown() {
+	xxx_messageInfo_ICEOffer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ICEOffer proto.InternalMessageInfo
+
+func (m *ICEOffer) GetCandidate() string {
+	if m != nil {
+		return m.Candidate
+	}
+	return ""
+}
+
+func (m *ICEOffer) GetSenderID() string {
+	if m != nil {
+		return m.SenderID
+	}
+	return ""
+}
+
+func (m *ICEOffer) GetIsRemote() bool {
+	if m != nil {
+		return m.IsRemote
+	}
+	return false
+}
+
+func (m *ICEOffer) GetSdpMid() string {
+	if m != nil {
+		return m.SdpMid
+	}
+	return ""
+}
+
+func (m *ICEOffer) GetSdpMLineIndex() *wrappers.UInt32Value {
+	if m != nil {
+		return m.SdpMLineIndex
+	}
+	return nil
+}
+
+func (m *ICEOffer) GetUsernameFragment() string {
+	if m != nil {
+		return m.UsernameFragment
+	}
+	return ""
+}
+
+func (m *ICEOffer) GetEndOfCandidates() bool {
+	if m != nil {
+		return m.EndOfCandidates
+	}
+	return false
+}
+
+func (m *ICEOffer) GetIceRestart() bool {
+	if m != nil {
+		return m.IceRestart
+	}
+	return false
+}
+
+func (m *ICEOffer) GetSenderDeviceID() string {
+	if m != nil {
+		return m.SenderDeviceID
+	}
+	return ""
+}
+
+func (m *ICEOffer) GetConnectionID() string {
+	if m != nil {
+		return m.ConnectionID
+	}
+	return ""
+}
+
+func (m *ICEOffer) GetCallID() string {
+	if m != nil {
+		return m.CallID
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("protos.PresenceState", PresenceState_name, PresenceState_value)
+	proto.RegisterEnum("protos.ICECredentialType", ICECredentialType_name, ICECredentialType_value)
+	proto.RegisterEnum("protos.CallStates", CallStates_name, CallStates_value)
+	proto.RegisterEnum("protos.RoomCallEvents", RoomCallEvents_name, RoomCallEvents_value)
+	proto.RegisterEnum("protos.DeliveryStatus", DeliveryStatus_name, DeliveryStatus_value)
+	proto.RegisterEnum("protos.SDPTypes", SDPTypes_name, SDPTypes_value)
+	proto.RegisterEnum("protos.RoomEvents", RoomEvents_name, RoomEvents_value)
+	proto.RegisterType((*NewUserParam)(nil), "protos.NewUserParam")
+	proto.RegisterType((*GetUserParam)(nil), "protos.GetUserParam")
+	proto.RegisterType((*User)(nil), "protos.User")
+	proto.RegisterType((*OnlineStatus)(nil), "protos.OnlineStatus")
+	proto.RegisterType((*Heartbeat)(nil), "protos.Heartbeat")
+	proto.RegisterType((*PresenceParam)(nil), "protos.PresenceParam")
+	proto.RegisterType((*GetPresenceParam)(nil), "protos.GetPresenceParam")
+	proto.RegisterType((*OnlineStatuses)(nil), "protos.OnlineStatuses")
+	proto.RegisterType((*Users)(nil), "protos.Users")
+	proto.RegisterType((*UpdateUserProfileParam)(nil), "protos.UpdateUserProfileParam")
+	proto.RegisterType((*UpdateProfileParam)(nil), "protos.UpdateProfileParam")
+	proto.RegisterType((*Profile)(nil), "protos.Profile")
+	proto.RegisterType((*ICEServer)(nil), "protos.ICEServer")
+	proto.RegisterType((*ICEServers)(nil), "protos.ICEServers")
+	proto.RegisterType((*UserAccessTokenParam)(nil), "protos.UserAccessTokenParam")
+	proto.RegisterType((*UserAccessToken)(nil), "protos.UserAccessToken")
+	proto.RegisterType((*RefreshTokenParam)(nil), "protos.RefreshTokenParam")
+	proto.RegisterType((*NewRoomParam)(nil), "protos.NewRoomParam")
+	proto.RegisterType((*Room)(nil), "protos.Room")
+	proto.RegisterType((*UpdateRoomProfileParam)(nil), "protos.UpdateRoomProfileParam")
+	proto.RegisterType((*Rooms)(nil), "protos.Rooms")
+	proto.RegisterType((*UserRoomParam)(nil), "protos.UserRoomParam")
+	proto.RegisterType((*GetRoomParam)(nil), "protos.GetRoomParam")
+	proto.RegisterType((*PaginationParam)(nil), "protos.PaginationParam")
+	proto.RegisterType((*SDPParam)(nil), "protos.SDPParam")
+	proto.RegisterType((*SDP)(nil), "protos.SDP")
+	proto.RegisterType((*StartCallParam)(nil), "protos.StartCallParam")
+	proto.RegisterType((*CallParam)(nil), "protos.CallParam")
+	proto.RegisterType((*Call)(nil), "protos.Call")
+	proto.RegisterType((*CallEvent)(nil), "protos.CallEvent")
+	proto.RegisterType((*RoomCallParam)(nil), "protos.RoomCallParam")
+	proto.RegisterType((*RoomCall)(nil), "protos.RoomCall")
+	proto.RegisterType((*RoomCallEvent)(nil), "protos.RoomCallEvent")
+	proto.RegisterType((*SignalParam)(nil), "protos.SignalParam")
+	proto.RegisterType((*Signal)(nil), "protos.Signal")
+	proto.RegisterType((*ClientMessage)(nil), "protos.ClientMessage")
+	proto.RegisterType((*ServerMessage)(nil), "protos.ServerMessage")
+	proto.RegisterType((*Ack)(nil), "protos.Ack")
+	proto.RegisterType((*Delivery)(nil), "protos.Delivery")
+	proto.RegisterType((*RoomEvent)(nil), "protos.RoomEvent")
+	proto.RegisterType((*RoomParticipantEventPayload)(nil), "protos.RoomParticipantEventPayload")
+	proto.RegisterType((*RoomInstanceEventPayload)(nil), "protos.RoomInstanceEventPayload")
+	proto.RegisterType((*UserInstanceEventPayload)(nil), "protos.UserInstanceEventPayload")
+	proto.RegisterType((*ICEParam)(nil), "protos.ICEParam")
+	proto.RegisterType((*ICEOffer)(nil), "protos.ICEOffer")
+}
+
+func init() { proto.RegisterFile("signalling.proto", fileDescriptor_39f66308029891ad) }
+
+var fileDescriptor_39f66308029891ad = []byte{
+	// 2927 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x1a, 0x4d, 0x73, 0xe4, 0x46,
+	0x75, 0x34, 0xdf, 0xf3, 0x66, 0xc6, 0xd6, 0x76, 0x62, 0xef, 0x30, 0xd9, 0xda, 0xb8, 0x44, 0x0a,
+	0x5c, 0x4e, 0x70, 0x12, 0x6f, 0x48, 0x36, 0x81, 0xdd, 0x30, 0xeb, 0xf1, 0xc6, 0x4e, 0x76, 0xd7,
+	0x46, 0x63, 0x43, 0xa5, 0x20, 0x07, 0x59, 0xea, 0x99, 0x15, 0xd6, 0x48, 0x53, 0x6a, 0x8d, 0x37,
+	0xbe, 0x51, 0x05, 0x95, 0x1b, 0x57, 0xe0, 0xcc, 0x95, 0xff, 0x00, 0x55, 0x54, 0x51, 0x14, 0x47,
+	0x4e, 0xf0, 0x0f, 0xf8, 0x17, 0x14, 0xf5, 0xba, 0x5b, 0x52, 0x4b, 0x9a, 0xf1, 0x47, 0x96, 0x9c,
+	0x38, 0x8d, 0x5e, 0xf7, 0x7b, 0xdd, 0xef, 0x5b, 0xef, 0x3d, 0x0d, 0xe8, 0xcc, 0x9d, 0xf8, 0x96,
+	0xe7, 0xb9, 0xfe, 0x64, 0x7b, 0x16, 0x06, 0x51, 0x40, 0xea, 0xfc, 0x87, 0xf5, 0x5f, 0x9b, 0x04,
+	0xc1, 0xc4, 0xa3, 0x6f, 0x73, 0xf0, 0x74, 0x3e, 0x7e, 0x9b, 0x4e, 0x67, 0xd1, 0x85, 0x40, 0xea,
+	0xdf, 0xc9, 0x6f, 0xb2, 0x28, 0x9c, 0xdb, 0x91, 0xdc, 0x7d, 0x3d, 0xbf, 0x1b, 0xb9, 0x53, 0xca,
+	0x22, 0x6b, 0x3a, 0x93, 0x08, 0x77, 0xf3, 0x08, 0x2f, 0x42, 0x6b, 0x36, 0xa3, 0x21, 0x13, 0xfb,
+	0xc6, 0x3e, 0x74, 0x9e, 0xd1, 0x17, 0x27, 0x8c, 0x86, 0x47, 0x56, 0x68, 0x4d, 0xc9, 0x0a, 0x94,
+	0x5d, 0xa7, 0xa7, 0x6d, 0x68, 0x9b, 0x2d, 0xb3, 0xec, 0x3a, 0x84, 0x40, 0xd5, 0xb7, 0xa6, 0xb4,
+	0x57, 0xe6, 0x2b, 0xfc, 0x99, 0xbc, 0x0a, 0xb5, 0xd9, 0xf3, 0x20, 0x0a, 0x7a, 0x15, 0xbe, 0x28,
+	0x00, 0xe3, 0x2e, 0x74, 0x3e, 0xa1, 0xd1, 0xd2, 0x93, 0x8c, 0x7f, 0x6b, 0x50, 0xc5, 0xdd, 0xaf,
+	0x7f, 0x05, 0x59, 0x87, 0x7a, 0xe0, 0x7b, 0xae, 0x4f, 0x7b, 0xd5, 0x0d, 0x6d, 0xb3, 0x69, 0x4a,
+	0x88, 0xbc, 0x0b, 0xcd, 0x59, 0x48, 0x19, 0xf5, 0x6d, 0xda, 0xab, 0x6d, 0x68, 0x9b, 0x2b, 0x3b,
+	0x6b, 0x42, 0x3c, 0xb6, 0x7d, 0x24, 0xd7, 0x47, 0x91, 0x15, 0x51, 0x33, 0x41, 0x23, 0x77, 0x01,
+	0x58, 0x64, 0x45, 0x73, 0x76, 0x4c, 0xbf, 0x8c, 0x7a, 0x75, 0x7e, 0x8b, 0xb2, 0x42, 0x3e, 0x02,
+	0xf0, 0x2c, 0x16, 0x8d, 0x28, 0xf5, 0x07, 0x51, 0xaf, 0xb1, 0xa1, 0x6d, 0xb6, 0x77, 0xfa, 0xdb,
+	0x42, 0x99, 0xdb, 0xb1, 0x32, 0xb7, 0x8f, 0x63, 0x6d, 0x9b, 0x0a, 0xb6, 0xf1, 0x57, 0x0d, 0x3a,
+	0x87, 0x9c, 0xb3, 0x11, 0x3f, 0xb0, 0x20, 0x71, 0x2a, 0x47, 0x79, 0xa9, 0x1c, 0x95, 0xaf, 0x23,
+	0x47, 0xf5, 0x0a, 0x39, 0x6a, 0x37, 0x92, 0xc3, 0x84, 0xd6, 0x3e, 0xb5, 0xc2, 0xe8, 0x94, 0x5a,
+	0x11, 0x5a, 0x09, 0x7f, 0x25, 0xc7, 0xfc, 0xb9, 0xc0, 0x6f, 0xbb, 0xc8, 0x2f, 0xf7, 0x85, 0x94,
+	0x5f, 0xe3, 0xe7, 0xd0, 0xcd, 0x6c, 0x91, 0x37, 0xa1, 0x86, 0xec, 0xd2, 0x9e, 0x76, 0x99, 0xc0,
+	0x02, 0x27, 0x27, 0x6d, 0x39, 0x2f, 0xad, 0xf1, 0x16, 0xe8, 0x9f, 0xd0, 0x28, 0x7b, 0x41, 0x0f,
+	0x1a, 0x73, 0x46, 0xc3, 0x83, 0x21, 0xeb, 0x69, 0x1b, 0x95, 0xcd, 0x96, 0x19, 0x83, 0xc6, 0x23,
+	0x58, 0x51, 0xcd, 0x44, 0x19, 0x79, 0x07, 0x9a, 0x4c, 0x3e, 0x73, 0xe4, 0xf6, 0xce, 0xab, 0x31,
+	0x3f, 0x2a, 0xa6, 0x99, 0x60, 0x19, 0x03, 0xa8, 0xa1, 0x53, 0x33, 0x62, 0x40, 0x0d, 0xcf, 0x8d,
+	0xe9, 0x3a, 0x31, 0x1d, 0xee, 0x9a, 0x62, 0x0b, 0xbd, 0xda, 0x0e, 0xe6, 0xbe, 0xe0, 0xbc, 0x6a,
+	0x0a, 0xc0, 0x30, 0x61, 0xfd, 0x64, 0xe6, 0x58, 0x11, 0xe5, 0xb1, 0x13, 0x06, 0x63, 0xd7, 0xa3,
+	0x2f, 0x1b, 0x8c, 0x0f, 0x81, 0x88, 0x33, 0x33, 0xe7, 0x5d, 0x9f, 0x7e, 0x06, 0x0d, 0x49, 0xf9,
+	0x12, 0xe1, 0xfa, 0x26, 0x34, 0x18, 0x0d, 0xcf, 0x51, 0x29, 0x55, 0xae, 0x94, 0x5b, 0xb1, 0x52,
+	0x0e, 0x76, 0xf7, 0x46, 0x7c, 0xc7, 0x8c, 0x31, 0x8c, 0x3f, 0x94, 0xa1, 0x95, 0x2c, 0x13, 0x1d,
+	0x2a, 0xf3, 0xd0, 0x93, 0xb7, 0xe2, 0x23, 0xe9, 0x43, 0x13, 0x95, 0xa8, 0x5c, 0x9d, 0xc0, 0x64,
+	0x00, 0x2b, 0x76, 0x48, 0x1d, 0xea, 0x47, 0xae, 0xe5, 0x1d, 0x5f, 0xcc, 0xe2, 0xe8, 0xf9, 0x96,
+	0x72, 0xdf, 0x6e, 0x06, 0xc1, 0xcc, 0x11, 0xe0, 0xf1, 0x33, 0x8b, 0xb1, 0x17, 0x41, 0xe8, 0xc8,
+	0x28, 0x4a, 0x60, 0xb2, 0x01, 0x6d, 0xcb, 0xb6, 0x29, 0x63, 0xc7, 0xc1, 0x19, 0xf5, 0x79, 0x10,
+	0xb5, 0x4c, 0x75, 0x09, 0x03, 0x7a, 0x6a, 0xd9, 0x9f, 0xd1, 0x0b, 0x99, 0x49, 0x24, 0x44, 0xee,
+	0x43, 0x8b, 0x7e, 0x39, 0x73, 0x43, 0xca, 0xae, 0x95, 0x44, 0x52, 0x64, 0xd4, 0x68, 0x48, 0x2d,
+	0x6f, 0xda, 0x6b, 0x0a, 0x8d, 0x72, 0xc0, 0xf8, 0x10, 0x20, 0xd1, 0x11, 0x53, 0xf5, 0xab, 0x5d,
+	0xa9, 0x5f, 0x07, 0x5e, 0x45, 0xff, 0x1a, 0xa4, 0x5c, 0x2f, 0xf6, 0xb1, 0x1e, 0x34, 0xc2, 0x20,
+	0x98, 0x62, 0xb8, 0x94, 0x45, 0xb8, 0x48, 0x90, 0x18, 0xd0, 0xb1, 0xad, 0x99, 0x75, 0xea, 0x7a,
+	0x6e, 0xe4, 0x52, 0xd6, 0xab, 0xf0, 0xed, 0xcc, 0x9a, 0xf1, 0x4f, 0x0d, 0x56, 0x73, 0xd7, 0xa0,
+	0x28, 0x11, 0x57, 0x9c, 0xb8, 0x44, 0x00, 0x59, 0xd5, 0x94, 0x6f, 0xa2, 0x1a, 0x03, 0x3a, 0x21,
+	0x1d, 0x87, 0x94, 0x3d, 0x17, 0xf6, 0x10, 0x3e, 0x97, 0x59, 0x23, 0x47, 0xb0, 0xa6, 0xc2, 0x7b,
+	0xc9, 0x4d, 0xd5, 0x2b, 0x6f, 0x5a, 0x4c, 0x68, 0x7c, 0x00, 0xb7, 0x4c, 0x65, 0x43, 0x28, 0x2f,
+	0xcf, 0x8a, 0x56, 0x64, 0xc5, 0xf8, 0xb5, 0xc6, 0x5f, 0xb1, 0x66, 0x10, 0x4c, 0x5f, 0x32, 0xaa,
+	0xd1, 0x11, 0x1d, 0xca, 0xec, 0xd0, 0x9d, 0x45, 0x6e, 0xe0, 0x4b, 0x3f, 0x55, 0x97, 0xd4, 0x64,
+	0x57, 0xcb, 0x26, 0xbb, 0xaf, 0x34, 0xa8, 0x22, 0x0f, 0xdf, 0xe8, 0xf5, 0x49, 0x12, 0xac, 0x2d,
+	0x4d, 0x82, 0x46, 0x14, 0xa7, 0x3b, 0xae, 0x91, 0xff, 0x49, 0xba, 0xbb, 0x9a, 0x33, 0xcc, 0xd3,
+	0x78, 0x1f, 0xcf, 0xd3, 0xe8, 0xd0, 0x85, 0x3c, 0x8d, 0xbb, 0xa6, 0xd8, 0x5a, 0x92, 0xa7, 0x3f,
+	0x86, 0x2e, 0x97, 0x23, 0x31, 0xe4, 0x3a, 0xd4, 0x85, 0x76, 0x25, 0xcf, 0x12, 0xc2, 0x75, 0x11,
+	0x33, 0x92, 0x73, 0x09, 0xc9, 0x0a, 0x69, 0xa9, 0x23, 0x18, 0x9f, 0xc3, 0xea, 0x91, 0x35, 0x71,
+	0x7d, 0x0b, 0x39, 0x4e, 0xae, 0x08, 0xc6, 0x63, 0x46, 0x23, 0x8e, 0x56, 0x33, 0x25, 0x84, 0x1c,
+	0x7a, 0xee, 0xd4, 0x15, 0x1c, 0xd6, 0x4c, 0x01, 0xa0, 0xf5, 0xcf, 0xe8, 0x05, 0xcf, 0x61, 0x42,
+	0x3d, 0x31, 0x68, 0xfc, 0x45, 0x83, 0xe6, 0x68, 0x78, 0x24, 0x0e, 0xcd, 0x69, 0x4b, 0x2b, 0xda,
+	0x31, 0x95, 0xac, 0x9c, 0x91, 0xec, 0x0d, 0xa8, 0x46, 0x69, 0x7a, 0xd5, 0x63, 0xdd, 0x8d, 0x86,
+	0x47, 0x98, 0x44, 0x99, 0xc9, 0x77, 0x91, 0xda, 0xb6, 0x3c, 0xef, 0x60, 0x28, 0x0d, 0x21, 0x21,
+	0xcc, 0xb1, 0x0e, 0x3d, 0x77, 0x6d, 0x7a, 0x30, 0x94, 0x49, 0x34, 0x81, 0x79, 0x72, 0x09, 0x7c,
+	0x9f, 0xda, 0x78, 0xff, 0xc1, 0x50, 0xe6, 0xd1, 0xcc, 0x9a, 0xf1, 0xab, 0x32, 0x54, 0x46, 0xc3,
+	0xa3, 0x84, 0x0b, 0xed, 0x52, 0x2e, 0x72, 0x52, 0x96, 0x8b, 0x52, 0xf6, 0xa1, 0xc9, 0xa8, 0xef,
+	0x70, 0x39, 0x85, 0xbe, 0x12, 0x78, 0xa9, 0x0c, 0xeb, 0x50, 0x9f, 0x05, 0x9e, 0x1b, 0x89, 0x42,
+	0xb3, 0x69, 0x4a, 0x88, 0xdc, 0x81, 0x96, 0x1d, 0x78, 0x9e, 0xcb, 0xf0, 0xae, 0x3a, 0xdf, 0x4a,
+	0x17, 0xc8, 0x77, 0x60, 0x45, 0x9c, 0x3c, 0x8c, 0xe5, 0x6f, 0xf0, 0x53, 0x73, 0xab, 0x05, 0x2d,
+	0x34, 0x17, 0x68, 0x61, 0x13, 0x56, 0x46, 0x91, 0x15, 0x46, 0xbb, 0x96, 0xe7, 0x5d, 0xea, 0x87,
+	0xc6, 0x6b, 0xd0, 0x4a, 0x91, 0xf2, 0xce, 0xf6, 0x9f, 0x32, 0x54, 0x71, 0x37, 0xbf, 0x81, 0x5a,
+	0x41, 0x59, 0x15, 0xeb, 0x27, 0x70, 0xb2, 0x47, 0x53, 0x8d, 0xc5, 0x30, 0xd9, 0x8c, 0x0b, 0xb9,
+	0x2a, 0x37, 0x0b, 0x89, 0xcd, 0x82, 0x97, 0xf0, 0x22, 0x8e, 0xc5, 0x55, 0xdc, 0x7d, 0x68, 0xd9,
+	0x21, 0xb5, 0x22, 0xea, 0x5c, 0xab, 0x24, 0x4d, 0x91, 0xc9, 0x43, 0xe8, 0x84, 0xae, 0x3f, 0x71,
+	0xfd, 0xc9, 0x89, 0x1f, 0xb9, 0x5e, 0xaf, 0x7e, 0x25, 0x71, 0x06, 0x1f, 0xab, 0x61, 0xcb, 0x67,
+	0x2f, 0x68, 0xc8, 0xaf, 0xbe, 0x46, 0x55, 0x9f, 0x62, 0x93, 0xf7, 0xa0, 0x81, 0xc6, 0x42, 0xc2,
+	0xe6, 0x95, 0x84, 0x31, 0x2a, 0x86, 0x24, 0x7f, 0x7c, 0x74, 0xd1, 0x6b, 0x89, 0x90, 0x94, 0xa0,
+	0xf1, 0x85, 0xb0, 0xce, 0xde, 0x39, 0xf5, 0x23, 0xb2, 0x01, 0x55, 0x54, 0x24, 0x37, 0x83, 0x92,
+	0x94, 0x10, 0xc1, 0xe4, 0x3b, 0x64, 0x1b, 0xaa, 0xd8, 0xdb, 0x5d, 0xe3, 0x55, 0xc9, 0xf1, 0x8c,
+	0xef, 0x42, 0x17, 0x33, 0x4d, 0xc6, 0x4b, 0x64, 0x56, 0xd2, 0x32, 0x59, 0xe9, 0x97, 0x1a, 0x34,
+	0x63, 0xcc, 0x65, 0x48, 0xe8, 0xc0, 0x33, 0x2b, 0x8c, 0x5c, 0xdb, 0x9d, 0x59, 0x7e, 0x94, 0x16,
+	0x07, 0xb9, 0x55, 0x14, 0x37, 0x18, 0x8f, 0x69, 0x78, 0x1c, 0xc8, 0xf2, 0x20, 0x06, 0xd1, 0x75,
+	0x84, 0x32, 0x8f, 0x03, 0x5e, 0x0d, 0xb6, 0xcc, 0x04, 0x36, 0xfe, 0xac, 0xa5, 0xcc, 0x0a, 0x7d,
+	0xbc, 0x05, 0x35, 0x8a, 0x0f, 0x32, 0xc6, 0xd7, 0xd5, 0x2c, 0x9d, 0x60, 0x31, 0x53, 0x20, 0x2d,
+	0x4b, 0xb8, 0x4a, 0x60, 0x54, 0x32, 0x69, 0x6c, 0x03, 0xda, 0xec, 0x79, 0x30, 0xf7, 0x9c, 0x43,
+	0x64, 0x4e, 0x36, 0x93, 0xea, 0x52, 0xa2, 0xed, 0xda, 0x35, 0xb5, 0xfd, 0x7b, 0x0d, 0xda, 0x23,
+	0xde, 0xdf, 0x27, 0x95, 0x76, 0x92, 0xa2, 0x5a, 0x32, 0x21, 0xf5, 0xa0, 0x31, 0xb3, 0x2e, 0xbc,
+	0xc0, 0x72, 0x38, 0x9b, 0x1d, 0x33, 0x06, 0xc9, 0x9b, 0x50, 0x75, 0xac, 0xc8, 0x92, 0x3d, 0xd4,
+	0xed, 0xc2, 0x6d, 0x23, 0xde, 0xf2, 0x9b, 0x1c, 0x49, 0x11, 0xaa, 0xba, 0xe4, 0xad, 0x53, 0xcb,
+	0xd8, 0xf7, 0x6f, 0x1a, 0xd4, 0x05, 0x6b, 0xdf, 0x24, 0x57, 0x6a, 0x2e, 0xad, 0x16, 0x73, 0xe9,
+	0x22, 0xce, 0x12, 0x25, 0xd7, 0xaf, 0xa9, 0xe4, 0x7f, 0x69, 0xd0, 0xdd, 0xf5, 0x5c, 0xea, 0x47,
+	0x4f, 0x29, 0x63, 0xd6, 0xa4, 0xd8, 0x9b, 0xbc, 0x0b, 0xad, 0xe7, 0x71, 0xc7, 0x2a, 0x23, 0x25,
+	0xa9, 0x89, 0x93, 0x56, 0x76, 0xbf, 0x64, 0xa6, 0x58, 0xe4, 0x0d, 0xa8, 0x30, 0x67, 0x26, 0x85,
+	0x54, 0xdf, 0x25, 0xdc, 0x90, 0xfb, 0x25, 0x13, 0xb7, 0x11, 0xcb, 0xb5, 0x69, 0xaf, 0x9a, 0xc5,
+	0x3a, 0xd8, 0xdd, 0x4b, 0xb0, 0x5c, 0x9b, 0x92, 0xef, 0x41, 0x5d, 0x0c, 0x79, 0xa4, 0xdf, 0xbc,
+	0x92, 0x1c, 0x97, 0xba, 0xc6, 0x7e, 0xc9, 0x94, 0x48, 0x8f, 0x5a, 0x89, 0xea, 0x8d, 0xdf, 0x54,
+	0xa0, 0x2b, 0x2a, 0xf6, 0x58, 0xb4, 0xd7, 0xa1, 0x62, 0xd9, 0x67, 0x32, 0x21, 0xb4, 0xe3, 0x83,
+	0x06, 0xf6, 0x19, 0x5e, 0x66, 0xd9, 0x67, 0xe4, 0x75, 0xc1, 0x78, 0x39, 0x8b, 0x30, 0x1a, 0x1e,
+	0xe5, 0x78, 0xae, 0x14, 0x78, 0xe6, 0x2e, 0x1e, 0xf3, 0xfc, 0x2e, 0xb4, 0xd0, 0x1c, 0x3c, 0xa0,
+	0x7a, 0xd5, 0xac, 0xca, 0xcc, 0x78, 0x03, 0x55, 0x96, 0x60, 0x91, 0x8f, 0xa0, 0x13, 0x28, 0xdd,
+	0xb0, 0x14, 0x76, 0x61, 0xa7, 0xbc, 0x5f, 0x32, 0x33, 0xb8, 0x64, 0x33, 0x51, 0x91, 0xb0, 0xfa,
+	0x4a, 0x56, 0x45, 0xa9, 0x76, 0x90, 0x31, 0x3b, 0x8e, 0xf4, 0x5e, 0x23, 0xcb, 0x58, 0x92, 0x02,
+	0x90, 0xb1, 0x04, 0x8b, 0x3c, 0x80, 0x6e, 0xa8, 0x26, 0x08, 0x99, 0xa8, 0xd7, 0x16, 0x66, 0x8f,
+	0xfd, 0x92, 0x99, 0xc5, 0x56, 0xed, 0xf1, 0x3b, 0x0d, 0x2a, 0x03, 0xfb, 0x6c, 0x51, 0x49, 0x6a,
+	0x07, 0x0e, 0x95, 0x65, 0x17, 0x7f, 0xc6, 0x08, 0x9a, 0x0a, 0xa3, 0xc5, 0x55, 0x97, 0x04, 0x71,
+	0x5c, 0x11, 0xd2, 0x28, 0xbc, 0x18, 0x8c, 0x23, 0x99, 0x66, 0x2a, 0xa6, 0xb2, 0x42, 0x76, 0xb0,
+	0x20, 0xf2, 0xdc, 0x73, 0x1a, 0x5e, 0xf4, 0x6a, 0xd9, 0x44, 0x37, 0x94, 0xeb, 0xf1, 0xc0, 0x21,
+	0xc6, 0x33, 0x3e, 0x82, 0x66, 0xbc, 0x47, 0xb6, 0xa1, 0x2e, 0x06, 0x11, 0xf9, 0x34, 0x99, 0xa3,
+	0x96, 0x58, 0xc6, 0x3f, 0xca, 0xd0, 0x4a, 0x6c, 0x8a, 0x2f, 0x6c, 0x35, 0xc7, 0x92, 0x82, 0xd5,
+	0x93, 0xfc, 0x1a, 0x07, 0x6a, 0xe5, 0x7a, 0x81, 0x4a, 0x0e, 0x61, 0x35, 0x14, 0x55, 0x6e, 0xfc,
+	0x6e, 0x90, 0x9e, 0xf5, 0x6d, 0xf5, 0x0e, 0x65, 0x9b, 0x5f, 0x77, 0x24, 0x74, 0xbf, 0x5f, 0x32,
+	0xf3, 0xd4, 0xe4, 0x31, 0x74, 0x78, 0xce, 0xf0, 0x59, 0x64, 0xc5, 0x43, 0xbe, 0xf6, 0xce, 0x86,
+	0x7a, 0x5a, 0xbc, 0x97, 0x3b, 0x2a, 0x43, 0x87, 0xe7, 0xf0, 0x6c, 0x19, 0x9f, 0x53, 0xcf, 0x9e,
+	0x73, 0xa2, 0xec, 0xe5, 0xcf, 0x51, 0xe9, 0x54, 0x4f, 0xf9, 0x19, 0xbc, 0x76, 0x89, 0x30, 0xe4,
+	0x0d, 0xe8, 0x66, 0x5e, 0x91, 0xd2, 0x97, 0xb2, 0x8b, 0x4b, 0x3b, 0x86, 0x73, 0xe8, 0x2d, 0x93,
+	0xed, 0x1b, 0xed, 0x96, 0x8e, 0xa1, 0xb7, 0x4c, 0x17, 0x2f, 0x31, 0x94, 0xfa, 0xaa, 0x02, 0xcd,
+	0x38, 0x65, 0xf2, 0x82, 0xd9, 0xf2, 0x1d, 0xd7, 0x89, 0x67, 0x7f, 0x2d, 0x33, 0x5d, 0x58, 0xda,
+	0x80, 0xf4, 0xa1, 0xe9, 0x32, 0x93, 0x4e, 0x83, 0x48, 0x78, 0x63, 0xd3, 0x4c, 0x60, 0xa4, 0x61,
+	0xce, 0xec, 0xa9, 0x1b, 0x0f, 0x70, 0x24, 0x44, 0x1e, 0x41, 0x17, 0x9f, 0x9e, 0xb8, 0x3e, 0x3d,
+	0xf0, 0x1d, 0xfa, 0xa5, 0xf4, 0x9e, 0x3b, 0x05, 0x37, 0x3e, 0x39, 0xf0, 0xa3, 0x7b, 0x3b, 0x3f,
+	0xb1, 0xbc, 0x39, 0x35, 0xb3, 0x24, 0x64, 0x0b, 0xf4, 0x78, 0xda, 0xf4, 0x38, 0xb4, 0x26, 0x53,
+	0x0c, 0x1b, 0xd1, 0xa2, 0x14, 0xd6, 0xc9, 0x26, 0xac, 0x52, 0xdf, 0x39, 0x1c, 0xef, 0xc6, 0xd2,
+	0x30, 0x9e, 0xbe, 0x9a, 0x66, 0x7e, 0x19, 0xf3, 0x83, 0x6b, 0x53, 0x13, 0x63, 0x27, 0x14, 0xc9,
+	0xaa, 0x69, 0x2a, 0x2b, 0x99, 0x86, 0xa9, 0x75, 0x45, 0xc3, 0x04, 0xc5, 0x56, 0x41, 0x69, 0x62,
+	0xda, 0x6a, 0x13, 0x63, 0xfc, 0x56, 0x18, 0x42, 0x94, 0x3a, 0x97, 0x1b, 0x42, 0x7d, 0xaf, 0x97,
+	0x73, 0xef, 0xf5, 0xff, 0x2f, 0x63, 0x14, 0x7b, 0xb8, 0xd6, 0xb5, 0x7a, 0xb8, 0x1b, 0x18, 0x66,
+	0xeb, 0x8b, 0x74, 0x3a, 0xce, 0x5b, 0x26, 0xd2, 0x86, 0xc6, 0xe1, 0x78, 0x8c, 0xef, 0x4f, 0xbd,
+	0x44, 0x00, 0xea, 0xe2, 0xdd, 0xaa, 0x6b, 0xa4, 0x09, 0xd5, 0xc1, 0x0b, 0xeb, 0x42, 0x2f, 0xe3,
+	0xd3, 0xa3, 0x39, 0xbb, 0xd0, 0x2b, 0x44, 0x87, 0xce, 0x30, 0x78, 0x16, 0x44, 0x43, 0x97, 0x45,
+	0xf3, 0xf0, 0x54, 0xaf, 0x92, 0x2e, 0xb4, 0x0e, 0xfc, 0x73, 0x97, 0xb9, 0xa7, 0x1e, 0xd5, 0x6b,
+	0x5b, 0xef, 0xc3, 0xad, 0xc2, 0x24, 0x14, 0xe9, 0x9f, 0x1d, 0x3e, 0xdb, 0xd3, 0x4b, 0xa4, 0x03,
+	0xcd, 0xa3, 0xc1, 0x68, 0xf4, 0xd3, 0x43, 0x73, 0xa8, 0x6b, 0xa4, 0x05, 0xb5, 0xc3, 0xc1, 0xc9,
+	0xf1, 0xbe, 0x5e, 0xde, 0xfa, 0x1c, 0x20, 0xed, 0xe2, 0x90, 0x27, 0x53, 0x34, 0x55, 0x82, 0xa7,
+	0x81, 0x1d, 0xb9, 0xe7, 0xc8, 0x53, 0x07, 0x9a, 0x26, 0xfd, 0x05, 0xb5, 0x23, 0xea, 0xe8, 0x65,
+	0xbc, 0x7b, 0x17, 0x93, 0x87, 0xe7, 0x51, 0x47, 0xaf, 0x20, 0xe2, 0x53, 0x97, 0x31, 0xea, 0xe8,
+	0x55, 0x3c, 0x7a, 0x0f, 0xbb, 0x20, 0xbd, 0xb6, 0xf5, 0x43, 0x58, 0xc9, 0xd6, 0xf4, 0x64, 0x0d,
+	0x6e, 0x29, 0xc9, 0xf4, 0xd3, 0xc0, 0xf5, 0xa9, 0xa3, 0x97, 0xc8, 0x2b, 0xb0, 0xaa, 0x2c, 0x3f,
+	0xa1, 0xe3, 0x48, 0xd7, 0xb6, 0x6c, 0x58, 0xc9, 0xbe, 0xea, 0xc8, 0x2a, 0xb4, 0x4f, 0x7c, 0x36,
+	0xa3, 0xb6, 0x3b, 0x76, 0x39, 0x5d, 0x13, 0xaa, 0x4f, 0x04, 0x7b, 0x00, 0xf5, 0x1f, 0xcf, 0xe9,
+	0x9c, 0x33, 0xd7, 0x86, 0x86, 0x18, 0xed, 0x21, 0x6b, 0x1d, 0x68, 0xee, 0x62, 0xab, 0xee, 0x70,
+	0xe6, 0xf8, 0x09, 0x76, 0xe0, 0x8f, 0xdd, 0x70, 0xca, 0x59, 0x3c, 0xe6, 0xa3, 0x13, 0x3e, 0x5a,
+	0xe0, 0x4a, 0xc1, 0xa8, 0x91, 0x92, 0xf3, 0x06, 0x46, 0x48, 0x7e, 0x14, 0x8a, 0x76, 0x46, 0x2f,
+	0x23, 0x64, 0x06, 0x9e, 0x77, 0x6a, 0xd9, 0x67, 0x7a, 0x05, 0xe5, 0x19, 0xc8, 0x2e, 0x72, 0xcf,
+	0x63, 0xf4, 0xc5, 0x73, 0x1a, 0x52, 0xbd, 0xba, 0xf5, 0x47, 0x0d, 0x20, 0x7d, 0xd3, 0xa2, 0xed,
+	0x30, 0xe3, 0xa2, 0x5c, 0xb8, 0xaa, 0x97, 0x08, 0x81, 0x15, 0x5c, 0x11, 0x0a, 0xe0, 0x6b, 0x1a,
+	0xf2, 0xc6, 0xb5, 0x25, 0x1a, 0x62, 0xbd, 0x4c, 0xd6, 0x81, 0x28, 0x63, 0x34, 0x31, 0x57, 0x43,
+	0x91, 0x6e, 0x89, 0x86, 0x6a, 0x48, 0x59, 0x14, 0x06, 0x17, 0x5c, 0x2e, 0x79, 0x9e, 0x49, 0x27,
+	0x2e, 0x8b, 0x90, 0x1b, 0xbd, 0x86, 0xe4, 0xca, 0x47, 0x87, 0x98, 0xbc, 0xce, 0x75, 0xc0, 0x71,
+	0xa7, 0xc1, 0x39, 0x75, 0xf4, 0xc6, 0xce, 0x9f, 0xea, 0xb0, 0x86, 0x07, 0x3e, 0xb5, 0x7c, 0x6b,
+	0x42, 0x31, 0xb2, 0xb0, 0x5a, 0xc5, 0xfa, 0xf1, 0x3d, 0xe8, 0xc4, 0x47, 0x22, 0x09, 0x49, 0xca,
+	0x40, 0xf5, 0xb3, 0x62, 0x3f, 0x33, 0x09, 0x34, 0x4a, 0xe4, 0x6d, 0x68, 0xc8, 0x8f, 0x85, 0x29,
+	0x81, 0xfa, 0xf5, 0xb0, 0x40, 0xf0, 0x1e, 0x34, 0xe5, 0x3e, 0x23, 0xb7, 0xe3, 0xbd, 0xdc, 0xb4,
+	0xac, 0xdf, 0x55, 0x89, 0x98, 0x51, 0x22, 0x4f, 0x81, 0x48, 0x2a, 0x75, 0x20, 0x7d, 0x47, 0x45,
+	0xcb, 0x0f, 0xc4, 0xfb, 0xb7, 0x97, 0xec, 0x1a, 0x25, 0xb2, 0x0b, 0xb7, 0x0a, 0x5f, 0x6a, 0xc8,
+	0xdd, 0x04, 0x7f, 0xe1, 0x47, 0x9c, 0x82, 0x24, 0x3b, 0x00, 0x42, 0xaf, 0x37, 0x90, 0xfe, 0x3e,
+	0xe8, 0x26, 0x3d, 0x0f, 0xce, 0x38, 0x0d, 0xe7, 0x86, 0x5d, 0x93, 0x72, 0x07, 0x40, 0x78, 0x0b,
+	0x9f, 0xfd, 0xaa, 0xc6, 0x49, 0xe6, 0x90, 0xfd, 0xcc, 0x0c, 0x34, 0x31, 0x4e, 0x96, 0x40, 0x1d,
+	0x5c, 0x16, 0x08, 0x84, 0x71, 0xc4, 0x7c, 0xf5, 0x6a, 0xe3, 0x70, 0x3c, 0x55, 0x9b, 0x8a, 0x07,
+	0xe7, 0xb5, 0x99, 0x9f, 0x11, 0x17, 0xae, 0x7e, 0x1f, 0xba, 0x03, 0xc7, 0x11, 0x6a, 0xe1, 0x1c,
+	0xaf, 0x65, 0x66, 0xce, 0x4b, 0x59, 0xfe, 0x10, 0xf4, 0xcf, 0x5c, 0xfb, 0x0c, 0x91, 0x1e, 0x87,
+	0xc1, 0xf4, 0x26, 0xa4, 0xf7, 0xa0, 0x2d, 0xe3, 0xea, 0xfa, 0x2a, 0xda, 0xf9, 0xfb, 0x0a, 0xe8,
+	0xa2, 0xc5, 0x71, 0xfd, 0x49, 0x1c, 0x3b, 0x1f, 0x00, 0xf0, 0xcf, 0x95, 0x42, 0xf4, 0xf5, 0xc2,
+	0x3b, 0x70, 0x0f, 0xff, 0x07, 0xd0, 0x5f, 0x4d, 0x34, 0x2a, 0x10, 0x8d, 0x12, 0x79, 0x08, 0xdd,
+	0xcc, 0xe7, 0x3d, 0xd2, 0xcf, 0xaa, 0x2d, 0xa3, 0xb2, 0x05, 0xf4, 0xdf, 0xe7, 0x17, 0x3f, 0xbd,
+	0x10, 0x26, 0x5b, 0x76, 0x71, 0xc1, 0x62, 0x37, 0x76, 0x8c, 0x1b, 0x87, 0xf9, 0xc7, 0x70, 0x9b,
+	0xe7, 0xd7, 0x11, 0x65, 0x38, 0x38, 0x1d, 0x2a, 0xd3, 0xda, 0x42, 0x6f, 0xde, 0xd7, 0xf3, 0xed,
+	0x8e, 0x51, 0x22, 0x3f, 0x82, 0x9e, 0xc8, 0xb6, 0x5f, 0xfb, 0x84, 0x87, 0xb0, 0x3e, 0xa2, 0xbe,
+	0xf3, 0x12, 0xf4, 0xaf, 0x8c, 0xe6, 0xa7, 0x48, 0x75, 0x4a, 0x47, 0xc3, 0xa3, 0xdd, 0x60, 0x3a,
+	0xb5, 0x7c, 0x67, 0xa9, 0x92, 0xd5, 0xce, 0xdd, 0x28, 0xbd, 0xa3, 0x91, 0x5d, 0x20, 0x09, 0x7d,
+	0xda, 0xac, 0x2d, 0x23, 0x2f, 0xf6, 0xea, 0xfc, 0x90, 0xfb, 0xa0, 0xa3, 0x10, 0xf8, 0xb6, 0x4f,
+	0x4a, 0xb9, 0xc2, 0xd8, 0x62, 0x21, 0xfb, 0x7b, 0xb0, 0x96, 0x5c, 0x9f, 0x21, 0x5f, 0xc6, 0x41,
+	0x61, 0xb2, 0xc0, 0x19, 0x78, 0xac, 0x1c, 0x93, 0xf9, 0x2f, 0x44, 0x71, 0x1e, 0xd3, 0x5f, 0x38,
+	0x39, 0x30, 0x4a, 0x9b, 0xda, 0x3b, 0x1a, 0x79, 0x08, 0xed, 0x51, 0xfa, 0x45, 0x9f, 0x2c, 0xfe,
+	0x7f, 0x41, 0x7f, 0x09, 0x6f, 0x46, 0x89, 0x0c, 0xa0, 0xad, 0xfc, 0x23, 0x80, 0xf4, 0x14, 0x2f,
+	0xcc, 0x1f, 0xb1, 0x80, 0x09, 0xca, 0xf8, 0x11, 0xab, 0xb1, 0x93, 0xc7, 0xc7, 0x2c, 0xf6, 0xfe,
+	0xe5, 0x47, 0xfc, 0x00, 0x80, 0xfb, 0x94, 0x98, 0x6c, 0x2c, 0x1a, 0x0b, 0x5d, 0x22, 0xc2, 0x03,
+	0x58, 0x4d, 0x1d, 0x4a, 0x9c, 0xb0, 0xcc, 0x16, 0xb9, 0x69, 0x0a, 0xb7, 0xc4, 0x03, 0x68, 0xec,
+	0x8a, 0xda, 0x33, 0xd5, 0x5e, 0x66, 0x8a, 0xd6, 0x4f, 0x96, 0x33, 0x13, 0x28, 0x69, 0x80, 0x7b,
+	0xd0, 0x4a, 0x3e, 0x37, 0x90, 0x44, 0xc2, 0xec, 0x17, 0x88, 0x7e, 0x66, 0x60, 0xcd, 0xe3, 0x1e,
+	0xf0, 0xcd, 0x39, 0x13, 0x54, 0x99, 0xb1, 0xcd, 0x52, 0x02, 0x51, 0x3a, 0xde, 0x80, 0x40, 0x54,
+	0x97, 0x37, 0x20, 0xd8, 0xb7, 0xfc, 0xc9, 0x7c, 0x76, 0x5d, 0x02, 0x35, 0x0e, 0xd3, 0xc1, 0xf4,
+	0x95, 0x71, 0x98, 0xa0, 0x72, 0xe5, 0x7f, 0x08, 0x1d, 0x2c, 0xe0, 0x92, 0xf9, 0x7a, 0x61, 0x14,
+	0x95, 0x0b, 0xc4, 0x78, 0x99, 0x67, 0xb2, 0xee, 0x13, 0x6a, 0x9d, 0xd3, 0xab, 0x68, 0x97, 0x3b,
+	0xce, 0x67, 0xb0, 0x9e, 0xc9, 0x24, 0x57, 0x4b, 0xb1, 0x78, 0x52, 0xc6, 0x25, 0xf9, 0x14, 0x88,
+	0xfc, 0xfe, 0xad, 0x96, 0x52, 0xc9, 0x3f, 0x2c, 0x0a, 0xdf, 0xc6, 0x2f, 0xab, 0xa3, 0x1e, 0x40,
+	0xf7, 0x13, 0x1a, 0x29, 0xff, 0x64, 0x58, 0xc6, 0x0f, 0x29, 0xfc, 0xa1, 0x81, 0x19, 0xa5, 0x53,
+	0xf1, 0xbf, 0xb9, 0x7b, 0xff, 0x1d, 0x00, 0x2d, 0x6c, 0x9c, 0x45, 0x52, 0x27, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RoomManagementServiceClient is the client API for RoomManagementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RoomManagementServiceClient interface {
+	RegisterUser(ctx context.Context, in *NewUserParam, opts ...grpc.CallOption) (*User, error)
+	GetUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error)
+	GetUsers(ctx context.Context, in *PaginationParam, opts ...grpc.CallOption) (*Users, error)
+	GetUserAccessToken(ctx context.Context, in *UserAccessTokenParam, opts ...grpc.CallOption) (*UserAccessToken, error)
+	UpdateUserProfile(ctx context.Context, in *UpdateUserProfileParam, opts ...grpc.CallOption) (*User, error)
+	RemoveUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error)
+	RevokeUserTokens(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error)
+	CreateRoom(ctx context.Context, in *NewRoomParam, opts ...grpc.CallOption) (*Room, error)
+	GetRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error)
+	GetRooms(ctx context.Context, in *PaginationParam, opts ...grpc.CallOption) (*Rooms, error)
+	UpdateRoomProfile(ctx context.Context, in *UpdateRoomProfileParam, opts ...grpc.CallOption) (*Room, error)
+	AddUserToRoom(ctx context.Context, in *UserRoomParam, opts ...grpc.CallOption) (*Room, error)
+	KickUserFromRoom(ctx context.Context, in *UserRoomParam, opts ...grpc.CallOption) (*Room, error)
+	DestroyRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error)
+}
+
+type roomManagementServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRoomManagementServiceClient(cc *grpc.ClientConn) RoomManagementServiceClient {
+	return &roomManagementServiceClient{cc}
+}
+
+func (c *roomManagementServiceClient) RegisterUser(ctx context.Context, in *NewUserParam, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/RegisterUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) GetUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/GetUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) GetUsers(ctx context.Context, in *PaginationParam, opts ...grpc.CallOption) (*Users, error) {
+	out := new(Users)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/GetUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) GetUserAccessToken(ctx context.Context, in *UserAccessTokenParam, opts ...grpc.CallOption) (*UserAccessToken, error) {
+	out := new(UserAccessToken)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/GetUserAccessToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) UpdateUserProfile(ctx context.Context, in *UpdateUserProfileParam, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/UpdateUserProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) RemoveUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/RemoveUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) RevokeUserTokens(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/RevokeUserTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) CreateRoom(ctx context.Context, in *NewRoomParam, opts ...grpc.CallOption) (*Room, error) {
+	out := new(Room)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/CreateRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) GetRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error) {
+	out := new(Room)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/GetRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) GetRooms(ctx context.Context, in *PaginationParam, opts ...grpc.CallOption) (*Rooms, error) {
+	out := new(Rooms)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/GetRooms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) UpdateRoomProfile(ctx context.Context, in *UpdateRoomProfileParam, opts ...grpc.CallOption) (*Room, error) {
+	out := new(Room)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/UpdateRoomProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) AddUserToRoom(ctx context.Context, in *UserRoomParam, opts ...grpc.CallOption) (*Room, error) {
+	out := new(Room)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/AddUserToRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) KickUserFromRoom(ctx context.Context, in *UserRoomParam, opts ...grpc.CallOption) (*Room, error) {
+	out := new(Room)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/KickUserFromRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomManagementServiceClient) DestroyRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error) {
+	out := new(Room)
+	err := c.cc.Invoke(ctx, "/protos.RoomManagementService/DestroyRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RoomManagementServiceServer is the server API for RoomManagementService service.
+type RoomManagementServiceServer interface {
+	RegisterUser(context.Context, *NewUserParam) (*User, error)
+	GetUser(context.Context, *GetUserParam) (*User, error)
+	GetUsers(context.Context, *PaginationParam) (*Users, error)
+	GetUserAccessToken(context.Context, *UserAccessTokenParam) (*UserAccessToken, error)
+	UpdateUserProfile(context.Context, *UpdateUserProfileParam) (*User, error)
+	RemoveUser(context.Context, *GetUserParam) (*User, error)
+	RevokeUserTokens(context.Context, *GetUserParam) (*User, error)
+	CreateRoom(context.Context, *NewRoomParam) (*Room, error)
+	GetRoom(context.Context, *GetRoomParam) (*Room, error)
+	GetRooms(context.Context, *PaginationParam) (*Rooms, error)
+	UpdateRoomProfile(context.Context, *UpdateRoomProfileParam) (*Room, error)
+	AddUserToRoom(context.Context, *UserRoomParam) (*Room, error)
+	KickUserFromRoom(context.Context, *UserRoomParam) (*Room, error)
+	DestroyRoom(context.Context, *GetRoomParam) (*Room, error)
+}
+
+// UnimplementedRoomManagementServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedRoomManagementServiceServer struct {
+}
+
+func (*UnimplementedRoomManagementServiceServer) RegisterUser(ctx context.Context, req *NewUserParam) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) GetUser(ctx context.Context, req *GetUserParam) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) GetUsers(ctx context.Context, req *PaginationParam) (*Users, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsers not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) GetUserAccessToken(ctx context.Context, req *UserAccessTokenParam) (*UserAccessToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserAccessToken not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) UpdateUserProfile(ctx context.Context, req *UpdateUserProfileParam) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserProfile not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) RemoveUser(ctx context.Context, req *GetUserParam) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUser not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) RevokeUserTokens(ctx context.Context, req *GetUserParam) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeUserTokens not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) CreateRoom(ctx context.Context, req *NewRoomParam) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) GetRoom(ctx context.Context, req *GetRoomParam) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoom not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) GetRooms(ctx context.Context, req *PaginationParam) (*Rooms, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRooms not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) UpdateRoomProfile(ctx context.Context, req *UpdateRoomProfileParam) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoomProfile not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) AddUserToRoom(ctx context.Context, req *UserRoomParam) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserToRoom not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) KickUserFromRoom(ctx context.Context, req *UserRoomParam) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KickUserFromRoom not implemented")
+}
+func (*UnimplementedRoomManagementServiceServer) DestroyRoom(ctx context.Context, req *GetRoomParam) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroyRoom not implemented")
+}
+
+func RegisterRoomManagementServiceServer(s *grpc.Server, srv RoomManagementServiceServer) {
+	s.RegisterService(&_RoomManagementService_serviceDesc, srv)
+}
+
+func _RoomManagementService_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewUserParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).RegisterUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/RegisterUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).RegisterUser(ctx, req.(*NewUserParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 func _RoomManagementService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -1949,794 +3780,1500 @@ func _RoomManagementService_GetUser_Handler(srv interface{}, ctx context.Context
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).GetUser(ctx, in)
+		return srv.(RoomManagementServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).GetUser(ctx, req.(*GetUserParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_GetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaginationParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).GetUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/GetUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).GetUsers(ctx, req.(*PaginationParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_GetUserAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserAccessTokenParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).GetUserAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/GetUserAccessToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).GetUserAccessToken(ctx, req.(*UserAccessTokenParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_UpdateUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserProfileParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).UpdateUserProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/UpdateUserProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).UpdateUserProfile(ctx, req.(*UpdateUserProfileParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).RemoveUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/RemoveUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).RemoveUser(ctx, req.(*GetUserParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_RevokeUserTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).RevokeUserTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/RevokeUserTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).RevokeUserTokens(ctx, req.(*GetUserParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewRoomParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).CreateRoom(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/GetUser",
+		FullMethod: "/protos.RoomManagementService/CreateRoom",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).GetUser(ctx, req.(*GetUserParam))
+		return srv.(RoomManagementServiceServer).CreateRoom(ctx, req.(*NewRoomParam))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoomManagementService_GetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _RoomManagementService_GetRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoomParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).GetRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/GetRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).GetRoom(ctx, req.(*GetRoomParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_GetRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PaginationParam)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).GetUsers(ctx, in)
+		return srv.(RoomManagementServiceServer).GetRooms(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/GetUsers",
+		FullMethod: "/protos.RoomManagementService/GetRooms",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).GetUsers(ctx, req.(*PaginationParam))
+		return srv.(RoomManagementServiceServer).GetRooms(ctx, req.(*PaginationParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_UpdateRoomProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoomProfileParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).UpdateRoomProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/UpdateRoomProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).UpdateRoomProfile(ctx, req.(*UpdateRoomProfileParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_AddUserToRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRoomParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).AddUserToRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/AddUserToRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).AddUserToRoom(ctx, req.(*UserRoomParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_KickUserFromRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRoomParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).KickUserFromRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/KickUserFromRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).KickUserFromRoom(ctx, req.(*UserRoomParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomManagementService_DestroyRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoomParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomManagementServiceServer).DestroyRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.RoomManagementService/DestroyRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomManagementServiceServer).DestroyRoom(ctx, req.(*GetRoomParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RoomManagementService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.RoomManagementService",
+	HandlerType: (*RoomManagementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterUser",
+			Handler:    _RoomManagementService_RegisterUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _RoomManagementService_GetUser_Handler,
+		},
+		{
+			MethodName: "GetUsers",
+			Handler:    _RoomManagementService_GetUsers_Handler,
+		},
+		{
+			MethodName: "GetUserAccessToken",
+			Handler:    _RoomManagementService_GetUserAccessToken_Handler,
+		},
+		{
+			MethodName: "UpdateUserProfile",
+			Handler:    _RoomManagementService_UpdateUserProfile_Handler,
+		},
+		{
+			MethodName: "RemoveUser",
+			Handler:    _RoomManagementService_RemoveUser_Handler,
+		},
+		{
+			MethodName: "RevokeUserTokens",
+			Handler:    _RoomManagementService_RevokeUserTokens_Handler,
+		},
+		{
+			MethodName: "CreateRoom",
+			Handler:    _RoomManagementService_CreateRoom_Handler,
+		},
+		{
+			MethodName: "GetRoom",
+			Handler:    _RoomManagementService_GetRoom_Handler,
+		},
+		{
+			MethodName: "GetRooms",
+			Handler:    _RoomManagementService_GetRooms_Handler,
+		},
+		{
+			MethodName: "UpdateRoomProfile",
+			Handler:    _RoomManagementService_UpdateRoomProfile_Handler,
+		},
+		{
+			MethodName: "AddUserToRoom",
+			Handler:    _RoomManagementService_AddUserToRoom_Handler,
+		},
+		{
+			MethodName: "KickUserFromRoom",
+			Handler:    _RoomManagementService_KickUserFromRoom_Handler,
+		},
+		{
+			MethodName: "DestroyRoom",
+			Handler:    _RoomManagementService_DestroyRoom_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "signalling.proto",
+}
+
+// SignalingServiceClient is the client API for SignalingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SignalingServiceClient interface {
+	GetProfile(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Profile, error)
+	UpdateProfile(ctx context.Context, in *UpdateProfileParam, opts ...grpc.CallOption) (*Profile, error)
+	GetMyRooms(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Rooms, error)
+	GetRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error)
+	GetUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error)
+	OfferSessionDescription(ctx context.Context, in *SDPParam, opts ...grpc.CallOption) (*Delivery, error)
+	AnswerSessionDescription(ctx context.Context, in *SDPParam, opts ...grpc.CallOption) (*Delivery, error)
+	SendSessionDescription(ctx context.Context, in *SDPParam, opts ...grpc.CallOption) (*Delivery, error)
+	SubscribeSDPCommand(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeSDPCommandClient, error)
+	SubscribeRoomEvent(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeRoomEventClient, error)
+	SendICECandidate(ctx context.Context, in *ICEParam, opts ...grpc.CallOption) (*Delivery, error)
+	SubscribeICECandidate(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeICECandidateClient, error)
+	SubscribeOnlineStatus(ctx context.Context, opts ...grpc.CallOption) (SignalingService_SubscribeOnlineStatusClient, error)
+	SetPresence(ctx context.Context, in *PresenceParam, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetPresence(ctx context.Context, in *GetPresenceParam, opts ...grpc.CallOption) (*OnlineStatuses, error)
+	GetRoomPresence(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*OnlineStatuses, error)
+	SendSignal(ctx context.Context, in *SignalParam, opts ...grpc.CallOption) (*empty.Empty, error)
+	SubscribeSignal(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeSignalClient, error)
+	Connect(ctx context.Context, opts ...grpc.CallOption) (SignalingService_ConnectClient, error)
+	StartCall(ctx context.Context, in *StartCallParam, opts ...grpc.CallOption) (*Call, error)
+	AcceptCall(ctx context.Context, in *CallParam, opts ...grpc.CallOption) (*Call, error)
+	RejectCall(ctx context.Context, in *CallParam, opts ...grpc.CallOption) (*Call, error)
+	CancelCall(ctx context.Context, in *CallParam, opts ...grpc.CallOption) (*Call, error)
+	HangupCall(ctx context.Context, in *CallParam, opts ...grpc.CallOption) (*Call, error)
+	SubscribeCallEvent(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeCallEventClient, error)
+	JoinRoomCall(ctx context.Context, in *RoomCallParam, opts ...grpc.CallOption) (*RoomCall, error)
+	LeaveRoomCall(ctx context.Context, in *RoomCallParam, opts ...grpc.CallOption) (*empty.Empty, error)
+	SubscribeRoomCallEvent(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeRoomCallEventClient, error)
+	RefreshAccessToken(ctx context.Context, in *RefreshTokenParam, opts ...grpc.CallOption) (*UserAccessToken, error)
+	GetICEServers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ICEServers, error)
+}
+
+type signalingServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSignalingServiceClient(cc *grpc.ClientConn) SignalingServiceClient {
+	return &signalingServiceClient{cc}
+}
+
+func (c *signalingServiceClient) GetProfile(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Profile, error) {
+	out := new(Profile)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signalingServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileParam, opts ...grpc.CallOption) (*Profile, error) {
+	out := new(Profile)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/UpdateProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signalingServiceClient) GetMyRooms(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Rooms, error) {
+	out := new(Rooms)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetMyRooms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signalingServiceClient) GetRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error) {
+	out := new(Room)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signalingServiceClient) GetUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signalingServiceClient) OfferSessionDescription(ctx context.Context, in *SDPParam, opts ...grpc.CallOption) (*Delivery, error) {
+	out := new(Delivery)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/OfferSessionDescription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signalingServiceClient) AnswerSessionDescription(ctx context.Context, in *SDPParam, opts ...grpc.CallOption) (*Delivery, error) {
+	out := new(Delivery)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/AnswerSessionDescription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signalingServiceClient) SendSessionDescription(ctx context.Context, in *SDPParam, opts ...grpc.CallOption) (*Delivery, error) {
+	out := new(Delivery)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/SendSessionDescription", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _RoomManagementService_GetUserAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserParam)
-	if err := dec(in); err != nil {
+func (c *signalingServiceClient) SubscribeSDPCommand(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeSDPCommandClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[0], "/protos.SignalingService/SubscribeSDPCommand", opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).GetUserAccessToken(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/GetUserAccessToken",
+	x := &signalingServiceSubscribeSDPCommandClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).GetUserAccessToken(ctx, req.(*GetUserParam))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return x, nil
 }
 
-func _RoomManagementService_UpdateUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserProfileParam)
-	if err := dec(in); err != nil {
+type SignalingService_SubscribeSDPCommandClient interface {
+	Recv() (*SDP, error)
+	grpc.ClientStream
+}
+
+type signalingServiceSubscribeSDPCommandClient struct {
+	grpc.ClientStream
+}
+
+func (x *signalingServiceSubscribeSDPCommandClient) Recv() (*SDP, error) {
+	m := new(SDP)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).UpdateUserProfile(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/UpdateUserProfile",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).UpdateUserProfile(ctx, req.(*UpdateUserProfileParam))
-	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-func _RoomManagementService_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserParam)
-	if err := dec(in); err != nil {
+func (c *signalingServiceClient) SubscribeRoomEvent(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeRoomEventClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[1], "/protos.SignalingService/SubscribeRoomEvent", opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).RemoveUser(ctx, in)
+	x := &signalingServiceSubscribeRoomEventClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/RemoveUser",
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).RemoveUser(ctx, req.(*GetUserParam))
+	return x, nil
+}
+
+type SignalingService_SubscribeRoomEventClient interface {
+	Recv() (*RoomEvent, error)
+	grpc.ClientStream
+}
+
+type signalingServiceSubscribeRoomEventClient struct {
+	grpc.ClientStream
+}
+
+func (x *signalingServiceSubscribeRoomEventClient) Recv() (*RoomEvent, error) {
+	m := new(RoomEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-func _RoomManagementService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewRoomParam)
-	if err := dec(in); err != nil {
+func (c *signalingServiceClient) SendICECandidate(ctx context.Context, in *ICEParam, opts ...grpc.CallOption) (*Delivery, error) {
+	out := new(Delivery)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/SendICECandidate", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).CreateRoom(ctx, in)
+	return out, nil
+}
+
+func (c *signalingServiceClient) SubscribeICECandidate(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeICECandidateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[2], "/protos.SignalingService/SubscribeICECandidate", opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/CreateRoom",
+	x := &signalingServiceSubscribeICECandidateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).CreateRoom(ctx, req.(*NewRoomParam))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return x, nil
 }
 
-func _RoomManagementService_GetRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRoomParam)
-	if err := dec(in); err != nil {
+type SignalingService_SubscribeICECandidateClient interface {
+	Recv() (*ICEOffer, error)
+	grpc.ClientStream
+}
+
+type signalingServiceSubscribeICECandidateClient struct {
+	grpc.ClientStream
+}
+
+func (x *signalingServiceSubscribeICECandidateClient) Recv() (*ICEOffer, error) {
+	m := new(ICEOffer)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).GetRoom(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/GetRoom",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).GetRoom(ctx, req.(*GetRoomParam))
+	return m, nil
+}
+
+func (c *signalingServiceClient) SubscribeOnlineStatus(ctx context.Context, opts ...grpc.CallOption) (SignalingService_SubscribeOnlineStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[3], "/protos.SignalingService/SubscribeOnlineStatus", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	x := &signalingServiceSubscribeOnlineStatusClient{stream}
+	return x, nil
 }
 
-func _RoomManagementService_GetRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PaginationParam)
-	if err := dec(in); err != nil {
+type SignalingService_SubscribeOnlineStatusClient interface {
+	Send(*Heartbeat) error
+	Recv() (*OnlineStatus, error)
+	grpc.ClientStream
+}
+
+type signalingServiceSubscribeOnlineStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *signalingServiceSubscribeOnlineStatusClient) Send(m *Heartbeat) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *signalingServiceSubscribeOnlineStatusClient) Recv() (*OnlineStatus, error) {
+	m := new(OnlineStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).GetRooms(ctx, in)
+	return m, nil
+}
+
+func (c *signalingServiceClient) SetPresence(ctx context.Context, in *PresenceParam, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/SetPresence", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/GetRooms",
+	return out, nil
+}
+
+func (c *signalingServiceClient) GetPresence(ctx context.Context, in *GetPresenceParam, opts ...grpc.CallOption) (*OnlineStatuses, error) {
+	out := new(OnlineStatuses)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetPresence", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).GetRooms(ctx, req.(*PaginationParam))
+	return out, nil
+}
+
+func (c *signalingServiceClient) GetRoomPresence(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*OnlineStatuses, error) {
+	out := new(OnlineStatuses)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetRoomPresence", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _RoomManagementService_UpdateRoomProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRoomProfileParam)
-	if err := dec(in); err != nil {
+func (c *signalingServiceClient) SendSignal(ctx context.Context, in *SignalParam, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/SendSignal", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).UpdateRoomProfile(ctx, in)
+	return out, nil
+}
+
+func (c *signalingServiceClient) SubscribeSignal(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeSignalClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[4], "/protos.SignalingService/SubscribeSignal", opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/UpdateRoomProfile",
+	x := &signalingServiceSubscribeSignalClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).UpdateRoomProfile(ctx, req.(*UpdateRoomProfileParam))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return x, nil
 }
 
-func _RoomManagementService_AddUserToRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserRoomParam)
-	if err := dec(in); err != nil {
+type SignalingService_SubscribeSignalClient interface {
+	Recv() (*Signal, error)
+	grpc.ClientStream
+}
+
+type signalingServiceSubscribeSignalClient struct {
+	grpc.ClientStream
+}
+
+func (x *signalingServiceSubscribeSignalClient) Recv() (*Signal, error) {
+	m := new(Signal)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).AddUserToRoom(ctx, in)
+	return m, nil
+}
+
+func (c *signalingServiceClient) Connect(ctx context.Context, opts ...grpc.CallOption) (SignalingService_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[5], "/protos.SignalingService/Connect", opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/AddUserToRoom",
+	x := &signalingServiceConnectClient{stream}
+	return x, nil
+}
+
+type SignalingService_ConnectClient interface {
+	Send(*ClientMessage) error
+	Recv() (*ServerMessage, error)
+	grpc.ClientStream
+}
+
+type signalingServiceConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *signalingServiceConnectClient) Send(m *ClientMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *signalingServiceConnectClient) Recv() (*ServerMessage, error) {
+	m := new(ServerMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).AddUserToRoom(ctx, req.(*UserRoomParam))
+	return m, nil
+}
+
+func (c *signalingServiceClient) StartCall(ctx context.Context, in *StartCallParam, opts ...grpc.CallOption) (*Call, error) {
+	out := new(Call)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/StartCall", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _RoomManagementService_KickUserFromRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserRoomParam)
-	if err := dec(in); err != nil {
+func (c *signalingServiceClient) AcceptCall(ctx context.Context, in *CallParam, opts ...grpc.CallOption) (*Call, error) {
+	out := new(Call)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/AcceptCall", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).KickUserFromRoom(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/KickUserFromRoom",
+	return out, nil
+}
+
+func (c *signalingServiceClient) RejectCall(ctx context.Context, in *CallParam, opts ...grpc.CallOption) (*Call, error) {
+	out := new(Call)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/RejectCall", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).KickUserFromRoom(ctx, req.(*UserRoomParam))
+	return out, nil
+}
+
+func (c *signalingServiceClient) CancelCall(ctx context.Context, in *CallParam, opts ...grpc.CallOption) (*Call, error) {
+	out := new(Call)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/CancelCall", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _RoomManagementService_DestroyRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRoomParam)
-	if err := dec(in); err != nil {
+func (c *signalingServiceClient) HangupCall(ctx context.Context, in *CallParam, opts ...grpc.CallOption) (*Call, error) {
+	out := new(Call)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/HangupCall", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RoomManagementServiceServer).DestroyRoom(ctx, in)
+	return out, nil
+}
+
+func (c *signalingServiceClient) SubscribeCallEvent(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeCallEventClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[6], "/protos.SignalingService/SubscribeCallEvent", opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.RoomManagementService/DestroyRoom",
+	x := &signalingServiceSubscribeCallEventClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomManagementServiceServer).DestroyRoom(ctx, req.(*GetRoomParam))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-var _RoomManagementService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "protos.RoomManagementService",
-	HandlerType: (*RoomManagementServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "RegisterUser",
-			Handler:    _RoomManagementService_RegisterUser_Handler,
-		},
-		{
-			MethodName: "GetUser",
-			Handler:    _RoomManagementService_GetUser_Handler,
-		},
-		{
-			MethodName: "GetUsers",
-			Handler:    _RoomManagementService_GetUsers_Handler,
-		},
-		{
-			MethodName: "GetUserAccessToken",
-			Handler:    _RoomManagementService_GetUserAccessToken_Handler,
-		},
-		{
-			MethodName: "UpdateUserProfile",
-			Handler:    _RoomManagementService_UpdateUserProfile_Handler,
-		},
-		{
-			MethodName: "RemoveUser",
-			Handler:    _RoomManagementService_RemoveUser_Handler,
-		},
-		{
-			MethodName: "CreateRoom",
-			Handler:    _RoomManagementService_CreateRoom_Handler,
-		},
-		{
-			MethodName: "GetRoom",
-			Handler:    _RoomManagementService_GetRoom_Handler,
-		},
-		{
-			MethodName: "GetRooms",
-			Handler:    _RoomManagementService_GetRooms_Handler,
-		},
-		{
-			MethodName: "UpdateRoomProfile",
-			Handler:    _RoomManagementService_UpdateRoomProfile_Handler,
-		},
-		{
-			MethodName: "AddUserToRoom",
-			Handler:    _RoomManagementService_AddUserToRoom_Handler,
-		},
-		{
-			MethodName: "KickUserFromRoom",
-			Handler:    _RoomManagementService_KickUserFromRoom_Handler,
-		},
-		{
-			MethodName: "DestroyRoom",
-			Handler:    _RoomManagementService_DestroyRoom_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "signalling.proto",
+	return x, nil
 }
 
-// SignalingServiceClient is the client API for SignalingService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SignalingServiceClient interface {
-	GetProfile(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Profile, error)
-	UpdateProfile(ctx context.Context, in *UpdateProfileParam, opts ...grpc.CallOption) (*Profile, error)
-	GetMyRooms(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Rooms, error)
-	GetRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error)
-	GetUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error)
-	OfferSessionDescription(ctx context.Context, in *SDPParam, opts ...grpc.CallOption) (*empty.Empty, error)
-	AnswerSessionDescription(ctx context.Context, in *SDPParam, opts ...grpc.CallOption) (*empty.Empty, error)
-	SubscribeSDPCommand(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeSDPCommandClient, error)
-	SubscribeRoomEvent(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeRoomEventClient, error)
-	SendICECandidate(ctx context.Context, in *ICEParam, opts ...grpc.CallOption) (*empty.Empty, error)
-	SubscribeICECandidate(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeICECandidateClient, error)
-	SubscribeOnlineStatus(ctx context.Context, opts ...grpc.CallOption) (SignalingService_SubscribeOnlineStatusClient, error)
+type SignalingService_SubscribeCallEventClient interface {
+	Recv() (*CallEvent, error)
+	grpc.ClientStream
 }
 
-type signalingServiceClient struct {
-	cc *grpc.ClientConn
+type signalingServiceSubscribeCallEventClient struct {
+	grpc.ClientStream
 }
 
-func NewSignalingServiceClient(cc *grpc.ClientConn) SignalingServiceClient {
-	return &signalingServiceClient{cc}
+func (x *signalingServiceSubscribeCallEventClient) Recv() (*CallEvent, error) {
+	m := new(CallEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func (c *signalingServiceClient) GetProfile(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Profile, error) {
-	out := new(Profile)
-	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetProfile", in, out, opts...)
+func (c *signalingServiceClient) JoinRoomCall(ctx context.Context, in *RoomCallParam, opts ...grpc.CallOption) (*RoomCall, error) {
+	out := new(RoomCall)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/JoinRoomCall", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *signalingServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileParam, opts ...grpc.CallOption) (*Profile, error) {
-	out := new(Profile)
-	err := c.cc.Invoke(ctx, "/protos.SignalingService/UpdateProfile", in, out, opts...)
+func (c *signalingServiceClient) LeaveRoomCall(ctx context.Context, in *RoomCallParam, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/LeaveRoomCall", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *signalingServiceClient) GetMyRooms(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Rooms, error) {
-	out := new(Rooms)
-	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetMyRooms", in, out, opts...)
+func (c *signalingServiceClient) SubscribeRoomCallEvent(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeRoomCallEventClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[7], "/protos.SignalingService/SubscribeRoomCallEvent", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &signalingServiceSubscribeRoomCallEventClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *signalingServiceClient) GetRoom(ctx context.Context, in *GetRoomParam, opts ...grpc.CallOption) (*Room, error) {
-	out := new(Room)
-	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetRoom", in, out, opts...)
-	if err != nil {
+type SignalingService_SubscribeRoomCallEventClient interface {
+	Recv() (*RoomCallEvent, error)
+	grpc.ClientStream
+}
+
+type signalingServiceSubscribeRoomCallEventClient struct {
+	grpc.ClientStream
+}
+
+func (x *signalingServiceSubscribeRoomCallEventClient) Recv() (*RoomCallEvent, error) {
+	m := new(RoomCallEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *signalingServiceClient) GetUser(ctx context.Context, in *GetUserParam, opts ...grpc.CallOption) (*User, error) {
-	out := new(User)
-	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetUser", in, out, opts...)
+func (c *signalingServiceClient) RefreshAccessToken(ctx context.Context, in *RefreshTokenParam, opts ...grpc.CallOption) (*UserAccessToken, error) {
+	out := new(UserAccessToken)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/RefreshAccessToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *signalingServiceClient) OfferSessionDescription(ctx context.Context, in *SDPParam, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/protos.SignalingService/OfferSessionDescription", in, out, opts...)
+func (c *signalingServiceClient) GetICEServers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ICEServers, error) {
+	out := new(ICEServers)
+	err := c.cc.Invoke(ctx, "/protos.SignalingService/GetICEServers", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *signalingServiceClient) AnswerSessionDescription(ctx context.Context, in *SDPParam, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/protos.SignalingService/AnswerSessionDescription", in, out, opts...)
-	if err != nil {
+// SignalingServiceServer is the server API for SignalingService service.
+type SignalingServiceServer interface {
+	GetProfile(context.Context, *empty.Empty) (*Profile, error)
+	UpdateProfile(context.Context, *UpdateProfileParam) (*Profile, error)
+	GetMyRooms(context.Context, *empty.Empty) (*Rooms, error)
+	GetRoom(context.Context, *GetRoomParam) (*Room, error)
+	GetUser(context.Context, *GetUserParam) (*User, error)
+	OfferSessionDescription(context.Context, *SDPParam) (*Delivery, error)
+	AnswerSessionDescription(context.Context, *SDPParam) (*Delivery, error)
+	SendSessionDescription(context.Context, *SDPParam) (*Delivery, error)
+	SubscribeSDPCommand(*empty.Empty, SignalingService_SubscribeSDPCommandServer) error
+	SubscribeRoomEvent(*empty.Empty, SignalingService_SubscribeRoomEventServer) error
+	SendICECandidate(context.Context, *ICEParam) (*Delivery, error)
+	SubscribeICECandidate(*empty.Empty, SignalingService_SubscribeICECandidateServer) error
+	SubscribeOnlineStatus(SignalingService_SubscribeOnlineStatusServer) error
+	SetPresence(context.Context, *PresenceParam) (*empty.Empty, error)
+	GetPresence(context.Context, *GetPresenceParam) (*OnlineStatuses, error)
+	GetRoomPresence(context.Context, *GetRoomParam) (*OnlineStatuses, error)
+	SendSignal(context.Context, *SignalParam) (*empty.Empty, error)
+	SubscribeSignal(*empty.Empty, SignalingService_SubscribeSignalServer) error
+	Connect(SignalingService_ConnectServer) error
+	StartCall(context.Context, *StartCallParam) (*Call, error)
+	AcceptCall(context.Context, *CallParam) (*Call, error)
+	RejectCall(context.Context, *CallParam) (*Call, error)
+	CancelCall(context.Context, *CallParam) (*Call, error)
+	HangupCall(context.Context, *CallParam) (*Call, error)
+	SubscribeCallEvent(*empty.Empty, SignalingService_SubscribeCallEventServer) error
+	JoinRoomCall(context.Context, *RoomCallParam) (*RoomCall, error)
+	LeaveRoomCall(context.Context, *RoomCallParam) (*empty.Empty, error)
+	SubscribeRoomCallEvent(*empty.Empty, SignalingService_SubscribeRoomCallEventServer) error
+	RefreshAccessToken(context.Context, *RefreshTokenParam) (*UserAccessToken, error)
+	GetICEServers(context.Context, *empty.Empty) (*ICEServers, error)
+}
+
+// UnimplementedSignalingServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedSignalingServiceServer struct {
+}
+
+func (*UnimplementedSignalingServiceServer) GetProfile(ctx context.Context, req *empty.Empty) (*Profile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (*UnimplementedSignalingServiceServer) UpdateProfile(ctx context.Context, req *UpdateProfileParam) (*Profile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfile not implemented")
+}
+func (*UnimplementedSignalingServiceServer) GetMyRooms(ctx context.Context, req *empty.Empty) (*Rooms, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyRooms not implemented")
+}
+func (*UnimplementedSignalingServiceServer) GetRoom(ctx context.Context, req *GetRoomParam) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoom not implemented")
+}
+func (*UnimplementedSignalingServiceServer) GetUser(ctx context.Context, req *GetUserParam) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (*UnimplementedSignalingServiceServer) OfferSessionDescription(ctx context.Context, req *SDPParam) (*Delivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfferSessionDescription not implemented")
+}
+func (*UnimplementedSignalingServiceServer) AnswerSessionDescription(ctx context.Context, req *SDPParam) (*Delivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerSessionDescription not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SendSessionDescription(ctx context.Context, req *SDPParam) (*Delivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendSessionDescription not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SubscribeSDPCommand(req *empty.Empty, srv SignalingService_SubscribeSDPCommandServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeSDPCommand not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SubscribeRoomEvent(req *empty.Empty, srv SignalingService_SubscribeRoomEventServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeRoomEvent not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SendICECandidate(ctx context.Context, req *ICEParam) (*Delivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendICECandidate not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SubscribeICECandidate(req *empty.Empty, srv SignalingService_SubscribeICECandidateServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeICECandidate not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SubscribeOnlineStatus(srv SignalingService_SubscribeOnlineStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOnlineStatus not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SetPresence(ctx context.Context, req *PresenceParam) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPresence not implemented")
+}
+func (*UnimplementedSignalingServiceServer) GetPresence(ctx context.Context, req *GetPresenceParam) (*OnlineStatuses, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPresence not implemented")
+}
+func (*UnimplementedSignalingServiceServer) GetRoomPresence(ctx context.Context, req *GetRoomParam) (*OnlineStatuses, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoomPresence not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SendSignal(ctx context.Context, req *SignalParam) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendSignal not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SubscribeSignal(req *empty.Empty, srv SignalingService_SubscribeSignalServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeSignal not implemented")
+}
+func (*UnimplementedSignalingServiceServer) Connect(srv SignalingService_ConnectServer) error {
+	return status.Errorf(codes.Unimplemented, "method Connect not implemented")
+}
+func (*UnimplementedSignalingServiceServer) StartCall(ctx context.Context, req *StartCallParam) (*Call, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartCall not implemented")
+}
+func (*UnimplementedSignalingServiceServer) AcceptCall(ctx context.Context, req *CallParam) (*Call, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptCall not implemented")
+}
+func (*UnimplementedSignalingServiceServer) RejectCall(ctx context.Context, req *CallParam) (*Call, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectCall not implemented")
+}
+func (*UnimplementedSignalingServiceServer) CancelCall(ctx context.Context, req *CallParam) (*Call, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCall not implemented")
+}
+func (*UnimplementedSignalingServiceServer) HangupCall(ctx context.Context, req *CallParam) (*Call, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HangupCall not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SubscribeCallEvent(req *empty.Empty, srv SignalingService_SubscribeCallEventServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeCallEvent not implemented")
+}
+func (*UnimplementedSignalingServiceServer) JoinRoomCall(ctx context.Context, req *RoomCallParam) (*RoomCall, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinRoomCall not implemented")
+}
+func (*UnimplementedSignalingServiceServer) LeaveRoomCall(ctx context.Context, req *RoomCallParam) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveRoomCall not implemented")
+}
+func (*UnimplementedSignalingServiceServer) SubscribeRoomCallEvent(req *empty.Empty, srv SignalingService_SubscribeRoomCallEventServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeRoomCallEvent not implemented")
+}
+func (*UnimplementedSignalingServiceServer) RefreshAccessToken(ctx context.Context, req *RefreshTokenParam) (*UserAccessToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAccessToken not implemented")
+}
+func (*UnimplementedSignalingServiceServer) GetICEServers(ctx context.Context, req *empty.Empty) (*ICEServers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetICEServers not implemented")
+}
+
+func RegisterSignalingServiceServer(s *grpc.Server, srv SignalingServiceServer) {
+	s.RegisterService(&_SignalingService_serviceDesc, srv)
+}
+
+func _SignalingService_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/GetProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).GetProfile(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *signalingServiceClient) SubscribeSDPCommand(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeSDPCommandClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[0], "/protos.SignalingService/SubscribeSDPCommand", opts...)
-	if err != nil {
+func _SignalingService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileParam)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &signalingServiceSubscribeSDPCommandClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).UpdateProfile(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/UpdateProfile",
 	}
-	return x, nil
-}
-
-type SignalingService_SubscribeSDPCommandClient interface {
-	Recv() (*SDP, error)
-	grpc.ClientStream
-}
-
-type signalingServiceSubscribeSDPCommandClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).UpdateProfile(ctx, req.(*UpdateProfileParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *signalingServiceSubscribeSDPCommandClient) Recv() (*SDP, error) {
-	m := new(SDP)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _SignalingService_GetMyRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).GetMyRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/GetMyRooms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).GetMyRooms(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *signalingServiceClient) SubscribeRoomEvent(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeRoomEventClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[1], "/protos.SignalingService/SubscribeRoomEvent", opts...)
-	if err != nil {
+func _SignalingService_GetRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoomParam)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &signalingServiceSubscribeRoomEventClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).GetRoom(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/GetRoom",
 	}
-	return x, nil
-}
-
-type SignalingService_SubscribeRoomEventClient interface {
-	Recv() (*RoomEvent, error)
-	grpc.ClientStream
-}
-
-type signalingServiceSubscribeRoomEventClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).GetRoom(ctx, req.(*GetRoomParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *signalingServiceSubscribeRoomEventClient) Recv() (*RoomEvent, error) {
-	m := new(RoomEvent)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _SignalingService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserParam)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).GetUser(ctx, req.(*GetUserParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *signalingServiceClient) SendICECandidate(ctx context.Context, in *ICEParam, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/protos.SignalingService/SendICECandidate", in, out, opts...)
-	if err != nil {
+func _SignalingService_OfferSessionDescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SDPParam)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).OfferSessionDescription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/OfferSessionDescription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).OfferSessionDescription(ctx, req.(*SDPParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *signalingServiceClient) SubscribeICECandidate(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SignalingService_SubscribeICECandidateClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[2], "/protos.SignalingService/SubscribeICECandidate", opts...)
-	if err != nil {
+func _SignalingService_AnswerSessionDescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SDPParam)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &signalingServiceSubscribeICECandidateClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).AnswerSessionDescription(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/AnswerSessionDescription",
 	}
-	return x, nil
-}
-
-type SignalingService_SubscribeICECandidateClient interface {
-	Recv() (*ICEOffer, error)
-	grpc.ClientStream
-}
-
-type signalingServiceSubscribeICECandidateClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).AnswerSessionDescription(ctx, req.(*SDPParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *signalingServiceSubscribeICECandidateClient) Recv() (*ICEOffer, error) {
-	m := new(ICEOffer)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _SignalingService_SendSessionDescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SDPParam)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).SendSessionDescription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/SendSessionDescription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).SendSessionDescription(ctx, req.(*SDPParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *signalingServiceClient) SubscribeOnlineStatus(ctx context.Context, opts ...grpc.CallOption) (SignalingService_SubscribeOnlineStatusClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SignalingService_serviceDesc.Streams[3], "/protos.SignalingService/SubscribeOnlineStatus", opts...)
-	if err != nil {
-		return nil, err
+func _SignalingService_SubscribeSDPCommand_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(empty.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	x := &signalingServiceSubscribeOnlineStatusClient{stream}
-	return x, nil
+	return srv.(SignalingServiceServer).SubscribeSDPCommand(m, &signalingServiceSubscribeSDPCommandServer{stream})
 }
 
-type SignalingService_SubscribeOnlineStatusClient interface {
-	Send(*Heartbeat) error
-	Recv() (*OnlineStatus, error)
-	grpc.ClientStream
+type SignalingService_SubscribeSDPCommandServer interface {
+	Send(*SDP) error
+	grpc.ServerStream
 }
 
-type signalingServiceSubscribeOnlineStatusClient struct {
-	grpc.ClientStream
+type signalingServiceSubscribeSDPCommandServer struct {
+	grpc.ServerStream
 }
 
-func (x *signalingServiceSubscribeOnlineStatusClient) Send(m *Heartbeat) error {
-	return x.ClientStream.SendMsg(m)
+func (x *signalingServiceSubscribeSDPCommandServer) Send(m *SDP) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (x *signalingServiceSubscribeOnlineStatusClient) Recv() (*OnlineStatus, error) {
-	m := new(OnlineStatus)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func _SignalingService_SubscribeRoomEvent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(empty.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return m, nil
+	return srv.(SignalingServiceServer).SubscribeRoomEvent(m, &signalingServiceSubscribeRoomEventServer{stream})
 }
 
-// SignalingServiceServer is the server API for SignalingService service.
-type SignalingServiceServer interface {
-	GetProfile(context.Context, *empty.Empty) (*Profile, error)
-	UpdateProfile(context.Context, *UpdateProfileParam) (*Profile, error)
-	GetMyRooms(context.Context, *empty.Empty) (*Rooms, error)
-	GetRoom(context.Context, *GetRoomParam) (*Room, error)
-	GetUser(context.Context, *GetUserParam) (*User, error)
-	OfferSessionDescription(context.Context, *SDPParam) (*empty.Empty, error)
-	AnswerSessionDescription(context.Context, *SDPParam) (*empty.Empty, error)
-	SubscribeSDPCommand(*empty.Empty, SignalingService_SubscribeSDPCommandServer) error
-	SubscribeRoomEvent(*empty.Empty, SignalingService_SubscribeRoomEventServer) error
-	SendICECandidate(context.Context, *ICEParam) (*empty.Empty, error)
-	SubscribeICECandidate(*empty.Empty, SignalingService_SubscribeICECandidateServer) error
-	SubscribeOnlineStatus(SignalingService_SubscribeOnlineStatusServer) error
+type SignalingService_SubscribeRoomEventServer interface {
+	Send(*RoomEvent) error
+	grpc.ServerStream
 }
 
-// UnimplementedSignalingServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedSignalingServiceServer struct {
+type signalingServiceSubscribeRoomEventServer struct {
+	grpc.ServerStream
 }
 
-func (*UnimplementedSignalingServiceServer) GetProfile(ctx context.Context, req *empty.Empty) (*Profile, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetProfile not implemented")
-}
-func (*UnimplementedSignalingServiceServer) UpdateProfile(ctx context.Context, req *UpdateProfileParam) (*Profile, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfile not implemented")
-}
-func (*UnimplementedSignalingServiceServer) GetMyRooms(ctx context.Context, req *empty.Empty) (*Rooms, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetMyRooms not implemented")
+func (x *signalingServiceSubscribeRoomEventServer) Send(m *RoomEvent) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (*UnimplementedSignalingServiceServer) GetRoom(ctx context.Context, req *GetRoomParam) (*Room, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRoom not implemented")
+
+func _SignalingService_SendICECandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ICEParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).SendICECandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/SendICECandidate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).SendICECandidate(ctx, req.(*ICEParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedSignalingServiceServer) GetUser(ctx context.Context, req *GetUserParam) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+
+func _SignalingService_SubscribeICECandidate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(empty.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SignalingServiceServer).SubscribeICECandidate(m, &signalingServiceSubscribeICECandidateServer{stream})
 }
-func (*UnimplementedSignalingServiceServer) OfferSessionDescription(ctx context.Context, req *SDPParam) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method OfferSessionDescription not implemented")
+
+type SignalingService_SubscribeICECandidateServer interface {
+	Send(*ICEOffer) error
+	grpc.ServerStream
 }
-func (*UnimplementedSignalingServiceServer) AnswerSessionDescription(ctx context.Context, req *SDPParam) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AnswerSessionDescription not implemented")
+
+type signalingServiceSubscribeICECandidateServer struct {
+	grpc.ServerStream
 }
-func (*UnimplementedSignalingServiceServer) SubscribeSDPCommand(req *empty.Empty, srv SignalingService_SubscribeSDPCommandServer) error {
-	return status.Errorf(codes.Unimplemented, "method SubscribeSDPCommand not implemented")
+
+func (x *signalingServiceSubscribeICECandidateServer) Send(m *ICEOffer) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (*UnimplementedSignalingServiceServer) SubscribeRoomEvent(req *empty.Empty, srv SignalingService_SubscribeRoomEventServer) error {
-	return status.Errorf(codes.Unimplemented, "method SubscribeRoomEvent not implemented")
+
+func _SignalingService_SubscribeOnlineStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SignalingServiceServer).SubscribeOnlineStatus(&signalingServiceSubscribeOnlineStatusServer{stream})
 }
-func (*UnimplementedSignalingServiceServer) SendICECandidate(ctx context.Context, req *ICEParam) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SendICECandidate not implemented")
+
+type SignalingService_SubscribeOnlineStatusServer interface {
+	Send(*OnlineStatus) error
+	Recv() (*Heartbeat, error)
+	grpc.ServerStream
 }
-func (*UnimplementedSignalingServiceServer) SubscribeICECandidate(req *empty.Empty, srv SignalingService_SubscribeICECandidateServer) error {
-	return status.Errorf(codes.Unimplemented, "method SubscribeICECandidate not implemented")
+
+type signalingServiceSubscribeOnlineStatusServer struct {
+	grpc.ServerStream
 }
-func (*UnimplementedSignalingServiceServer) SubscribeOnlineStatus(srv SignalingService_SubscribeOnlineStatusServer) error {
-	return status.Errorf(codes.Unimplemented, "method SubscribeOnlineStatus not implemented")
+
+func (x *signalingServiceSubscribeOnlineStatusServer) Send(m *OnlineStatus) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func RegisterSignalingServiceServer(s *grpc.Server, srv SignalingServiceServer) {
-	s.RegisterService(&_SignalingService_serviceDesc, srv)
+func (x *signalingServiceSubscribeOnlineStatusServer) Recv() (*Heartbeat, error) {
+	m := new(Heartbeat)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func _SignalingService_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _SignalingService_SetPresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PresenceParam)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SignalingServiceServer).GetProfile(ctx, in)
+		return srv.(SignalingServiceServer).SetPresence(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/protos.SignalingService/GetProfile",
+		FullMethod: "/protos.SignalingService/SetPresence",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SignalingServiceServer).GetProfile(ctx, req.(*empty.Empty))
+		return srv.(SignalingServiceServer).SetPresence(ctx, req.(*PresenceParam))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SignalingService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateProfileParam)
+func _SignalingService_GetPresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPresenceParam)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SignalingServiceServer).UpdateProfile(ctx, in)
+		return srv.(SignalingServiceServer).GetPresence(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/protos.SignalingService/UpdateProfile",
+		FullMethod: "/protos.SignalingService/GetPresence",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SignalingServiceServer).UpdateProfile(ctx, req.(*UpdateProfileParam))
+		return srv.(SignalingServiceServer).GetPresence(ctx, req.(*GetPresenceParam))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SignalingService_GetMyRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _SignalingService_GetRoomPresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoomParam)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SignalingServiceServer).GetMyRooms(ctx, in)
+		return srv.(SignalingServiceServer).GetRoomPresence(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/protos.SignalingService/GetMyRooms",
+		FullMethod: "/protos.SignalingService/GetRoomPresence",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SignalingServiceServer).GetMyRooms(ctx, req.(*empty.Empty))
+		return srv.(SignalingServiceServer).GetRoomPresence(ctx, req.(*GetRoomParam))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SignalingService_GetRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRoomParam)
+func _SignalingService_SendSignal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignalParam)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SignalingServiceServer).GetRoom(ctx, in)
+		return srv.(SignalingServiceServer).SendSignal(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/protos.SignalingService/GetRoom",
+		FullMethod: "/protos.SignalingService/SendSignal",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SignalingServiceServer).GetRoom(ctx, req.(*GetRoomParam))
+		return srv.(SignalingServiceServer).SendSignal(ctx, req.(*SignalParam))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SignalingService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserParam)
+func _SignalingService_SubscribeSignal_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(empty.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SignalingServiceServer).SubscribeSignal(m, &signalingServiceSubscribeSignalServer{stream})
+}
+
+type SignalingService_SubscribeSignalServer interface {
+	Send(*Signal) error
+	grpc.ServerStream
+}
+
+type signalingServiceSubscribeSignalServer struct {
+	grpc.ServerStream
+}
+
+func (x *signalingServiceSubscribeSignalServer) Send(m *Signal) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SignalingService_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SignalingServiceServer).Connect(&signalingServiceConnectServer{stream})
+}
+
+type SignalingService_ConnectServer interface {
+	Send(*ServerMessage) error
+	Recv() (*ClientMessage, error)
+	grpc.ServerStream
+}
+
+type signalingServiceConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *signalingServiceConnectServer) Send(m *ServerMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *signalingServiceConnectServer) Recv() (*ClientMessage, error) {
+	m := new(ClientMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SignalingService_StartCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartCallParam)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SignalingServiceServer).GetUser(ctx, in)
+		return srv.(SignalingServiceServer).StartCall(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/protos.SignalingService/GetUser",
+		FullMethod: "/protos.SignalingService/StartCall",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SignalingServiceServer).GetUser(ctx, req.(*GetUserParam))
+		return srv.(SignalingServiceServer).StartCall(ctx, req.(*StartCallParam))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SignalingService_OfferSessionDescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SDPParam)
+func _SignalingService_AcceptCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallParam)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SignalingServiceServer).OfferSessionDescription(ctx, in)
+		return srv.(SignalingServiceServer).AcceptCall(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/protos.SignalingService/OfferSessionDescription",
+		FullMethod: "/protos.SignalingService/AcceptCall",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SignalingServiceServer).OfferSessionDescription(ctx, req.(*SDPParam))
+		return srv.(SignalingServiceServer).AcceptCall(ctx, req.(*CallParam))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SignalingService_AnswerSessionDescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SDPParam)
+func _SignalingService_RejectCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallParam)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SignalingServiceServer).AnswerSessionDescription(ctx, in)
+		return srv.(SignalingServiceServer).RejectCall(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/protos.SignalingService/AnswerSessionDescription",
+		FullMethod: "/protos.SignalingService/RejectCall",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SignalingServiceServer).AnswerSessionDescription(ctx, req.(*SDPParam))
+		return srv.(SignalingServiceServer).RejectCall(ctx, req.(*CallParam))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SignalingService_SubscribeSDPCommand_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(empty.Empty)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _SignalingService_CancelCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallParam)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(SignalingServiceServer).SubscribeSDPCommand(m, &signalingServiceSubscribeSDPCommandServer{stream})
-}
-
-type SignalingService_SubscribeSDPCommandServer interface {
-	Send(*SDP) error
-	grpc.ServerStream
-}
-
-type signalingServiceSubscribeSDPCommandServer struct {
-	grpc.ServerStream
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).CancelCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/CancelCall",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).CancelCall(ctx, req.(*CallParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *signalingServiceSubscribeSDPCommandServer) Send(m *SDP) error {
-	return x.ServerStream.SendMsg(m)
+func _SignalingService_HangupCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).HangupCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/HangupCall",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).HangupCall(ctx, req.(*CallParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _SignalingService_SubscribeRoomEvent_Handler(srv interface{}, stream grpc.ServerStream) error {
+func _SignalingService_SubscribeCallEvent_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(empty.Empty)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(SignalingServiceServer).SubscribeRoomEvent(m, &signalingServiceSubscribeRoomEventServer{stream})
+	return srv.(SignalingServiceServer).SubscribeCallEvent(m, &signalingServiceSubscribeCallEventServer{stream})
 }
 
-type SignalingService_SubscribeRoomEventServer interface {
-	Send(*RoomEvent) error
+type SignalingService_SubscribeCallEventServer interface {
+	Send(*CallEvent) error
 	grpc.ServerStream
 }
 
-type signalingServiceSubscribeRoomEventServer struct {
+type signalingServiceSubscribeCallEventServer struct {
 	grpc.ServerStream
 }
 
-func (x *signalingServiceSubscribeRoomEventServer) Send(m *RoomEvent) error {
+func (x *signalingServiceSubscribeCallEventServer) Send(m *CallEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _SignalingService_SendICECandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ICEParam)
+func _SignalingService_JoinRoomCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomCallParam)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SignalingServiceServer).SendICECandidate(ctx, in)
+		return srv.(SignalingServiceServer).JoinRoomCall(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/protos.SignalingService/SendICECandidate",
+		FullMethod: "/protos.SignalingService/JoinRoomCall",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SignalingServiceServer).SendICECandidate(ctx, req.(*ICEParam))
+		return srv.(SignalingServiceServer).JoinRoomCall(ctx, req.(*RoomCallParam))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SignalingService_SubscribeICECandidate_Handler(srv interface{}, stream grpc.ServerStream) error {
+func _SignalingService_LeaveRoomCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomCallParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).LeaveRoomCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/LeaveRoomCall",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).LeaveRoomCall(ctx, req.(*RoomCallParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SignalingService_SubscribeRoomCallEvent_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(empty.Empty)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(SignalingServiceServer).SubscribeICECandidate(m, &signalingServiceSubscribeICECandidateServer{stream})
+	return srv.(SignalingServiceServer).SubscribeRoomCallEvent(m, &signalingServiceSubscribeRoomCallEventServer{stream})
 }
 
-type SignalingService_SubscribeICECandidateServer interface {
-	Send(*ICEOffer) error
+type SignalingService_SubscribeRoomCallEventServer interface {
+	Send(*RoomCallEvent) error
 	grpc.ServerStream
 }
 
-type signalingServiceSubscribeICECandidateServer struct {
+type signalingServiceSubscribeRoomCallEventServer struct {
 	grpc.ServerStream
 }
 
-func (x *signalingServiceSubscribeICECandidateServer) Send(m *ICEOffer) error {
+func (x *signalingServiceSubscribeRoomCallEventServer) Send(m *RoomCallEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _SignalingService_SubscribeOnlineStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(SignalingServiceServer).SubscribeOnlineStatus(&signalingServiceSubscribeOnlineStatusServer{stream})
-}
-
-type SignalingService_SubscribeOnlineStatusServer interface {
-	Send(*OnlineStatus) error
-	Recv() (*Heartbeat, error)
-	grpc.ServerStream
-}
-
-type signalingServiceSubscribeOnlineStatusServer struct {
-	grpc.ServerStream
-}
-
-func (x *signalingServiceSubscribeOnlineStatusServer) Send(m *OnlineStatus) error {
-	return x.ServerStream.SendMsg(m)
+func _SignalingService_RefreshAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).RefreshAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/RefreshAccessToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).RefreshAccessToken(ctx, req.(*RefreshTokenParam))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *signalingServiceSubscribeOnlineStatusServer) Recv() (*Heartbeat, error) {
-	m := new(Heartbeat)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _SignalingService_GetICEServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(SignalingServiceServer).GetICEServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.SignalingService/GetICEServers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignalingServiceServer).GetICEServers(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 var _SignalingService_serviceDesc = grpc.ServiceDesc{
@@ -2771,10 +5308,66 @@ var _SignalingService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AnswerSessionDescription",
 			Handler:    _SignalingService_AnswerSessionDescription_Handler,
 		},
+		{
+			MethodName: "SendSessionDescription",
+			Handler:    _SignalingService_SendSessionDescription_Handler,
+		},
 		{
 			MethodName: "SendICECandidate",
 			Handler:    _SignalingService_SendICECandidate_Handler,
 		},
+		{
+			MethodName: "SetPresence",
+			Handler:    _SignalingService_SetPresence_Handler,
+		},
+		{
+			MethodName: "GetPresence",
+			Handler:    _SignalingService_GetPresence_Handler,
+		},
+		{
+			MethodName: "GetRoomPresence",
+			Handler:    _SignalingService_GetRoomPresence_Handler,
+		},
+		{
+			MethodName: "SendSignal",
+			Handler:    _SignalingService_SendSignal_Handler,
+		},
+		{
+			MethodName: "StartCall",
+			Handler:    _SignalingService_StartCall_Handler,
+		},
+		{
+			MethodName: "AcceptCall",
+			Handler:    _SignalingService_AcceptCall_Handler,
+		},
+		{
+			MethodName: "RejectCall",
+			Handler:    _SignalingService_RejectCall_Handler,
+		},
+		{
+			MethodName: "CancelCall",
+			Handler:    _SignalingService_CancelCall_Handler,
+		},
+		{
+			MethodName: "HangupCall",
+			Handler:    _SignalingService_HangupCall_Handler,
+		},
+		{
+			MethodName: "JoinRoomCall",
+			Handler:    _SignalingService_JoinRoomCall_Handler,
+		},
+		{
+			MethodName: "LeaveRoomCall",
+			Handler:    _SignalingService_LeaveRoomCall_Handler,
+		},
+		{
+			MethodName: "RefreshAccessToken",
+			Handler:    _SignalingService_RefreshAccessToken_Handler,
+		},
+		{
+			MethodName: "GetICEServers",
+			Handler:    _SignalingService_GetICEServers_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2798,6 +5391,27 @@ var _SignalingService_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "SubscribeSignal",
+			Handler:       _SignalingService_SubscribeSignal_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Connect",
+			Handler:       _SignalingService_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeCallEvent",
+			Handler:       _SignalingService_SubscribeCallEvent_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeRoomCallEvent",
+			Handler:       _SignalingService_SubscribeRoomCallEvent_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "signalling.proto",
 }